@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// horizon-gen generates the Go source adding the components defined in
+// Kubernetes YAML or JSON manifests to a Horizon deployer
+//
+//	horizon-gen -package myapp -function AddMyApp -o components.go deployment.yaml service.yaml
+//
+// The manifests are read from stdin when no file is given
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/codegen"
+)
+
+func main() {
+	pkg := flag.String("package", "main", "package of the generated file")
+	function := flag.String("function", "AddComponents", "name of the generated function")
+	output := flag.String("o", "", "file to write the generated source to, defaults to stdout")
+	flag.Parse()
+
+	if err := run(codegen.Config{Package: *pkg, Function: *function}, *output, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "horizon-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(config codegen.Config, output string, files []string) error {
+	var r io.Reader = os.Stdin
+	if len(files) > 0 {
+		readers := []io.Reader{}
+		for _, name := range files {
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			readers = append(readers, strings.NewReader("\n---\n"), strings.NewReader(string(data)))
+		}
+		r = io.MultiReader(readers...)
+	}
+
+	result, err := codegen.Generate(r, config)
+	if err != nil {
+		return err
+	}
+	for _, field := range result.Unsupported {
+		fmt.Fprintf(os.Stderr, "horizon-gen: not generated: %s\n", field)
+	}
+
+	if len(output) == 0 {
+		_, err = os.Stdout.Write(result.Source)
+		return err
+	}
+	return ioutil.WriteFile(output, result.Source, 0644)
+}
@@ -31,6 +31,33 @@ const (
 	ActionTypeTCP
 )
 
+var actionTypeText = enumText{"ActionType", []string{
+	"Exec",
+	"HTTP",
+	"HTTPS",
+	"TCP",
+}}
+
+// String returns the Kubernetes spelling of the action type
+func (a ActionType) String() string {
+	return actionTypeText.name(int(a))
+}
+
+// MarshalText encodes the action type with its Kubernetes spelling
+func (a ActionType) MarshalText() ([]byte, error) {
+	return actionTypeText.marshal(int(a))
+}
+
+// UnmarshalText decodes a action type from its Kubernetes spelling
+func (a *ActionType) UnmarshalText(text []byte) error {
+	value, err := actionTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*a = ActionType(value)
+	return nil
+}
+
 // ActionConfig defines the configuration for an action (ie on-start, pre-stop)
 type ActionConfig struct {
 	Type    ActionType
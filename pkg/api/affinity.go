@@ -44,6 +44,31 @@ const (
 	AffinitySoft
 )
 
+var affinityTypeText = enumText{"AffinityType", []string{
+	"Hard",
+	"Soft",
+}}
+
+// String returns the Kubernetes spelling of the affinity type
+func (a AffinityType) String() string {
+	return affinityTypeText.name(int(a))
+}
+
+// MarshalText encodes the affinity type with its Kubernetes spelling
+func (a AffinityType) MarshalText() ([]byte, error) {
+	return affinityTypeText.marshal(int(a))
+}
+
+// UnmarshalText decodes a affinity type from its Kubernetes spelling
+func (a *AffinityType) UnmarshalText(text []byte) error {
+	value, err := affinityTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*a = AffinityType(value)
+	return nil
+}
+
 // NodeExpression defines the configuration for a node expresion
 type NodeExpression struct {
 	Key    string
@@ -62,3 +87,32 @@ const (
 	NodeOperatorGt
 	NodeOperatorLt
 )
+
+var nodeOperatorText = enumText{"NodeOperator", []string{
+	"In",
+	"NotIn",
+	"Exists",
+	"DoesNotExist",
+	"Gt",
+	"Lt",
+}}
+
+// String returns the Kubernetes spelling of the node operator
+func (n NodeOperator) String() string {
+	return nodeOperatorText.name(int(n))
+}
+
+// MarshalText encodes the node operator with its Kubernetes spelling
+func (n NodeOperator) MarshalText() ([]byte, error) {
+	return nodeOperatorText.marshal(int(n))
+}
+
+// UnmarshalText decodes a node operator from its Kubernetes spelling
+func (n *NodeOperator) UnmarshalText(text []byte) error {
+	value, err := nodeOperatorText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*n = NodeOperator(value)
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+// AppConfig defines an application running a single container
+type AppConfig struct {
+	Name      string
+	Namespace string
+	// Instance distinguishes installations of the application, defaults to Name
+	Instance string
+	Version  string
+	PartOf   string
+
+	Image      string
+	PullPolicy PullPolicyType
+	Command    []string
+	Args       []string
+	MinCPU     string
+	MaxCPU     string
+	MinMem     string
+	MaxMem     string
+	Ports      []AppPortConfig
+	Env        []EnvConfig
+
+	// ConfigFiles maps the paths of files in the container to their content,
+	// which is stored in a config map
+	ConfigFiles map[string]string
+	// SecretEnv maps environment variables to their values, which are stored
+	// in a secret
+	SecretEnv map[string]string
+	// SecretFiles maps the paths of files in the container to their content,
+	// which is stored in a secret
+	SecretFiles map[string]string
+	Storage     []AppStorageConfig
+
+	// Stateful runs the application in a stateful set, which creates a claim
+	// for each storage in every pod, instead of a deployment.  The stateful set
+	// is governed by a headless service named after the application with a
+	// -headless suffix
+	Stateful bool
+	Expose   AppExposeConfig
+	Scaling  AppScalingConfig
+}
+
+// AppPortConfig defines a port of the container, which the service of the
+// application exposes with the same number
+type AppPortConfig struct {
+	Name     string
+	Port     int32
+	Protocol ProtocolType
+}
+
+// AppStorageConfig defines a persistent volume mounted in the container
+type AppStorageConfig struct {
+	Name      string
+	MountPath string
+	Size      string
+	Class     *string
+}
+
+// AppExposeConfig defines how the ports of the application are exposed
+type AppExposeConfig struct {
+	Type ServiceType
+	// Host creates an ingress routing the requests for the host to the service
+	Host string
+	Path string
+	// Port is the name of the port the ingress routes to, defaults to the first port
+	Port      string
+	TLSSecret string
+}
+
+// AppScalingConfig defines the number of pods running the application.  An
+// autoscaler is created if MaxReplicas is set
+type AppScalingConfig struct {
+	Replicas                       *int32
+	MinReplicas                    *int32
+	MaxReplicas                    int32
+	TargetCPUUtilizationPercentage *int32
+}
@@ -45,4 +45,6 @@ const (
 	IngressComponent                 ComponentType = "Ingress"
 	StatefulSetComponent             ComponentType = "StatefulSetIngress"
 	DaemonSetComponent               ComponentType = "DaemonSetIngress"
+	PodDisruptionBudgetComponent     ComponentType = "PodDisruptionBudget"
+	NetworkPolicyComponent           ComponentType = "NetworkPolicy"
 )
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+// Constants maps the name of the constants of the api enums to their value.  It
+// is used by the tools reading or writing Horizon definitions to refer to the
+// constants by name
+var Constants = map[string]interface{}{
+	"DeploymentComponent":              DeploymentComponent,
+	"PodComponent":                     PodComponent,
+	"ConfigMapComponent":               ConfigMapComponent,
+	"SecretComponent":                  SecretComponent,
+	"ServiceComponent":                 ServiceComponent,
+	"ServiceAccountComponent":          ServiceAccountComponent,
+	"ClusterRoleComponent":             ClusterRoleComponent,
+	"ClusterRoleBindingComponent":      ClusterRoleBindingComponent,
+	"RoleComponent":                    RoleComponent,
+	"RoleBindingComponent":             RoleBindingComponent,
+	"ReplicationControllerComponent":   ReplicationControllerComponent,
+	"CRDComponent":                     CRDComponent,
+	"NamespaceComponent":               NamespaceComponent,
+	"PersistentVolumeClaimComponent":   PersistentVolumeClaimComponent,
+	"JobComponent":                     JobComponent,
+	"HorizontalPodAutoscalerComponent": HorizontalPodAutoscalerComponent,
+	"IngressComponent":                 IngressComponent,
+	"StatefulSetComponent":             StatefulSetComponent,
+	"DaemonSetComponent":               DaemonSetComponent,
+	"PodDisruptionBudgetComponent":     PodDisruptionBudgetComponent,
+	"NetworkPolicyComponent":           NetworkPolicyComponent,
+
+	"DeploymentStrategyTypeRecreate":      DeploymentStrategyTypeRecreate,
+	"DeploymentStrategyTypeRollingUpdate": DeploymentStrategyTypeRollingUpdate,
+
+	"StatefulSetUpdateStrategyRollingUpdate": StatefulSetUpdateStrategyRollingUpdate,
+	"StatefulSetUpdateStrategyOnDelete":      StatefulSetUpdateStrategyOnDelete,
+	"PodManagementPolicyOrdered":             PodManagementPolicyOrdered,
+	"PodManagementPolicyParallel":            PodManagementPolicyParallel,
+
+	"DaemonSetUpdateStrategyRollingUpdate": DaemonSetUpdateStrategyRollingUpdate,
+	"DaemonSetUpdateStrategyOnDelete":      DaemonSetUpdateStrategyOnDelete,
+
+	"DNSClusterFirstWithHostNet": DNSClusterFirstWithHostNet,
+	"DNSClusterFirst":            DNSClusterFirst,
+	"DNSDefault":                 DNSDefault,
+	"RestartPolicyAlways":        RestartPolicyAlways,
+	"RestartPolicyOnFailure":     RestartPolicyOnFailure,
+	"RestartPolicyNever":         RestartPolicyNever,
+	"HostModeNet":                HostModeNet,
+	"HostModePID":                HostModePID,
+	"HostModeIPC":                HostModeIPC,
+
+	"TolerationEffectNoSchedule":       TolerationEffectNoSchedule,
+	"TolerationEffectPreferNoSchedule": TolerationEffectPreferNoSchedule,
+	"TolerationEffectNoExecute":        TolerationEffectNoExecute,
+	"TolerationOpExists":               TolerationOpExists,
+	"TolerationOpEqual":                TolerationOpEqual,
+
+	"PullAlways":                              PullAlways,
+	"PullNever":                               PullNever,
+	"PullIfNotPresent":                        PullIfNotPresent,
+	"MountPropagationHostToContainer":         MountPropagationHostToContainer,
+	"MountPropagationBidirectional":           MountPropagationBidirectional,
+	"MountPropagationNone":                    MountPropagationNone,
+	"TerminationMessageReadFile":              TerminationMessageReadFile,
+	"TerminationMessageFallbackToLogsOnError": TerminationMessageFallbackToLogsOnError,
+	"ProcMountTypeDefault":                    ProcMountTypeDefault,
+	"ProcMountTypeUmasked":                    ProcMountTypeUmasked,
+
+	"ActionTypeCommand": ActionTypeCommand,
+	"ActionTypeHTTP":    ActionTypeHTTP,
+	"ActionTypeHTTPS":   ActionTypeHTTPS,
+	"ActionTypeTCP":     ActionTypeTCP,
+
+	"EnvVal":                          EnvVal,
+	"EnvFromConfigMap":                EnvFromConfigMap,
+	"EnvFromSecret":                   EnvFromSecret,
+	"EnvFromCPULimits":                EnvFromCPULimits,
+	"EnvFromMemLimits":                EnvFromMemLimits,
+	"EnvFromEphemeralStorageLimits":   EnvFromEphemeralStorageLimits,
+	"EnvFromCPURequests":              EnvFromCPURequests,
+	"EnvFromMemRequests":              EnvFromMemRequests,
+	"EnvFromEphemeralStorageRequests": EnvFromEphemeralStorageRequests,
+	"EnvFromName":                     EnvFromName,
+	"EnvFromNamespace":                EnvFromNamespace,
+	"EnvFromLabels":                   EnvFromLabels,
+	"EnvFromAnnotation":               EnvFromAnnotation,
+	"EnvFromNodename":                 EnvFromNodename,
+	"EnvFromServiceAccountName":       EnvFromServiceAccountName,
+	"EnvFromHostIP":                   EnvFromHostIP,
+	"EnvFromPodIP":                    EnvFromPodIP,
+
+	"ProtocolTCP":  ProtocolTCP,
+	"ProtocolUDP":  ProtocolUDP,
+	"ProtocolSCTP": ProtocolSCTP,
+
+	"StorageMediumDefault":      StorageMediumDefault,
+	"StorageMediumMemory":       StorageMediumMemory,
+	"StorageMediumHugePages":    StorageMediumHugePages,
+	"HostPathUnset":             HostPathUnset,
+	"HostPathDirectoryOrCreate": HostPathDirectoryOrCreate,
+	"HostPathDirectory":         HostPathDirectory,
+	"HostPathFileOrCreate":      HostPathFileOrCreate,
+	"HostPathFile":              HostPathFile,
+	"HostPathSocket":            HostPathSocket,
+	"HostPathCharDev":           HostPathCharDev,
+	"HostPathBlockDev":          HostPathBlockDev,
+
+	"ServiceTypeServiceIP":        ServiceTypeServiceIP,
+	"ServiceTypeNodePort":         ServiceTypeNodePort,
+	"ServiceTypeLoadBalancer":     ServiceTypeLoadBalancer,
+	"ServiceTypeExternalName":     ServiceTypeExternalName,
+	"ServiceTrafficPolicyLocal":   ServiceTrafficPolicyLocal,
+	"ServiceTrafficPolicyCluster": ServiceTrafficPolicyCluster,
+	"ServiceAffinityTypeClientIP": ServiceAffinityTypeClientIP,
+	"ServiceAffinityTypeNone":     ServiceAffinityTypeNone,
+
+	"SecretTypeOpaque":              SecretTypeOpaque,
+	"SecretTypeServiceAccountToken": SecretTypeServiceAccountToken,
+	"SecretTypeDockercfg":           SecretTypeDockercfg,
+	"SecretTypeDockerConfigJSON":    SecretTypeDockerConfigJSON,
+	"SecretTypeBasicAuth":           SecretTypeBasicAuth,
+	"SecretTypeSSHAuth":             SecretTypeSSHAuth,
+	"SecretTypeTLS":                 SecretTypeTLS,
+	"SecretTypeBootstrapToken":      SecretTypeBootstrapToken,
+
+	"ReadWriteOnce":      ReadWriteOnce,
+	"ReadOnlyMany":       ReadOnlyMany,
+	"ReadWriteMany":      ReadWriteMany,
+	"PVCModeBLock":       PVCModeBLock,
+	"PVCModeFilesystem":  PVCModeFilesystem,
+	"CRDClusterScoped":   CRDClusterScoped,
+	"CRDNamespaceScoped": CRDNamespaceScoped,
+
+	"CRDConversionStraegyTypeNone":    CRDConversionStraegyTypeNone,
+	"CRDConversionStraegyTypeWebhook": CRDConversionStraegyTypeWebhook,
+
+	"ExpressionRequirementOpIn":           ExpressionRequirementOpIn,
+	"ExpressionRequirementOpNotIn":        ExpressionRequirementOpNotIn,
+	"ExpressionRequirementOpExists":       ExpressionRequirementOpExists,
+	"ExpressionRequirementOpDoesNotExist": ExpressionRequirementOpDoesNotExist,
+}
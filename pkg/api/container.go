@@ -57,6 +57,32 @@ const (
 	PullIfNotPresent
 )
 
+var pullPolicyTypeText = enumText{"PullPolicyType", []string{
+	"Always",
+	"Never",
+	"IfNotPresent",
+}}
+
+// String returns the Kubernetes spelling of the pull policy
+func (p PullPolicyType) String() string {
+	return pullPolicyTypeText.name(int(p))
+}
+
+// MarshalText encodes the pull policy with its Kubernetes spelling
+func (p PullPolicyType) MarshalText() ([]byte, error) {
+	return pullPolicyTypeText.marshal(int(p))
+}
+
+// UnmarshalText decodes a pull policy from its Kubernetes spelling
+func (p *PullPolicyType) UnmarshalText(text []byte) error {
+	value, err := pullPolicyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*p = PullPolicyType(value)
+	return nil
+}
+
 // PortConfig defines the configuration for a port
 type PortConfig struct {
 	Name          string
@@ -85,6 +111,32 @@ const (
 	MountPropagationNone
 )
 
+var mountPropagationTypeText = enumText{"MountPropagationType", []string{
+	"HostToContainer",
+	"Bidirectional",
+	"None",
+}}
+
+// String returns the Kubernetes spelling of the mount propagation
+func (m MountPropagationType) String() string {
+	return mountPropagationTypeText.name(int(m))
+}
+
+// MarshalText encodes the mount propagation with its Kubernetes spelling
+func (m MountPropagationType) MarshalText() ([]byte, error) {
+	return mountPropagationTypeText.marshal(int(m))
+}
+
+// UnmarshalText decodes a mount propagation from its Kubernetes spelling
+func (m *MountPropagationType) UnmarshalText(text []byte) error {
+	value, err := mountPropagationTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*m = MountPropagationType(value)
+	return nil
+}
+
 // ProbeConfig defines the configuration for a probe
 type ProbeConfig struct {
 	ActionConfig
@@ -103,6 +155,31 @@ const (
 	TerminationMessageFallbackToLogsOnError
 )
 
+var terminationMessagePolicyTypeText = enumText{"TerminationMessagePolicyType", []string{
+	"File",
+	"FallbackToLogsOnError",
+}}
+
+// String returns the Kubernetes spelling of the termination message policy
+func (t TerminationMessagePolicyType) String() string {
+	return terminationMessagePolicyTypeText.name(int(t))
+}
+
+// MarshalText encodes the termination message policy with its Kubernetes spelling
+func (t TerminationMessagePolicyType) MarshalText() ([]byte, error) {
+	return terminationMessagePolicyTypeText.marshal(int(t))
+}
+
+// UnmarshalText decodes a termination message policy from its Kubernetes spelling
+func (t *TerminationMessagePolicyType) UnmarshalText(text []byte) error {
+	value, err := terminationMessagePolicyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*t = TerminationMessagePolicyType(value)
+	return nil
+}
+
 // VolumeDeviceConfig defines the configuration for a volume device
 type VolumeDeviceConfig struct {
 	Name string
@@ -116,3 +193,28 @@ const (
 	ProcMountTypeDefault ProcMountType = iota + 1
 	ProcMountTypeUmasked
 )
+
+var procMountTypeText = enumText{"ProcMountType", []string{
+	"Default",
+	"Unmasked",
+}}
+
+// String returns the Kubernetes spelling of the proc mount type
+func (p ProcMountType) String() string {
+	return procMountTypeText.name(int(p))
+}
+
+// MarshalText encodes the proc mount type with its Kubernetes spelling
+func (p ProcMountType) MarshalText() ([]byte, error) {
+	return procMountTypeText.marshal(int(p))
+}
+
+// UnmarshalText decodes a proc mount type from its Kubernetes spelling
+func (p *ProcMountType) UnmarshalText(text []byte) error {
+	value, err := procMountTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*p = ProcMountType(value)
+	return nil
+}
@@ -61,6 +61,31 @@ const (
 	CRDNamespaceScoped
 )
 
+var crdScopeTypeText = enumText{"CRDScopeType", []string{
+	"Cluster",
+	"Namespaced",
+}}
+
+// String returns the Kubernetes spelling of the CRD scope
+func (c CRDScopeType) String() string {
+	return crdScopeTypeText.name(int(c))
+}
+
+// MarshalText encodes the CRD scope with its Kubernetes spelling
+func (c CRDScopeType) MarshalText() ([]byte, error) {
+	return crdScopeTypeText.marshal(int(c))
+}
+
+// UnmarshalText decodes a CRD scope from its Kubernetes spelling
+func (c *CRDScopeType) UnmarshalText(text []byte) error {
+	value, err := crdScopeTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*c = CRDScopeType(value)
+	return nil
+}
+
 // CRDVersion defines a version for a custom resource definition
 type CRDVersion struct {
 	Name              string
@@ -95,3 +120,28 @@ const (
 	CRDConversionStraegyTypeNone CRDConversionStraegyType = iota + 1
 	CRDConversionStraegyTypeWebhook
 )
+
+var crdConversionStraegyTypeText = enumText{"CRDConversionStraegyType", []string{
+	"None",
+	"Webhook",
+}}
+
+// String returns the Kubernetes spelling of the CRD conversion strategy
+func (c CRDConversionStraegyType) String() string {
+	return crdConversionStraegyTypeText.name(int(c))
+}
+
+// MarshalText encodes the CRD conversion strategy with its Kubernetes spelling
+func (c CRDConversionStraegyType) MarshalText() ([]byte, error) {
+	return crdConversionStraegyTypeText.marshal(int(c))
+}
+
+// UnmarshalText decodes a CRD conversion strategy from its Kubernetes spelling
+func (c *CRDConversionStraegyType) UnmarshalText(text []byte) error {
+	value, err := crdConversionStraegyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*c = CRDConversionStraegyType(value)
+	return nil
+}
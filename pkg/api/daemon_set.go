@@ -40,3 +40,28 @@ const (
 	DaemonSetUpdateStrategyRollingUpdate DaemonSetUpdateStrategyType = iota + 1
 	DaemonSetUpdateStrategyOnDelete
 )
+
+var daemonSetUpdateStrategyTypeText = enumText{"DaemonSetUpdateStrategyType", []string{
+	"RollingUpdate",
+	"OnDelete",
+}}
+
+// String returns the Kubernetes spelling of the update strategy
+func (d DaemonSetUpdateStrategyType) String() string {
+	return daemonSetUpdateStrategyTypeText.name(int(d))
+}
+
+// MarshalText encodes the update strategy with its Kubernetes spelling
+func (d DaemonSetUpdateStrategyType) MarshalText() ([]byte, error) {
+	return daemonSetUpdateStrategyTypeText.marshal(int(d))
+}
+
+// UnmarshalText decodes a update strategy from its Kubernetes spelling
+func (d *DaemonSetUpdateStrategyType) UnmarshalText(text []byte) error {
+	value, err := daemonSetUpdateStrategyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*d = DaemonSetUpdateStrategyType(value)
+	return nil
+}
@@ -22,6 +22,8 @@ under the License.
 package api
 
 import (
+	"context"
+
 	extensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -31,8 +33,8 @@ import (
 
 // DeployerResources defines the resources the deployer will provide
 type DeployerResources struct {
-	KubeClient           *kubernetes.Clientset
-	KubeExtensionsClient *extensionsclient.Clientset
+	KubeClient           kubernetes.Interface
+	KubeExtensionsClient extensionsclient.Interface
 }
 
 // DeployerControllerInterface defines the interface for controllers
@@ -45,6 +47,6 @@ type DeployerControllerInterface interface {
 type DeployableComponentInterface interface {
 	runtime.Object
 	GetName() string
-	Deploy(DeployerResources) error
-	Undeploy(DeployerResources) error
+	Deploy(context.Context, DeployerResources) error
+	Undeploy(context.Context, DeployerResources) error
 }
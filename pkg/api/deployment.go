@@ -43,3 +43,28 @@ const (
 	DeploymentStrategyTypeRecreate DeploymentStrategyType = iota + 1
 	DeploymentStrategyTypeRollingUpdate
 )
+
+var deploymentStrategyTypeText = enumText{"DeploymentStrategyType", []string{
+	"Recreate",
+	"RollingUpdate",
+}}
+
+// String returns the Kubernetes spelling of the deployment strategy
+func (d DeploymentStrategyType) String() string {
+	return deploymentStrategyTypeText.name(int(d))
+}
+
+// MarshalText encodes the deployment strategy with its Kubernetes spelling
+func (d DeploymentStrategyType) MarshalText() ([]byte, error) {
+	return deploymentStrategyTypeText.marshal(int(d))
+}
+
+// UnmarshalText decodes a deployment strategy from its Kubernetes spelling
+func (d *DeploymentStrategyType) UnmarshalText(text []byte) error {
+	value, err := deploymentStrategyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*d = DeploymentStrategyType(value)
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+)
+
+// enumText holds the text of the values of an int enum, the names being
+// indexed from the first value.  The zero value, which leaves a setting to its
+// default, has an empty text
+type enumText struct {
+	typeName string
+	names    []string
+}
+
+func (e enumText) name(value int) string {
+	if value < 1 || value > len(e.names) {
+		return fmt.Sprintf("%s(%d)", e.typeName, value)
+	}
+	return e.names[value-1]
+}
+
+func (e enumText) marshal(value int) ([]byte, error) {
+	if value == 0 {
+		return []byte{}, nil
+	}
+	if value < 0 || value > len(e.names) {
+		return nil, fmt.Errorf("invalid %s %d", e.typeName, value)
+	}
+	return []byte(e.names[value-1]), nil
+}
+
+func (e enumText) unmarshal(text []byte) (int, error) {
+	if len(text) == 0 {
+		return 0, nil
+	}
+	for i, name := range e.names {
+		if name == string(text) {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s %q", e.typeName, text)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEnumTextRoundTrip(t *testing.T) {
+	for name, constant := range Constants {
+		v := reflect.ValueOf(constant)
+		if v.Kind() != reflect.Int {
+			continue
+		}
+
+		marshaler, ok := constant.(encoding.TextMarshaler)
+		if !ok {
+			t.Errorf("%s: %T doesn't implement encoding.TextMarshaler", name, constant)
+			continue
+		}
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if len(text) == 0 {
+			t.Errorf("%s: expected a text", name)
+		}
+		if s := constant.(interface{ String() string }).String(); s != string(text) {
+			t.Errorf("%s: expected String to return %q, got %q", name, text, s)
+		}
+
+		decoded := reflect.New(v.Type())
+		if err := decoded.Interface().(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if decoded.Elem().Interface() != constant {
+			t.Errorf("%s: %q decoded to %v", name, text, decoded.Elem().Interface())
+		}
+	}
+}
+
+func TestEnumText(t *testing.T) {
+	var tests = []struct {
+		value    encoding.TextMarshaler
+		expected string
+	}{
+		{value: PullIfNotPresent, expected: "IfNotPresent"},
+		{value: ServiceTypeServiceIP, expected: "ClusterIP"},
+		{value: EnvFromPodIP, expected: "status.podIP"},
+		{value: DNSClusterFirstWithHostNet, expected: "ClusterFirstWithHostNet"},
+		{value: SecretTypeDockerConfigJSON, expected: "kubernetes.io/dockerconfigjson"},
+		{value: HostPathCharDev, expected: "CharDevice"},
+		{value: NodeOperatorDoesNotExist, expected: "DoesNotExist"},
+		{value: CRDNamespaceScoped, expected: "Namespaced"},
+		{value: PodManagementPolicyOrdered, expected: "OrderedReady"},
+		{value: ServiceType(0), expected: ""},
+	}
+
+	for _, test := range tests {
+		text, err := test.value.MarshalText()
+		if err != nil {
+			t.Errorf("%#v: unexpected error: %v", test.value, err)
+		} else if string(text) != test.expected {
+			t.Errorf("%#v: expected %q, got %q", test.value, test.expected, text)
+		}
+	}
+}
+
+func TestEnumTextErrors(t *testing.T) {
+	if _, err := ServiceType(42).MarshalText(); err == nil {
+		t.Errorf("expected an error marshalling an invalid service type")
+	}
+	if s := ServiceType(42).String(); s != "ServiceType(42)" {
+		t.Errorf("expected the invalid service type to print as ServiceType(42), got %s", s)
+	}
+
+	p := PullAlways
+	if err := p.UnmarshalText([]byte("always")); err == nil {
+		t.Errorf("expected an error unmarshalling an unknown pull policy")
+	}
+	if p != PullAlways {
+		t.Errorf("expected a failed unmarshal to leave the value unchanged, got %v", p)
+	}
+	if err := p.UnmarshalText([]byte{}); err != nil || p != 0 {
+		t.Errorf("expected an empty text to unmarshal to the zero value, got %v, %v", p, err)
+	}
+}
+
+func TestEnumJSONRoundTrip(t *testing.T) {
+	config := ServiceConfig{
+		Name:          "web",
+		Type:          ServiceTypeLoadBalancer,
+		TrafficPolicy: ServiceTrafficPolicyLocal,
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"APIVersion":"","ClusterName":"","Name":"web","Namespace":"","ExternalName":"","Type":"LoadBalancer","ClusterIP":"","PublishNotReadyAddresses":false,"TrafficPolicy":"Local","Affinity":"","IPTimeout":null}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	decoded := ServiceConfig{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, config) {
+		t.Errorf("expected %+v, got %+v", config, decoded)
+	}
+
+	modes := map[HostModeType]bool{HostModeNet: true, HostModePID: false}
+	data, err = json.Marshal(modes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"Network":true,"PID":false}` {
+		t.Errorf("unexpected map encoding %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Type":"Cluster"}`), &decoded); err == nil {
+		t.Errorf("expected an error decoding an unknown service type")
+	}
+}
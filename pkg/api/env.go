@@ -52,3 +52,43 @@ const (
 	EnvFromHostIP
 	EnvFromPodIP
 )
+
+var envTypeText = enumText{"EnvType", []string{
+	"Value",
+	"ConfigMap",
+	"Secret",
+	"limits.cpu",
+	"limits.memory",
+	"limits.ephemeral-storage",
+	"requests.cpu",
+	"requests.memory",
+	"requests.ephemeral-storage",
+	"metadata.name",
+	"metadata.namespace",
+	"metadata.labels",
+	"metadata.annotations",
+	"spec.nodeName",
+	"spec.serviceAccountName",
+	"status.hostIP",
+	"status.podIP",
+}}
+
+// String returns the Kubernetes spelling of the env type
+func (e EnvType) String() string {
+	return envTypeText.name(int(e))
+}
+
+// MarshalText encodes the env type with its Kubernetes spelling
+func (e EnvType) MarshalText() ([]byte, error) {
+	return envTypeText.marshal(int(e))
+}
+
+// UnmarshalText decodes a env type from its Kubernetes spelling
+func (e *EnvType) UnmarshalText(text []byte) error {
+	value, err := envTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*e = EnvType(value)
+	return nil
+}
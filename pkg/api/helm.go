@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+// HelmChartConfig defines the chart written by the Helm exporter
+type HelmChartConfig struct {
+	Name        string
+	Version     string
+	AppVersion  string
+	Description string
+	// Values lists the fields lifted into values.yaml and referenced from the templates
+	Values []HelmValueType
+}
+
+// HelmValueType defines a field that can be lifted into the chart values
+type HelmValueType int
+
+const (
+	HelmValueImage HelmValueType = iota + 1
+	HelmValueReplicas
+	HelmValueResources
+	HelmValueServiceType
+	HelmValueIngressHost
+)
+
+var helmValueTypeText = enumText{"HelmValueType", []string{
+	"Image",
+	"Replicas",
+	"Resources",
+	"ServiceType",
+	"IngressHost",
+}}
+
+// String returns the name of the chart field the helm value lifts into values.yaml
+func (h HelmValueType) String() string {
+	return helmValueTypeText.name(int(h))
+}
+
+// MarshalText encodes the helm value with the name of the chart field it lifts
+func (h HelmValueType) MarshalText() ([]byte, error) {
+	return helmValueTypeText.marshal(int(h))
+}
+
+// UnmarshalText decodes a helm value from the name of the chart field it lifts
+func (h *HelmValueType) UnmarshalText(text []byte) error {
+	value, err := helmValueTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*h = HelmValueType(value)
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+// NetworkPolicyConfig defines the basic configuration for a network policy
+type NetworkPolicyConfig struct {
+	APIVersion  string
+	ClusterName string
+	Name        string
+	Namespace   string
+	PodSelector SelectorConfig
+}
+
+// NetworkPolicyRuleConfig defines the traffic allowed to or from the selected pods.
+// Empty ports or peers allow all ports or all peers
+type NetworkPolicyRuleConfig struct {
+	Ports []NetworkPolicyPortConfig
+	Peers []NetworkPolicyPeerConfig
+}
+
+// NetworkPolicyPortConfig defines a port, by number or name, allowed by a rule
+type NetworkPolicyPortConfig struct {
+	Port     string
+	Protocol ProtocolType
+}
+
+// NetworkPolicyPeerConfig defines the pods or the IP block allowed by a rule.  A pod
+// selector without a namespace selector selects pods in the namespace of the policy
+type NetworkPolicyPeerConfig struct {
+	PodSelector       *SelectorConfig
+	NamespaceSelector *SelectorConfig
+	CIDR              string
+	Except            []string
+}
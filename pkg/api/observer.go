@@ -0,0 +1,54 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+import (
+	"time"
+)
+
+// DeployPhaseType defines a phase of a deployer run
+type DeployPhaseType string
+
+const (
+	DeployPhaseDeploy   DeployPhaseType = "Deploy"
+	DeployPhaseRollback DeployPhaseType = "Rollback"
+	DeployPhasePrune    DeployPhaseType = "Prune"
+	DeployPhaseRelease  DeployPhaseType = "Release"
+	DeployPhaseUndeploy DeployPhaseType = "Undeploy"
+)
+
+// DeployObserver defines an interface that is notified of the progress of the
+// deployer.  The deployer never calls an observer concurrently, even when
+// components are deployed in parallel
+type DeployObserver interface {
+	// OnStart is called when a run or undeploy starts with the number of components
+	OnStart(total int)
+	// OnComponentStart is called before a component is deployed or removed
+	OnComponentStart(phase DeployPhaseType, kind ComponentType, name string)
+	// OnComponentDone is called after a component was deployed or removed, including
+	// the time spent waiting for it to be ready
+	OnComponentDone(phase DeployPhaseType, kind ComponentType, name string, duration time.Duration, err error)
+	// OnPhaseComplete is called at the end of each phase of a run
+	OnPhaseComplete(phase DeployPhaseType, err error)
+	// OnFinish is called when a run or undeploy is done with its result
+	OnFinish(err error)
+}
@@ -45,9 +45,60 @@ const (
 	ReadWriteMany
 )
 
+var pvcAccessModeTypeText = enumText{"PVCAccessModeType", []string{
+	"ReadWriteOnce",
+	"ReadOnlyMany",
+	"ReadWriteMany",
+}}
+
+// String returns the Kubernetes spelling of the access mode
+func (p PVCAccessModeType) String() string {
+	return pvcAccessModeTypeText.name(int(p))
+}
+
+// MarshalText encodes the access mode with its Kubernetes spelling
+func (p PVCAccessModeType) MarshalText() ([]byte, error) {
+	return pvcAccessModeTypeText.marshal(int(p))
+}
+
+// UnmarshalText decodes a access mode from its Kubernetes spelling
+func (p *PVCAccessModeType) UnmarshalText(text []byte) error {
+	value, err := pvcAccessModeTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*p = PVCAccessModeType(value)
+	return nil
+}
+
 type PVCMode int
 
 const (
 	PVCModeBLock PVCMode = iota + 1
 	PVCModeFilesystem
 )
+
+var pvcModeText = enumText{"PVCMode", []string{
+	"Block",
+	"Filesystem",
+}}
+
+// String returns the Kubernetes spelling of the volume mode
+func (p PVCMode) String() string {
+	return pvcModeText.name(int(p))
+}
+
+// MarshalText encodes the volume mode with its Kubernetes spelling
+func (p PVCMode) MarshalText() ([]byte, error) {
+	return pvcModeText.marshal(int(p))
+}
+
+// UnmarshalText decodes a volume mode from its Kubernetes spelling
+func (p *PVCMode) UnmarshalText(text []byte) error {
+	value, err := pvcModeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*p = PVCMode(value)
+	return nil
+}
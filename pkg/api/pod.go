@@ -57,6 +57,32 @@ const (
 	DNSDefault
 )
 
+var dnsPolicyTypeText = enumText{"DNSPolicyType", []string{
+	"ClusterFirstWithHostNet",
+	"ClusterFirst",
+	"Default",
+}}
+
+// String returns the Kubernetes spelling of the DNS policy
+func (d DNSPolicyType) String() string {
+	return dnsPolicyTypeText.name(int(d))
+}
+
+// MarshalText encodes the DNS policy with its Kubernetes spelling
+func (d DNSPolicyType) MarshalText() ([]byte, error) {
+	return dnsPolicyTypeText.marshal(int(d))
+}
+
+// UnmarshalText decodes a DNS policy from its Kubernetes spelling
+func (d *DNSPolicyType) UnmarshalText(text []byte) error {
+	value, err := dnsPolicyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*d = DNSPolicyType(value)
+	return nil
+}
+
 // RestartPolicyType defines the pod restart policy
 type RestartPolicyType int
 
@@ -66,6 +92,32 @@ const (
 	RestartPolicyNever
 )
 
+var restartPolicyTypeText = enumText{"RestartPolicyType", []string{
+	"Always",
+	"OnFailure",
+	"Never",
+}}
+
+// String returns the Kubernetes spelling of the restart policy
+func (r RestartPolicyType) String() string {
+	return restartPolicyTypeText.name(int(r))
+}
+
+// MarshalText encodes the restart policy with its Kubernetes spelling
+func (r RestartPolicyType) MarshalText() ([]byte, error) {
+	return restartPolicyTypeText.marshal(int(r))
+}
+
+// UnmarshalText decodes a restart policy from its Kubernetes spelling
+func (r *RestartPolicyType) UnmarshalText(text []byte) error {
+	value, err := restartPolicyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*r = RestartPolicyType(value)
+	return nil
+}
+
 // HostModeType defines the host mode for the pod
 type HostModeType int
 
@@ -75,6 +127,32 @@ const (
 	HostModeIPC
 )
 
+var hostModeTypeText = enumText{"HostModeType", []string{
+	"Network",
+	"PID",
+	"IPC",
+}}
+
+// String returns the Kubernetes spelling of the host mode
+func (h HostModeType) String() string {
+	return hostModeTypeText.name(int(h))
+}
+
+// MarshalText encodes the host mode with its Kubernetes spelling
+func (h HostModeType) MarshalText() ([]byte, error) {
+	return hostModeTypeText.marshal(int(h))
+}
+
+// UnmarshalText decodes a host mode from its Kubernetes spelling
+func (h *HostModeType) UnmarshalText(text []byte) error {
+	value, err := hostModeTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*h = HostModeType(value)
+	return nil
+}
+
 // TolerationConfig defines the configuration for a pod toleration
 type TolerationConfig struct {
 	Duration *int64
@@ -93,6 +171,32 @@ const (
 	TolerationEffectNoExecute
 )
 
+var tolerationEffectTypeText = enumText{"TolerationEffectType", []string{
+	"NoSchedule",
+	"PreferNoSchedule",
+	"NoExecute",
+}}
+
+// String returns the Kubernetes spelling of the toleration effect
+func (t TolerationEffectType) String() string {
+	return tolerationEffectTypeText.name(int(t))
+}
+
+// MarshalText encodes the toleration effect with its Kubernetes spelling
+func (t TolerationEffectType) MarshalText() ([]byte, error) {
+	return tolerationEffectTypeText.marshal(int(t))
+}
+
+// UnmarshalText decodes a toleration effect from its Kubernetes spelling
+func (t *TolerationEffectType) UnmarshalText(text []byte) error {
+	value, err := tolerationEffectTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*t = TolerationEffectType(value)
+	return nil
+}
+
 // TolerationOpType defines the toleration operator
 type TolerationOpType int
 
@@ -101,6 +205,31 @@ const (
 	TolerationOpEqual
 )
 
+var tolerationOpTypeText = enumText{"TolerationOpType", []string{
+	"Exists",
+	"Equal",
+}}
+
+// String returns the Kubernetes spelling of the toleration operator
+func (t TolerationOpType) String() string {
+	return tolerationOpTypeText.name(int(t))
+}
+
+// MarshalText encodes the toleration operator with its Kubernetes spelling
+func (t TolerationOpType) MarshalText() ([]byte, error) {
+	return tolerationOpTypeText.marshal(int(t))
+}
+
+// UnmarshalText decodes a toleration operator from its Kubernetes spelling
+func (t *TolerationOpType) UnmarshalText(text []byte) error {
+	value, err := tolerationOpTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*t = TolerationOpType(value)
+	return nil
+}
+
 // PodDNSConfig defines the dns configuration for a pod
 type PodDNSConfig struct {
 	Nameservers     []string
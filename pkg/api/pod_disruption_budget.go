@@ -0,0 +1,34 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+// PodDisruptionBudgetConfig defines the basic configuration for a pod disruption budget.
+// MinAvailable and MaxUnavailable are a number or a percentage of pods, and only one of
+// them can be set
+type PodDisruptionBudgetConfig struct {
+	APIVersion     string
+	ClusterName    string
+	Name           string
+	Namespace      string
+	MinAvailable   string
+	MaxUnavailable string
+}
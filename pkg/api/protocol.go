@@ -29,3 +29,29 @@ const (
 	ProtocolUDP
 	ProtocolSCTP
 )
+
+var protocolTypeText = enumText{"ProtocolType", []string{
+	"TCP",
+	"UDP",
+	"SCTP",
+}}
+
+// String returns the Kubernetes spelling of the protocol
+func (p ProtocolType) String() string {
+	return protocolTypeText.name(int(p))
+}
+
+// MarshalText encodes the protocol with its Kubernetes spelling
+func (p ProtocolType) MarshalText() ([]byte, error) {
+	return protocolTypeText.marshal(int(p))
+}
+
+// UnmarshalText decodes a protocol from its Kubernetes spelling
+func (p *ProtocolType) UnmarshalText(text []byte) error {
+	value, err := protocolTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*p = ProtocolType(value)
+	return nil
+}
@@ -78,3 +78,18 @@ type RoleRefConfig struct {
 	Name      string
 	Namespace string
 }
+
+// DeployerRBACConfig defines the service account running a deployer, and the
+// operations it performs besides deploying the components
+type DeployerRBACConfig struct {
+	// Name is the name of the generated roles and bindings
+	Name                    string
+	ServiceAccount          string
+	ServiceAccountNamespace string
+	// Wait allows waiting for the components to be ready
+	Wait bool
+	// Prune allows removing the objects that are no longer part of the deployer
+	Prune bool
+	// Undeploy allows removing the components
+	Undeploy bool
+}
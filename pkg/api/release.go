@@ -0,0 +1,69 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+// ReleaseConfig defines how the deployer records the releases it deploys
+type ReleaseConfig struct {
+	// Name identifies the application the releases belong to
+	Name string
+	// Namespace is where the release history is stored
+	Namespace  string
+	AppVersion string
+	// Storage defaults to secrets.  Config maps can't be used when the deployer
+	// has secrets, since the manifest of a release includes their data
+	Storage ReleaseStorageType
+	// MaxHistory is the number of releases to keep, 0 keeps all of them
+	MaxHistory int
+}
+
+// ReleaseStorageType defines the type of object used to store releases
+type ReleaseStorageType int
+
+const (
+	ReleaseStorageSecret ReleaseStorageType = iota + 1
+	ReleaseStorageConfigMap
+)
+
+var releaseStorageTypeText = enumText{"ReleaseStorageType", []string{
+	"Secret",
+	"ConfigMap",
+}}
+
+// String returns the kind of the objects the releases are stored in
+func (r ReleaseStorageType) String() string {
+	return releaseStorageTypeText.name(int(r))
+}
+
+// MarshalText encodes the release storage with the kind of the objects it uses
+func (r ReleaseStorageType) MarshalText() ([]byte, error) {
+	return releaseStorageTypeText.marshal(int(r))
+}
+
+// UnmarshalText decodes a release storage from the kind of the objects it uses
+func (r *ReleaseStorageType) UnmarshalText(text []byte) error {
+	value, err := releaseStorageTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*r = ReleaseStorageType(value)
+	return nil
+}
@@ -43,3 +43,34 @@ const (
 	SecretTypeTLS
 	SecretTypeBootstrapToken
 )
+
+var secretTypeText = enumText{"SecretType", []string{
+	"Opaque",
+	"kubernetes.io/service-account-token",
+	"kubernetes.io/dockercfg",
+	"kubernetes.io/dockerconfigjson",
+	"kubernetes.io/basic-auth",
+	"kubernetes.io/ssh-auth",
+	"kubernetes.io/tls",
+	"bootstrap.kubernetes.io/token",
+}}
+
+// String returns the Kubernetes spelling of the secret type
+func (s SecretType) String() string {
+	return secretTypeText.name(int(s))
+}
+
+// MarshalText encodes the secret type with its Kubernetes spelling
+func (s SecretType) MarshalText() ([]byte, error) {
+	return secretTypeText.marshal(int(s))
+}
+
+// UnmarshalText decodes a secret type from its Kubernetes spelling
+func (s *SecretType) UnmarshalText(text []byte) error {
+	value, err := secretTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*s = SecretType(value)
+	return nil
+}
@@ -43,3 +43,30 @@ const (
 	ExpressionRequirementOpExists
 	ExpressionRequirementOpDoesNotExist
 )
+
+var expressionRequirementOpText = enumText{"ExpressionRequirementOp", []string{
+	"In",
+	"NotIn",
+	"Exists",
+	"DoesNotExist",
+}}
+
+// String returns the Kubernetes spelling of the expression operator
+func (e ExpressionRequirementOp) String() string {
+	return expressionRequirementOpText.name(int(e))
+}
+
+// MarshalText encodes the expression operator with its Kubernetes spelling
+func (e ExpressionRequirementOp) MarshalText() ([]byte, error) {
+	return expressionRequirementOpText.marshal(int(e))
+}
+
+// UnmarshalText decodes a expression operator from its Kubernetes spelling
+func (e *ExpressionRequirementOp) UnmarshalText(text []byte) error {
+	value, err := expressionRequirementOpText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*e = ExpressionRequirementOp(value)
+	return nil
+}
@@ -46,6 +46,33 @@ const (
 	ServiceTypeExternalName
 )
 
+var serviceTypeText = enumText{"ServiceType", []string{
+	"ClusterIP",
+	"NodePort",
+	"LoadBalancer",
+	"ExternalName",
+}}
+
+// String returns the Kubernetes spelling of the service type
+func (s ServiceType) String() string {
+	return serviceTypeText.name(int(s))
+}
+
+// MarshalText encodes the service type with its Kubernetes spelling
+func (s ServiceType) MarshalText() ([]byte, error) {
+	return serviceTypeText.marshal(int(s))
+}
+
+// UnmarshalText decodes a service type from its Kubernetes spelling
+func (s *ServiceType) UnmarshalText(text []byte) error {
+	value, err := serviceTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*s = ServiceType(value)
+	return nil
+}
+
 // ServicePortConfig defines the configuration for a service port
 type ServicePortConfig struct {
 	Name       string
@@ -63,6 +90,31 @@ const (
 	ServiceTrafficPolicyCluster
 )
 
+var trafficPolicyTypeText = enumText{"TrafficPolicyType", []string{
+	"Local",
+	"Cluster",
+}}
+
+// String returns the Kubernetes spelling of the traffic policy
+func (t TrafficPolicyType) String() string {
+	return trafficPolicyTypeText.name(int(t))
+}
+
+// MarshalText encodes the traffic policy with its Kubernetes spelling
+func (t TrafficPolicyType) MarshalText() ([]byte, error) {
+	return trafficPolicyTypeText.marshal(int(t))
+}
+
+// UnmarshalText decodes a traffic policy from its Kubernetes spelling
+func (t *TrafficPolicyType) UnmarshalText(text []byte) error {
+	value, err := trafficPolicyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*t = TrafficPolicyType(value)
+	return nil
+}
+
 // LoadBalancerConfig defines the configuration for a load balancer
 // to use with a service
 type LoadBalancerConfig struct {
@@ -77,3 +129,28 @@ const (
 	ServiceAffinityTypeClientIP ServiceAffinityType = iota + 1
 	ServiceAffinityTypeNone
 )
+
+var serviceAffinityTypeText = enumText{"ServiceAffinityType", []string{
+	"ClientIP",
+	"None",
+}}
+
+// String returns the Kubernetes spelling of the session affinity
+func (s ServiceAffinityType) String() string {
+	return serviceAffinityTypeText.name(int(s))
+}
+
+// MarshalText encodes the session affinity with its Kubernetes spelling
+func (s ServiceAffinityType) MarshalText() ([]byte, error) {
+	return serviceAffinityTypeText.marshal(int(s))
+}
+
+// UnmarshalText decodes a session affinity from its Kubernetes spelling
+func (s *ServiceAffinityType) UnmarshalText(text []byte) error {
+	value, err := serviceAffinityTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*s = ServiceAffinityType(value)
+	return nil
+}
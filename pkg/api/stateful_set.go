@@ -43,6 +43,31 @@ const (
 	StatefulSetUpdateStrategyOnDelete
 )
 
+var statefulSetUpdateStrategyTypeText = enumText{"StatefulSetUpdateStrategyType", []string{
+	"RollingUpdate",
+	"OnDelete",
+}}
+
+// String returns the Kubernetes spelling of the update strategy
+func (s StatefulSetUpdateStrategyType) String() string {
+	return statefulSetUpdateStrategyTypeText.name(int(s))
+}
+
+// MarshalText encodes the update strategy with its Kubernetes spelling
+func (s StatefulSetUpdateStrategyType) MarshalText() ([]byte, error) {
+	return statefulSetUpdateStrategyTypeText.marshal(int(s))
+}
+
+// UnmarshalText decodes a update strategy from its Kubernetes spelling
+func (s *StatefulSetUpdateStrategyType) UnmarshalText(text []byte) error {
+	value, err := statefulSetUpdateStrategyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*s = StatefulSetUpdateStrategyType(value)
+	return nil
+}
+
 // PodManagementPolicyType defines the pod managagement policy for the stateful set
 type PodManagementPolicyType int
 
@@ -50,3 +75,28 @@ const (
 	PodManagementPolicyOrdered PodManagementPolicyType = iota + 1
 	PodManagementPolicyParallel
 )
+
+var podManagementPolicyTypeText = enumText{"PodManagementPolicyType", []string{
+	"OrderedReady",
+	"Parallel",
+}}
+
+// String returns the Kubernetes spelling of the pod management policy
+func (p PodManagementPolicyType) String() string {
+	return podManagementPolicyTypeText.name(int(p))
+}
+
+// MarshalText encodes the pod management policy with its Kubernetes spelling
+func (p PodManagementPolicyType) MarshalText() ([]byte, error) {
+	return podManagementPolicyTypeText.marshal(int(p))
+}
+
+// UnmarshalText decodes a pod management policy from its Kubernetes spelling
+func (p *PodManagementPolicyType) UnmarshalText(text []byte) error {
+	value, err := podManagementPolicyTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*p = PodManagementPolicyType(value)
+	return nil
+}
@@ -0,0 +1,37 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+import (
+	"time"
+)
+
+// TimeoutConfig defines how long the deployer lets operations on the cluster run
+type TimeoutConfig struct {
+	// Timeout is the deadline for a whole run, there is no deadline if it isn't set
+	Timeout time.Duration
+	// ComponentTimeout is the time allowed to deploy or remove each component,
+	// there is no limit if it isn't set
+	ComponentTimeout time.Duration
+	// KindTimeouts overrides ComponentTimeout for specific component types
+	KindTimeouts map[ComponentType]time.Duration
+}
@@ -38,6 +38,32 @@ const (
 	StorageMediumHugePages
 )
 
+var storageMediumTypeText = enumText{"StorageMediumType", []string{
+	"Default",
+	"Memory",
+	"HugePages",
+}}
+
+// String returns the Kubernetes spelling of the storage medium
+func (s StorageMediumType) String() string {
+	return storageMediumTypeText.name(int(s))
+}
+
+// MarshalText encodes the storage medium with its Kubernetes spelling
+func (s StorageMediumType) MarshalText() ([]byte, error) {
+	return storageMediumTypeText.marshal(int(s))
+}
+
+// UnmarshalText decodes a storage medium from its Kubernetes spelling
+func (s *StorageMediumType) UnmarshalText(text []byte) error {
+	value, err := storageMediumTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*s = StorageMediumType(value)
+	return nil
+}
+
 // ConfigMapOrSecretVolumeConfig defines the configuration for
 // a config map or secret
 type ConfigMapOrSecretVolumeConfig struct {
@@ -77,6 +103,37 @@ const (
 	HostPathBlockDev
 )
 
+var hostPathTypeText = enumText{"HostPathType", []string{
+	"Unset",
+	"DirectoryOrCreate",
+	"Directory",
+	"FileOrCreate",
+	"File",
+	"Socket",
+	"CharDevice",
+	"BlockDevice",
+}}
+
+// String returns the Kubernetes spelling of the host path type
+func (h HostPathType) String() string {
+	return hostPathTypeText.name(int(h))
+}
+
+// MarshalText encodes the host path type with its Kubernetes spelling
+func (h HostPathType) MarshalText() ([]byte, error) {
+	return hostPathTypeText.marshal(int(h))
+}
+
+// UnmarshalText decodes a host path type from its Kubernetes spelling
+func (h *HostPathType) UnmarshalText(text []byte) error {
+	value, err := hostPathTypeText.unmarshal(text)
+	if err != nil {
+		return err
+	}
+	*h = HostPathType(value)
+	return nil
+}
+
 // GCEPersistentDiskVolumeConfig defines the configuraton for a
 // GCE Persistent Disk volume
 type GCEPersistentDiskVolumeConfig struct {
@@ -0,0 +1,36 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package api
+
+import (
+	"time"
+)
+
+// WaitConfig defines how the deployer waits for components to become ready
+type WaitConfig struct {
+	// Timeout is the time to wait for each component, defaults to 5 minutes
+	Timeout time.Duration
+	// Interval is the time between readiness checks, defaults to 2 seconds
+	Interval time.Duration
+	// KindTimeouts overrides Timeout for specific component types
+	KindTimeouts map[ComponentType]time.Duration
+}
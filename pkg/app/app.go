@@ -0,0 +1,393 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package app builds the components of an application running a single
+// container from one AppConfig, so that the labels, selectors, ports, volumes
+// and references of the components always match:
+//
+//	a, err := app.NewApp(api.AppConfig{
+//		Name:      "web",
+//		Namespace: "shop",
+//		Image:     "shop/web:1.0",
+//		Ports:     []api.AppPortConfig{{Name: "http", Port: 8080}},
+//		Expose:    api.AppExposeConfig{Host: "shop.example.com"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	a.AddTo(deployer)
+//
+// The generated components are exported by App so they can be changed before
+// they are deployed.
+package app
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/deployer"
+)
+
+// The recommended labels set on every component of an application.  The pods
+// are selected by name and instance, which don't change between versions
+const (
+	NameLabel      = "app.kubernetes.io/name"
+	InstanceLabel  = "app.kubernetes.io/instance"
+	VersionLabel   = "app.kubernetes.io/version"
+	PartOfLabel    = "app.kubernetes.io/part-of"
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+
+	managedBy        = "horizon"
+	headlessSuffix   = "-headless"
+	configVolumeName = "config"
+	secretVolumeName = "secret"
+)
+
+// App defines the components of an application running a single container.
+// Components the configuration doesn't need are nil.  HeadlessService is the
+// governing service of a stateful set, which gives its pods stable network
+// identities, and Service exposes the ports of the application
+type App struct {
+	ServiceAccount         *components.ServiceAccount
+	ConfigMap              *components.ConfigMap
+	Secret                 *components.Secret
+	PersistentVolumeClaims []*components.PersistentVolumeClaim
+	Deployment             *components.Deployment
+	StatefulSet            *components.StatefulSet
+	Service                *components.Service
+	HeadlessService        *components.Service
+	Ingress                *components.Ingress
+	HPA                    *components.HorizontalPodAutoscaler
+}
+
+// Labels returns the recommended labels of the components of an application
+func Labels(config api.AppConfig) map[string]string {
+	labels := SelectorLabels(config)
+	labels[ManagedByLabel] = managedBy
+	if len(config.Version) > 0 {
+		labels[VersionLabel] = config.Version
+	}
+	if len(config.PartOf) > 0 {
+		labels[PartOfLabel] = config.PartOf
+	}
+	return labels
+}
+
+// SelectorLabels returns the labels selecting the pods of an application
+func SelectorLabels(config api.AppConfig) map[string]string {
+	instance := config.Instance
+	if len(instance) == 0 {
+		instance = config.Name
+	}
+	return map[string]string{NameLabel: config.Name, InstanceLabel: instance}
+}
+
+// NewApp creates an App object with the components running the application
+func NewApp(config api.AppConfig) (*App, error) {
+	if len(config.Name) == 0 {
+		return nil, fmt.Errorf("an application requires a name")
+	}
+	if len(config.Image) == 0 {
+		return nil, fmt.Errorf("application %s requires an image", config.Name)
+	}
+
+	a := &App{}
+	labels := Labels(config)
+	selector := SelectorLabels(config)
+
+	a.ServiceAccount = components.NewServiceAccount(api.ServiceAccountConfig{Name: config.Name, Namespace: config.Namespace})
+	container, err := components.NewContainer(api.ContainerConfig{
+		Name:       config.Name,
+		Image:      config.Image,
+		PullPolicy: config.PullPolicy,
+		Command:    config.Command,
+		Args:       config.Args,
+		MinCPU:     config.MinCPU,
+		MaxCPU:     config.MaxCPU,
+		MinMem:     config.MinMem,
+		MaxMem:     config.MaxMem,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("application %s: %v", config.Name, err)
+	}
+	for _, p := range config.Ports {
+		container.AddPort(api.PortConfig{Name: p.Name, ContainerPort: p.Port, Protocol: p.Protocol})
+	}
+	for _, e := range config.Env {
+		container.AddEnv(e)
+	}
+
+	pod := components.NewPod(api.PodConfig{Name: config.Name, Namespace: config.Namespace, ServiceAccount: config.Name})
+	if err := a.addConfig(config, container, pod); err != nil {
+		return nil, err
+	}
+	if err := a.addStorage(config, container, pod); err != nil {
+		return nil, err
+	}
+	if err := pod.AddContainer(container); err != nil {
+		return nil, fmt.Errorf("application %s: %v", config.Name, err)
+	}
+	pod.AddLabels(labels)
+
+	kind := "Deployment"
+	if config.Stateful {
+		kind = "StatefulSet"
+		a.HeadlessService = components.NewService(api.ServiceConfig{Name: config.Name + headlessSuffix, Namespace: config.Namespace, ClusterIP: "None"})
+		a.HeadlessService.AddSelectors(selector)
+		if err := addServicePorts(a.HeadlessService, config); err != nil {
+			return nil, err
+		}
+		a.StatefulSet = components.NewStatefulSet(api.StatefulSetConfig{Name: config.Name, Namespace: config.Namespace, Replicas: config.Scaling.Replicas, Service: a.HeadlessService.Name})
+		a.StatefulSet.AddMatchLabelsSelectors(selector)
+		a.StatefulSet.AddPod(pod)
+		for _, claim := range a.PersistentVolumeClaims {
+			a.StatefulSet.AddVolumeClaimTemplate(*claim)
+		}
+		a.PersistentVolumeClaims = nil
+	} else {
+		a.Deployment = components.NewDeployment(api.DeploymentConfig{Name: config.Name, Namespace: config.Namespace, Replicas: config.Scaling.Replicas})
+		a.Deployment.AddMatchLabelsSelectors(selector)
+		a.Deployment.AddPod(pod)
+	}
+
+	if len(config.Ports) > 0 {
+		a.Service = components.NewService(api.ServiceConfig{Name: config.Name, Namespace: config.Namespace, Type: config.Expose.Type})
+		a.Service.AddSelectors(selector)
+		if err := addServicePorts(a.Service, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.Expose.Host) > 0 {
+		if a.Service == nil {
+			return nil, fmt.Errorf("application %s has no ports to expose", config.Name)
+		}
+		port := config.Expose.Port
+		if len(port) == 0 {
+			port = portName(config.Ports[0])
+		}
+		a.Ingress, err = components.NewIngress(api.IngressConfig{Name: config.Name, Namespace: config.Namespace})
+		if err != nil {
+			return nil, fmt.Errorf("application %s: %v", config.Name, err)
+		}
+		a.Ingress.AddHostRule(api.IngressHostRuleConfig{Host: config.Expose.Host, Paths: []api.HTTPIngressPathConfig{{Path: config.Expose.Path, ServiceName: config.Name, ServicePort: port}}})
+		if len(config.Expose.TLSSecret) > 0 {
+			a.Ingress.AddTLS(api.IngressTLSConfig{Hosts: []string{config.Expose.Host}, SecretName: config.Expose.TLSSecret})
+		}
+	}
+
+	if config.Scaling.MaxReplicas > 0 {
+		a.HPA = components.NewHorizontalPodAutoscaler(api.HPAConfig{
+			Name:                           config.Name,
+			Namespace:                      config.Namespace,
+			MinReplicas:                    config.Scaling.MinReplicas,
+			MaxReplicas:                    config.Scaling.MaxReplicas,
+			TargetCPUUtilizationPercentage: config.Scaling.TargetCPUUtilizationPercentage,
+			ScaleTargetKind:                kind,
+			ScaleTargetName:                config.Name,
+			ScaleTargetAPIVersion:          "apps/v1",
+		})
+	}
+
+	for _, list := range a.Components() {
+		for _, c := range list {
+			c.(labeled).AddLabels(labels)
+		}
+	}
+	return a, nil
+}
+
+// labeled is implemented by the components with metadata
+type labeled interface {
+	AddLabels(map[string]string)
+}
+
+// addServicePorts exposes the ports of the application on the service
+func addServicePorts(svc *components.Service, config api.AppConfig) error {
+	for _, p := range config.Ports {
+		if err := svc.AddPort(api.ServicePortConfig{Name: p.Name, Port: p.Port, TargetPort: portName(p), Protocol: p.Protocol}); err != nil {
+			return fmt.Errorf("application %s: %v", config.Name, err)
+		}
+	}
+	return nil
+}
+
+// portName returns the name of a port, or its number if it has no name
+func portName(p api.AppPortConfig) string {
+	if len(p.Name) > 0 {
+		return p.Name
+	}
+	return strconv.Itoa(int(p.Port))
+}
+
+// addConfig creates the config map and secret holding the configuration
+// files and secrets, and mounts the files in the container
+func (a *App) addConfig(config api.AppConfig, container *components.Container, pod *components.Pod) error {
+	if len(config.ConfigFiles) > 0 {
+		a.ConfigMap = components.NewConfigMap(api.ConfigMapConfig{Name: config.Name + "-config", Namespace: config.Namespace})
+		data, err := mountFiles(config.ConfigFiles, configVolumeName, container, nil)
+		if err != nil {
+			return fmt.Errorf("application %s: %v", config.Name, err)
+		}
+		a.ConfigMap.AddData(data)
+		pod.AddVolume(components.NewConfigMapVolume(api.ConfigMapOrSecretVolumeConfig{VolumeName: configVolumeName, MapOrSecretName: a.ConfigMap.Name}))
+	}
+
+	if len(config.SecretEnv) == 0 && len(config.SecretFiles) == 0 {
+		return nil
+	}
+	a.Secret = components.NewSecret(api.SecretConfig{Name: config.Name + "-secret", Namespace: config.Namespace, Type: api.SecretTypeOpaque})
+	a.Secret.AddStringData(config.SecretEnv)
+	for _, name := range sortedKeys(config.SecretEnv) {
+		container.AddEnv(api.EnvConfig{NameOrPrefix: name, Type: api.EnvFromSecret, KeyOrVal: name, FromName: a.Secret.Name})
+	}
+	if len(config.SecretFiles) > 0 {
+		data, err := mountFiles(config.SecretFiles, secretVolumeName, container, config.SecretEnv)
+		if err != nil {
+			return fmt.Errorf("application %s: %v", config.Name, err)
+		}
+		a.Secret.AddStringData(data)
+		pod.AddVolume(components.NewSecretVolume(api.ConfigMapOrSecretVolumeConfig{VolumeName: secretVolumeName, MapOrSecretName: a.Secret.Name}))
+	}
+	return nil
+}
+
+// mountFiles mounts each file from the volume, where it is stored under the
+// base name of its path, and returns the data of the volume
+func mountFiles(files map[string]string, volume string, container *components.Container, used map[string]string) (map[string]string, error) {
+	data := map[string]string{}
+	paths := map[string]string{}
+	for _, p := range sortedKeys(files) {
+		if !path.IsAbs(p) {
+			return nil, fmt.Errorf("file path %s must be absolute", p)
+		}
+		key := path.Base(p)
+		if other, ok := paths[key]; ok {
+			return nil, fmt.Errorf("files %s and %s have the same name", other, p)
+		}
+		paths[key] = p
+		if _, ok := used[key]; ok {
+			return nil, fmt.Errorf("file %s has the same name as the secret %s", p, key)
+		}
+		data[key] = files[p]
+		if err := container.AddVolumeMount(api.VolumeMountConfig{Name: volume, MountPath: p, SubPath: key, ReadOnly: true}); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// addStorage creates the claims of the storage and mounts them in the
+// container.  The claims of a stateful set are used as its claim templates
+func (a *App) addStorage(config api.AppConfig, container *components.Container, pod *components.Pod) error {
+	for _, s := range config.Storage {
+		name := config.Name + "-" + s.Name
+		if config.Stateful {
+			name = s.Name
+		}
+		claim, err := components.NewPersistentVolumeClaim(api.PVCConfig{Name: name, Namespace: config.Namespace, Size: s.Size, Class: s.Class})
+		if err != nil {
+			return fmt.Errorf("application %s: storage %s: %v", config.Name, s.Name, err)
+		}
+		claim.AddAccessMode(api.ReadWriteOnce)
+		claim.AddLabels(Labels(config))
+		a.PersistentVolumeClaims = append(a.PersistentVolumeClaims, claim)
+
+		if err := container.AddVolumeMount(api.VolumeMountConfig{Name: s.Name, MountPath: s.MountPath}); err != nil {
+			return fmt.Errorf("application %s: storage %s: %v", config.Name, s.Name, err)
+		}
+		if !config.Stateful {
+			pod.AddVolume(components.NewPVCVolume(api.PVCVolumeConfig{VolumeName: s.Name, PVCName: name}))
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Container returns the container of the application, within the pod
+// template of its deployment or stateful set
+func (a *App) Container() *components.Container {
+	spec := components.GetPodSpec(a.workload())
+	return &components.Container{Container: &spec.Containers[0]}
+}
+
+func (a *App) workload() api.DeployableComponentInterface {
+	if a.StatefulSet != nil {
+		return a.StatefulSet
+	}
+	return a.Deployment
+}
+
+// Components returns the components of the application by type
+func (a *App) Components() map[api.ComponentType][]api.DeployableComponentInterface {
+	comps := map[api.ComponentType][]api.DeployableComponentInterface{
+		api.ServiceAccountComponent: {a.ServiceAccount},
+	}
+	if a.ConfigMap != nil {
+		comps[api.ConfigMapComponent] = []api.DeployableComponentInterface{a.ConfigMap}
+	}
+	if a.Secret != nil {
+		comps[api.SecretComponent] = []api.DeployableComponentInterface{a.Secret}
+	}
+	for _, claim := range a.PersistentVolumeClaims {
+		comps[api.PersistentVolumeClaimComponent] = append(comps[api.PersistentVolumeClaimComponent], claim)
+	}
+	if a.StatefulSet != nil {
+		comps[api.StatefulSetComponent] = []api.DeployableComponentInterface{a.StatefulSet}
+	}
+	if a.Deployment != nil {
+		comps[api.DeploymentComponent] = []api.DeployableComponentInterface{a.Deployment}
+	}
+	if a.Service != nil {
+		comps[api.ServiceComponent] = append(comps[api.ServiceComponent], a.Service)
+	}
+	if a.HeadlessService != nil {
+		comps[api.ServiceComponent] = append(comps[api.ServiceComponent], a.HeadlessService)
+	}
+	if a.Ingress != nil {
+		comps[api.IngressComponent] = []api.DeployableComponentInterface{a.Ingress}
+	}
+	if a.HPA != nil {
+		comps[api.HorizontalPodAutoscalerComponent] = []api.DeployableComponentInterface{a.HPA}
+	}
+	return comps
+}
+
+// AddTo will add the components of the application to the deployer
+func (a *App) AddTo(d *deployer.Deployer) {
+	for ct, list := range a.Components() {
+		for _, c := range list {
+			d.AddComponent(ct, c)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/deployer"
+)
+
+func newAppConfig() api.AppConfig {
+	three, five, cpu := int32(3), int32(5), int32(80)
+	return api.AppConfig{
+		Name:        "web",
+		Namespace:   "shop",
+		Version:     "1.0",
+		PartOf:      "shop",
+		Image:       "shop/web:1.0",
+		MaxCPU:      "500m",
+		MaxMem:      "256Mi",
+		Ports:       []api.AppPortConfig{{Name: "http", Port: 8080}, {Name: "metrics", Port: 9090}},
+		Env:         []api.EnvConfig{{NameOrPrefix: "MODE", Type: api.EnvVal, KeyOrVal: "production"}},
+		ConfigFiles: map[string]string{"/etc/web/web.yaml": "port: 8080"},
+		SecretEnv:   map[string]string{"DB_PASSWORD": "secret"},
+		SecretFiles: map[string]string{"/etc/web/tls/tls.key": "key"},
+		Storage:     []api.AppStorageConfig{{Name: "data", MountPath: "/data", Size: "1Gi"}},
+		Expose:      api.AppExposeConfig{Host: "shop.example.com", Path: "/", TLSSecret: "shop-tls"},
+		Scaling:     api.AppScalingConfig{Replicas: &three, MaxReplicas: five, TargetCPUUtilizationPercentage: &cpu},
+	}
+}
+
+// checkConsistent checks that the components of the app reference each other
+// and select the pods of the app
+func checkConsistent(t *testing.T, a *App) {
+	d := deployer.NewDeployerExporter()
+	a.AddTo(d)
+	if err := d.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+	if err := d.CheckReferences(); err != nil {
+		t.Errorf("unexpected reference error: %v", err)
+	}
+	if err := d.CheckSelectors(); err != nil {
+		t.Errorf("unexpected selector error: %v", err)
+	}
+}
+
+func TestNewApp(t *testing.T) {
+	a, err := NewApp(newAppConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkConsistent(t, a)
+
+	if a.StatefulSet != nil || a.Deployment == nil || *a.Deployment.Spec.Replicas != 3 {
+		t.Fatalf("expected a deployment with 3 replicas")
+	}
+	for ct, list := range a.Components() {
+		for _, c := range list {
+			labels := c.(interface{ GetLabels() map[string]string }).GetLabels()
+			if labels[NameLabel] != "web" || labels[InstanceLabel] != "web" || labels[VersionLabel] != "1.0" || labels[PartOfLabel] != "shop" || labels[ManagedByLabel] != "horizon" {
+				t.Errorf("unexpected labels of %s: %v", ct, labels)
+			}
+		}
+	}
+	if labels := a.Deployment.Spec.Selector.MatchLabels; len(labels) != 2 {
+		t.Errorf("expected the selector to use the name and instance, got %v", labels)
+	}
+	if labels := a.Deployment.Spec.Template.Labels; labels[VersionLabel] != "1.0" {
+		t.Errorf("unexpected pod labels %v", labels)
+	}
+
+	c := a.Container()
+	if c.Image != "shop/web:1.0" || len(c.Ports) != 2 || len(c.Env) != 2 || len(c.VolumeMounts) != 3 {
+		t.Errorf("unexpected container %+v", c.Container)
+	}
+	if a.ConfigMap.Name != "web-config" || a.ConfigMap.Data["web.yaml"] != "port: 8080" {
+		t.Errorf("unexpected config map %+v", a.ConfigMap.Data)
+	}
+	if a.Secret.StringData["DB_PASSWORD"] != "secret" || a.Secret.StringData["tls.key"] != "key" {
+		t.Errorf("unexpected secret %+v", a.Secret.StringData)
+	}
+	if len(a.PersistentVolumeClaims) != 1 || a.PersistentVolumeClaims[0].Name != "web-data" {
+		t.Errorf("unexpected claims %+v", a.PersistentVolumeClaims)
+	}
+	if ports := a.Service.Spec.Ports; len(ports) != 2 || ports[1].TargetPort.StrVal != "metrics" {
+		t.Errorf("unexpected service ports %+v", ports)
+	}
+	if backend := a.Ingress.Spec.Rules[0].HTTP.Paths[0].Backend; backend.ServiceName != "web" || backend.ServicePort.StrVal != "http" || len(a.Ingress.Spec.TLS) != 1 {
+		t.Errorf("unexpected ingress %+v", a.Ingress.Spec)
+	}
+	if a.HPA.Spec.ScaleTargetRef.Kind != "Deployment" || a.HPA.Spec.MaxReplicas != 5 {
+		t.Errorf("unexpected autoscaler %+v", a.HPA.Spec)
+	}
+
+	c.AddReadinessProbe(api.ProbeConfig{ActionConfig: api.ActionConfig{Type: api.ActionTypeHTTP, Path: "/healthz", Port: "http"}})
+	if a.Deployment.Spec.Template.Spec.Containers[0].ReadinessProbe == nil {
+		t.Errorf("expected changes to the container to change the deployment")
+	}
+}
+
+func TestNewStatefulApp(t *testing.T) {
+	config := newAppConfig()
+	config.Stateful = true
+	config.Expose = api.AppExposeConfig{}
+	config.Ports = []api.AppPortConfig{{Port: 5432}}
+	a, err := NewApp(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkConsistent(t, a)
+
+	if a.Deployment != nil || a.StatefulSet == nil || a.StatefulSet.Spec.ServiceName != "web-headless" {
+		t.Fatalf("expected a stateful set governed by the web-headless service")
+	}
+	if a.HeadlessService.Spec.ClusterIP != "None" || len(a.HeadlessService.Spec.Ports) != 1 || a.Service.Spec.ClusterIP == "None" {
+		t.Errorf("expected a headless service besides the web service, got %+v and %+v", a.HeadlessService.Spec, a.Service.Spec)
+	}
+	templates := a.StatefulSet.Spec.VolumeClaimTemplates
+	if len(a.PersistentVolumeClaims) != 0 || len(templates) != 1 || templates[0].Name != "data" || templates[0].Labels[NameLabel] != "web" {
+		t.Errorf("unexpected claims %+v, %+v", a.PersistentVolumeClaims, templates)
+	}
+	if a.Ingress != nil || a.HPA.Spec.ScaleTargetRef.Kind != "StatefulSet" {
+		t.Errorf("unexpected ingress or autoscaler")
+	}
+	if port := a.Service.Spec.Ports[0].TargetPort; port.IntValue() != 5432 {
+		t.Errorf("unexpected target port %+v", port)
+	}
+}
+
+func TestNewStatefulAppWithoutPorts(t *testing.T) {
+	config := newAppConfig()
+	config.Stateful = true
+	config.Expose = api.AppExposeConfig{}
+	config.Ports = nil
+	a, err := NewApp(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkConsistent(t, a)
+
+	if a.Service != nil || a.HeadlessService == nil || a.StatefulSet.Spec.ServiceName != a.HeadlessService.Name {
+		t.Errorf("expected only the headless service governing the stateful set")
+	}
+}
+
+func TestNewAppErrors(t *testing.T) {
+	var cases = []struct {
+		description string
+		config      func(c *api.AppConfig)
+		expected    string
+	}{
+		{"no name", func(c *api.AppConfig) { c.Name = "" }, "an application requires a name"},
+		{"no image", func(c *api.AppConfig) { c.Image = "" }, "application web requires an image"},
+		{"relative file", func(c *api.AppConfig) { c.ConfigFiles = map[string]string{"web.yaml": ""} }, "file path web.yaml must be absolute"},
+		{"same file name", func(c *api.AppConfig) { c.ConfigFiles = map[string]string{"/a/web.yaml": "", "/b/web.yaml": ""} }, "files /a/web.yaml and /b/web.yaml have the same name"},
+		{"file named after secret", func(c *api.AppConfig) { c.SecretFiles = map[string]string{"/etc/DB_PASSWORD": ""} }, "file /etc/DB_PASSWORD has the same name as the secret DB_PASSWORD"},
+		{"invalid size", func(c *api.AppConfig) { c.Storage[0].Size = "big" }, "storage data: invalid size"},
+		{"nothing to expose", func(c *api.AppConfig) { c.Ports = nil }, "application web has no ports to expose"},
+	}
+
+	for _, tc := range cases {
+		config := newAppConfig()
+		tc.config(&config)
+		if _, err := NewApp(config); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: expected error %q, got %v", tc.description, tc.expected, err)
+		}
+	}
+}
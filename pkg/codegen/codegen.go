@@ -0,0 +1,403 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultPackage  = "main"
+	defaultFunction = "AddComponents"
+)
+
+// Config defines the Go source generated from the manifests
+type Config struct {
+	// Package is the package of the generated file, defaults to main
+	Package string
+	// Function is the name of the generated function, defaults to AddComponents
+	Function string
+}
+
+// Result defines the generated Go source
+type Result struct {
+	Source []byte
+	// Unsupported lists the fields of the manifests that the generated code doesn't set
+	Unsupported []string
+}
+
+// Generate reads YAML or JSON manifests and returns the Go source of a function
+// adding the same components to a deployer.  The generated calls are run while
+// generating the source, and the fields of the manifests that they don't
+// reproduce are reported in the result and as comments in the source
+func Generate(r io.Reader, config Config) (*Result, error) {
+	if len(config.Package) == 0 {
+		config.Package = defaultPackage
+	}
+	if len(config.Function) == 0 {
+		config.Function = defaultFunction
+	}
+
+	decoded, err := components.DecodeComponents(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) == 0 {
+		return nil, fmt.Errorf("no components found in the manifests")
+	}
+
+	g := newGenerator()
+	result := &Result{}
+	for _, d := range decoded {
+		b, err := g.component(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.verify(d.Component); err != nil {
+			return nil, fmt.Errorf("unable to generate %s: %v", b.description, err)
+		}
+		for _, field := range b.unsupported {
+			result.Unsupported = append(result.Unsupported, fmt.Sprintf("%s: %s", b.description, field))
+		}
+		g.blocks = append(g.blocks, b)
+	}
+
+	result.Source, err = g.source(config)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// generator collects the calls creating each component
+type generator struct {
+	blocks    []*block
+	variables map[string]bool
+	printer   *printer
+}
+
+func newGenerator() *generator {
+	return &generator{variables: map[string]bool{}, printer: newPrinter()}
+}
+
+// variable returns an unused variable name made of the object name and a suffix
+func (g *generator) variable(name string, suffix string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words = append(words, suffix)
+
+	base := ""
+	for i, w := range words {
+		runes := []rune(w)
+		if i == 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		base = base + string(runes)
+	}
+	if !unicode.IsLetter([]rune(base)[0]) {
+		base = "c" + base
+	}
+
+	variable := base
+	for i := 2; g.variables[variable]; i++ {
+		variable = fmt.Sprintf("%s%d", base, i)
+	}
+	g.variables[variable] = true
+	return variable
+}
+
+// call defines a call to a components constructor, when recv is empty, or to
+// a method of a variable
+type call struct {
+	assign string
+	recv   string
+	fn     string
+	args   []interface{}
+}
+
+// varRef is a call argument referring to a variable
+type varRef string
+
+// derefVar is a call argument referring to the value of a pointer variable
+type derefVar string
+
+// constructors defines the components constructors the generated code can call
+var constructors = map[string]interface{}{
+	"NewClusterRole":             components.NewClusterRole,
+	"NewClusterRoleBinding":      components.NewClusterRoleBinding,
+	"NewConfigMap":               components.NewConfigMap,
+	"NewConfigMapVolume":         components.NewConfigMapVolume,
+	"NewContainer":               components.NewContainer,
+	"NewCustomResourceDefintion": components.NewCustomResourceDefintion,
+	"NewDaemonSet":               components.NewDaemonSet,
+	"NewDeployment":              components.NewDeployment,
+	"NewEmptyDirVolume":          components.NewEmptyDirVolume,
+	"NewGCEPersistentDiskVolume": components.NewGCEPersistentDiskVolume,
+	"NewHorizontalPodAutoscaler": components.NewHorizontalPodAutoscaler,
+	"NewHostPathVolume":          components.NewHostPathVolume,
+	"NewIngress":                 components.NewIngress,
+	"NewJob":                     components.NewJob,
+	"NewNamespace":               components.NewNamespace,
+	"NewPVCVolume":               components.NewPVCVolume,
+	"NewPersistentVolumeClaim":   components.NewPersistentVolumeClaim,
+	"NewPod":                     components.NewPod,
+	"NewReplicationController":   components.NewReplicationController,
+	"NewRole":                    components.NewRole,
+	"NewRoleBinding":             components.NewRoleBinding,
+	"NewSecret":                  components.NewSecret,
+	"NewSecretVolume":            components.NewSecretVolume,
+	"NewService":                 components.NewService,
+	"NewServiceAccount":          components.NewServiceAccount,
+	"NewStatefulSet":             components.NewStatefulSet,
+}
+
+// block defines the calls creating a single component
+type block struct {
+	kind        api.ComponentType
+	description string
+	variable    string
+	calls       []call
+	unsupported []string
+	vars        map[string]reflect.Value
+}
+
+// assign adds a call to a constructor assigning the result to a variable
+func (b *block) assign(variable string, fn string, args ...interface{}) {
+	b.calls = append(b.calls, call{assign: variable, fn: fn, args: args})
+}
+
+// add adds a call to a method of a variable
+func (b *block) add(recv string, fn string, args ...interface{}) {
+	b.calls = append(b.calls, call{recv: recv, fn: fn, args: args})
+}
+
+// run performs the calls of the block
+func (b *block) run() error {
+	b.vars = map[string]reflect.Value{}
+	for _, c := range b.calls {
+		f := b.function(c)
+		args := []reflect.Value{}
+		for i, a := range c.args {
+			switch a := a.(type) {
+			case varRef:
+				args = append(args, b.vars[string(a)])
+			case derefVar:
+				args = append(args, b.vars[string(a)].Elem())
+			default:
+				arg := reflect.New(f.Type().In(i)).Elem()
+				arg.Set(reflect.ValueOf(a))
+				args = append(args, arg)
+			}
+		}
+
+		out := f.Call(args)
+		if returnsError(f.Type()) {
+			if err := out[len(out)-1]; !err.IsNil() {
+				return fmt.Errorf("%s failed: %v", c.fn, err.Interface())
+			}
+		}
+		if len(c.assign) > 0 {
+			b.vars[c.assign] = out[0]
+		}
+	}
+	return nil
+}
+
+func (b *block) function(c call) reflect.Value {
+	if len(c.recv) == 0 {
+		return reflect.ValueOf(constructors[c.fn])
+	}
+	return b.vars[c.recv].MethodByName(c.fn)
+}
+
+// verify runs the calls of the block and records the fields of the original
+// component that the created component doesn't match
+func (b *block) verify(original interface{}) error {
+	if err := b.run(); err != nil {
+		return err
+	}
+
+	expected, err := toMap(original)
+	if err != nil {
+		return err
+	}
+	actual, err := toMap(b.vars[b.variable].Interface())
+	if err != nil {
+		return err
+	}
+
+	delete(expected, "status")
+	if metadata, ok := expected["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"creationTimestamp", "generation", "resourceVersion", "selfLink", "uid"} {
+			delete(metadata, field)
+		}
+	}
+	b.unsupported = append(b.unsupported, differences("", expected, actual)...)
+	return nil
+}
+
+func toMap(obj interface{}) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	err = yaml.Unmarshal(data, &m)
+	return m, err
+}
+
+// differences returns the paths of the fields of expected that are missing
+// or different in actual
+func differences(path string, expected interface{}, actual interface{}) []string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		diffs := []string{}
+		for _, k := range sortedKeys(e) {
+			if e[k] == nil {
+				continue
+			}
+			childPath := k
+			if len(path) > 0 {
+				childPath = path + "." + k
+			}
+			diffs = append(diffs, differences(childPath, e[k], a[k])...)
+		}
+		return diffs
+
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			break
+		}
+		diffs := []string{}
+		for i := range e {
+			diffs = append(diffs, differences(fmt.Sprintf("%s[%d]", path, i), e[i], a[i])...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+	return []string{path}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func returnsError(t reflect.Type) bool {
+	return t.NumOut() > 0 && t.Out(t.NumOut()-1) == reflect.TypeOf((*error)(nil)).Elem()
+}
+
+// source renders the Go source of the generated function
+func (g *generator) source(config Config) ([]byte, error) {
+	body := &bytes.Buffer{}
+	for _, b := range g.blocks {
+		if len(b.unsupported) > 0 {
+			fmt.Fprintf(body, "// TODO: the following fields of %s are not generated:\n", b.description)
+			for _, field := range b.unsupported {
+				fmt.Fprintf(body, "//   %s\n", field)
+			}
+		}
+		for _, c := range b.calls {
+			g.writeCall(body, b, c)
+		}
+		fmt.Fprintf(body, "d.AddComponent(%s, %s)\n\n", g.printer.literal(reflect.ValueOf(b.kind), false), b.variable)
+	}
+
+	src := &bytes.Buffer{}
+	fmt.Fprintf(src, "package %s\n\n", config.Package)
+	fmt.Fprintf(src, "import (\n\t%q\n\t%q\n\t%q\n)\n\n", apiPackage, "github.com/blackducksoftware/horizon/pkg/components", "github.com/blackducksoftware/horizon/pkg/deployer")
+	fmt.Fprintf(src, "// %s adds the components to the deployer\n", config.Function)
+	fmt.Fprintf(src, "func %s(d *deployer.Deployer) error {\n%sreturn nil\n}\n", config.Function, body.String())
+
+	helpers := []string{}
+	for name := range g.printer.helpers {
+		helpers = append(helpers, name)
+	}
+	sort.Strings(helpers)
+	for _, name := range helpers {
+		fmt.Fprintf(src, "\n%s", g.printer.helpers[name])
+	}
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format the generated source: %v", err)
+	}
+	return formatted, nil
+}
+
+func (g *generator) writeCall(w io.Writer, b *block, c call) {
+	f := b.function(c)
+	args := []string{}
+	for _, a := range c.args {
+		switch a := a.(type) {
+		case varRef:
+			args = append(args, string(a))
+		case derefVar:
+			args = append(args, "*"+string(a))
+		default:
+			args = append(args, g.printer.literal(reflect.ValueOf(a), false))
+		}
+	}
+
+	expr := fmt.Sprintf("components.%s(%s)", c.fn, strings.Join(args, ", "))
+	if len(c.recv) > 0 {
+		expr = fmt.Sprintf("%s.%s(%s)", c.recv, c.fn, strings.Join(args, ", "))
+	}
+
+	switch {
+	case len(c.assign) > 0 && returnsError(f.Type()):
+		fmt.Fprintf(w, "%s, err := %s\nif err != nil {\nreturn err\n}\n", c.assign, expr)
+	case len(c.assign) > 0:
+		fmt.Fprintf(w, "%s := %s\n", c.assign, expr)
+	case returnsError(f.Type()):
+		fmt.Fprintf(w, "if err := %s; err != nil {\nreturn err\n}\n", expr)
+	default:
+		fmt.Fprintf(w, "%s\n", expr)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+)
+
+const manifests = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+  namespace: shop
+data:
+  mode: production
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  labels:
+    app: web
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      affinity:
+        nodeAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            nodeSelectorTerms:
+            - matchExpressions:
+              - key: disk
+                operator: In
+                values: [ssd]
+      volumes:
+      - name: config
+        configMap:
+          name: web-config
+      containers:
+      - name: nginx
+        image: nginx:1.15
+        env:
+        - name: MODE
+          valueFrom:
+            configMapKeyRef:
+              name: web-config
+              key: mode
+        ports:
+        - containerPort: 80
+        resources:
+          limits:
+            memory: 1Gi
+        volumeMounts:
+        - name: config
+          mountPath: /etc/web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+spec:
+  selector:
+    app: web
+  ports:
+  - port: 80
+    targetPort: 8080
+`
+
+func TestGenerate(t *testing.T) {
+	result, err := Generate(strings.NewReader(manifests), Config{Package: "shop", Function: "AddShop"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "shop.go", result.Source, 0); err != nil {
+		t.Fatalf("unable to parse the generated source: %v\n%s", err, result.Source)
+	}
+
+	expected := []string{
+		"package shop",
+		"func AddShop(d *deployer.Deployer) error {",
+		"webConfigConfigMap := components.NewConfigMap(api.ConfigMapConfig{",
+		"Replicas:  int32Ptr(3),",
+		"webDeployment.AddMatchLabelsSelectors(map[string]string{",
+		"nginxContainer, err := components.NewContainer(api.ContainerConfig{",
+		"Type:         api.EnvFromConfigMap,",
+		"if err := webDeploymentPod.AddContainer(nginxContainer); err != nil {",
+		"webDeployment.AddPod(webDeploymentPod)",
+		`TargetPort: "8080",`,
+		"d.AddComponent(api.ServiceComponent, webService)",
+		"func int32Ptr(v int32) *int32 {",
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(result.Source), e) {
+			t.Errorf("expected the generated source to contain %q\n%s", e, result.Source)
+		}
+	}
+
+	unsupported := []string{"Deployment shop/web: spec.template.spec.affinity"}
+	if !reflect.DeepEqual(result.Unsupported, unsupported) {
+		t.Errorf("expected unsupported fields %v, got %v", unsupported, result.Unsupported)
+	}
+	if !strings.Contains(string(result.Source), "// TODO: the following fields of Deployment shop/web are not generated:") {
+		t.Errorf("expected the unsupported fields to be commented in the source")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testcases := []struct {
+		Name string
+		Data string
+	}{
+		{Name: "empty", Data: "---\n"},
+		{Name: "invalid", Data: "kind: Unknown\napiVersion: v1\n"},
+	}
+
+	for _, tc := range testcases {
+		if _, err := Generate(strings.NewReader(tc.Data), Config{}); err == nil {
+			t.Errorf("%s: expected an error", tc.Name)
+		}
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	procMount := api.ProcMountTypeUmasked
+	testcases := []struct {
+		Value    interface{}
+		Expected string
+	}{
+		{Value: "a\"b", Expected: `"a\"b"`},
+		{Value: api.EnvFromSecret, Expected: "api.EnvFromSecret"},
+		{Value: api.StatefulSetComponent, Expected: "api.StatefulSetComponent"},
+		{Value: api.EnvType(100), Expected: "api.EnvType(100)"},
+		{Value: []string{"a", "b"}, Expected: `[]string{"a", "b"}`},
+		{Value: []byte("data"), Expected: `[]byte("data")`},
+		{Value: &procMount, Expected: "procMountTypePtr(api.ProcMountTypeUmasked)"},
+		{Value: api.SELinuxType{}, Expected: "api.SELinuxType{}"},
+		{Value: &api.SELinuxType{User: "u"}, Expected: "&api.SELinuxType{\nUser: \"u\",\n}"},
+		{Value: []api.KeyPath{{Key: "k"}}, Expected: "[]api.KeyPath{\n{\nKey: \"k\",\n},\n}"},
+		{Value: map[string][]byte{"b": nil, "a": []byte("x")}, Expected: "map[string][]byte{\n\"a\": []byte(\"x\"),\n\"b\": nil,\n}"},
+	}
+
+	for _, tc := range testcases {
+		p := newPrinter()
+		if actual := p.literal(reflect.ValueOf(tc.Value), false); actual != tc.Expected {
+			t.Errorf("expected %q, got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func TestVariable(t *testing.T) {
+	g := newGenerator()
+	testcases := []struct {
+		Name     string
+		Suffix   string
+		Expected string
+	}{
+		{Name: "my-app", Suffix: "Deployment", Expected: "myAppDeployment"},
+		{Name: "my.app", Suffix: "Deployment", Expected: "myAppDeployment2"},
+		{Name: "1st", Suffix: "Service", Expected: "c1stService"},
+		{Name: "", Suffix: "Pod", Expected: "pod"},
+	}
+
+	for _, tc := range testcases {
+		if actual := g.variable(tc.Name, tc.Suffix); actual != tc.Expected {
+			t.Errorf("expected %s, got %s", tc.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,1173 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// component returns the calls creating a decoded component
+func (g *generator) component(d components.DecodedComponent) (*block, error) {
+	b := &block{kind: d.Kind}
+	switch c := d.Component.(type) {
+	case *components.Namespace:
+		b.describe("Namespace", c.ObjectMeta)
+		g.namespace(b, c.Namespace)
+	case *components.ConfigMap:
+		b.describe("ConfigMap", c.ObjectMeta)
+		g.configMap(b, c.ConfigMap)
+	case *components.Secret:
+		b.describe("Secret", c.ObjectMeta)
+		g.secret(b, c.Secret)
+	case *components.ServiceAccount:
+		b.describe("ServiceAccount", c.ObjectMeta)
+		g.serviceAccount(b, c.ServiceAccount)
+	case *components.ClusterRole:
+		b.describe("ClusterRole", c.ObjectMeta)
+		g.clusterRole(b, c.ClusterRole)
+	case *components.Role:
+		b.describe("Role", c.ObjectMeta)
+		g.role(b, c.Role)
+	case *components.ClusterRoleBinding:
+		b.describe("ClusterRoleBinding", c.ObjectMeta)
+		g.clusterRoleBinding(b, c.ClusterRoleBinding)
+	case *components.RoleBinding:
+		b.describe("RoleBinding", c.ObjectMeta)
+		g.roleBinding(b, c.RoleBinding)
+	case *components.Service:
+		b.describe("Service", c.ObjectMeta)
+		g.service(b, c.Service)
+	case *components.PersistentVolumeClaim:
+		b.describe("PersistentVolumeClaim", c.ObjectMeta)
+		b.variable = g.variable(c.Name, "PVC")
+		g.persistentVolumeClaim(b, b.variable, c.PersistentVolumeClaim)
+	case *components.Deployment:
+		b.describe("Deployment", c.ObjectMeta)
+		g.deployment(b, c.Deployment)
+	case *components.StatefulSet:
+		b.describe("StatefulSet", c.ObjectMeta)
+		g.statefulSet(b, c.StatefulSet)
+	case *components.DaemonSet:
+		b.describe("DaemonSet", c.ObjectMeta)
+		g.daemonSet(b, c.DaemonSet)
+	case *components.Job:
+		b.describe("Job", c.ObjectMeta)
+		g.job(b, c.Job)
+	case *components.ReplicationController:
+		b.describe("ReplicationController", c.ObjectMeta)
+		g.replicationController(b, c.ReplicationController)
+	case *components.Pod:
+		b.describe("Pod", c.ObjectMeta)
+		b.variable = g.variable(c.Name, "Pod")
+		g.pod(b, b.variable, c.ObjectMeta, c.Spec, c.APIVersion)
+	case *components.HorizontalPodAutoscaler:
+		b.describe("HorizontalPodAutoscaler", c.ObjectMeta)
+		g.horizontalPodAutoscaler(b, c)
+	case *components.Ingress:
+		b.describe("Ingress", c.ObjectMeta)
+		g.ingress(b, c)
+	case *components.CustomResourceDefinition:
+		b.describe("CustomResourceDefinition", c.ObjectMeta)
+		g.customResourceDefinition(b, c.CustomResourceDefinition)
+	default:
+		return nil, fmt.Errorf("%s is not supported by the generator", d.Kind)
+	}
+	return b, nil
+}
+
+func (b *block) describe(kind string, m metav1.ObjectMeta) {
+	b.description = fmt.Sprintf("%s %s", kind, m.Name)
+	if len(m.Namespace) > 0 {
+		b.description = fmt.Sprintf("%s %s/%s", kind, m.Namespace, m.Name)
+	}
+}
+
+// apiVersion returns the api version to set in a config, empty when the
+// constructor default is used
+func apiVersion(version string, defaultVersion string) string {
+	if version == defaultVersion {
+		return ""
+	}
+	return version
+}
+
+func intOrString(v *intstr.IntOrString) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+func (b *block) metadata(variable string, m metav1.ObjectMeta) {
+	if len(m.Labels) > 0 {
+		b.add(variable, "AddLabels", m.Labels)
+	}
+	if len(m.Annotations) > 0 {
+		b.add(variable, "AddAnnotations", m.Annotations)
+	}
+	if len(m.Finalizers) > 0 {
+		b.add(variable, "AddFinalizers", m.Finalizers)
+	}
+}
+
+func (b *block) selector(variable string, selector *metav1.LabelSelector) {
+	if selector == nil {
+		return
+	}
+	if len(selector.MatchLabels) > 0 {
+		b.add(variable, "AddMatchLabelsSelectors", selector.MatchLabels)
+	}
+	for _, req := range selector.MatchExpressions {
+		b.add(variable, "AddMatchExpressionsSelector", expression(req))
+	}
+}
+
+func selectorConfig(selector metav1.LabelSelector) api.SelectorConfig {
+	config := api.SelectorConfig{Labels: selector.MatchLabels}
+	for _, req := range selector.MatchExpressions {
+		config.Expressions = append(config.Expressions, expression(req))
+	}
+	return config
+}
+
+func expression(req metav1.LabelSelectorRequirement) api.ExpressionRequirementConfig {
+	config := api.ExpressionRequirementConfig{Key: req.Key, Values: req.Values}
+	switch req.Operator {
+	case metav1.LabelSelectorOpIn:
+		config.Op = api.ExpressionRequirementOpIn
+	case metav1.LabelSelectorOpNotIn:
+		config.Op = api.ExpressionRequirementOpNotIn
+	case metav1.LabelSelectorOpExists:
+		config.Op = api.ExpressionRequirementOpExists
+	case metav1.LabelSelectorOpDoesNotExist:
+		config.Op = api.ExpressionRequirementOpDoesNotExist
+	}
+	return config
+}
+
+func (g *generator) namespace(b *block, ns *v1.Namespace) {
+	b.variable = g.variable(ns.Name, "Namespace")
+	b.assign(b.variable, "NewNamespace", api.NamespaceConfig{
+		APIVersion: apiVersion(ns.APIVersion, "v1"),
+		Name:       ns.Name,
+	})
+	b.metadata(b.variable, ns.ObjectMeta)
+}
+
+func (g *generator) configMap(b *block, cm *v1.ConfigMap) {
+	b.variable = g.variable(cm.Name, "ConfigMap")
+	b.assign(b.variable, "NewConfigMap", api.ConfigMapConfig{
+		APIVersion: apiVersion(cm.APIVersion, "v1"),
+		Name:       cm.Name,
+		Namespace:  cm.Namespace,
+	})
+	b.metadata(b.variable, cm.ObjectMeta)
+	if len(cm.Data) > 0 {
+		b.add(b.variable, "AddData", cm.Data)
+	}
+	if len(cm.BinaryData) > 0 {
+		b.add(b.variable, "AddBinaryData", cm.BinaryData)
+	}
+}
+
+var secretTypes = map[v1.SecretType]api.SecretType{
+	v1.SecretTypeOpaque:              api.SecretTypeOpaque,
+	v1.SecretTypeServiceAccountToken: api.SecretTypeServiceAccountToken,
+	v1.SecretTypeDockercfg:           api.SecretTypeDockercfg,
+	v1.SecretTypeDockerConfigJson:    api.SecretTypeDockerConfigJSON,
+	v1.SecretTypeBasicAuth:           api.SecretTypeBasicAuth,
+	v1.SecretTypeSSHAuth:             api.SecretTypeSSHAuth,
+	v1.SecretTypeTLS:                 api.SecretTypeTLS,
+	v1.SecretTypeBootstrapToken:      api.SecretTypeBootstrapToken,
+}
+
+func (g *generator) secret(b *block, s *v1.Secret) {
+	b.variable = g.variable(s.Name, "Secret")
+	b.assign(b.variable, "NewSecret", api.SecretConfig{
+		APIVersion: apiVersion(s.APIVersion, "v1"),
+		Name:       s.Name,
+		Namespace:  s.Namespace,
+		Type:       secretTypes[s.Type],
+	})
+	b.metadata(b.variable, s.ObjectMeta)
+	if len(s.Data) > 0 {
+		b.add(b.variable, "AddData", s.Data)
+	}
+	if len(s.StringData) > 0 {
+		b.add(b.variable, "AddStringData", s.StringData)
+	}
+}
+
+func (g *generator) serviceAccount(b *block, sa *v1.ServiceAccount) {
+	b.variable = g.variable(sa.Name, "ServiceAccount")
+	b.assign(b.variable, "NewServiceAccount", api.ServiceAccountConfig{
+		APIVersion:     apiVersion(sa.APIVersion, "v1"),
+		Name:           sa.Name,
+		Namespace:      sa.Namespace,
+		AutomountToken: sa.AutomountServiceAccountToken,
+	})
+	b.metadata(b.variable, sa.ObjectMeta)
+	if len(sa.ImagePullSecrets) > 0 {
+		b.add(b.variable, "AddPullSecrets", localObjectNames(sa.ImagePullSecrets))
+	}
+	for _, s := range sa.Secrets {
+		b.add(b.variable, "AddSecret", api.ServiceAccountSecretConfig{
+			Kind:            s.Kind,
+			Namespace:       s.Namespace,
+			Name:            s.Name,
+			UID:             string(s.UID),
+			Version:         s.APIVersion,
+			ResourceVersion: s.ResourceVersion,
+			FieldPath:       s.FieldPath,
+		})
+	}
+}
+
+func localObjectNames(refs []v1.LocalObjectReference) []string {
+	names := []string{}
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+func policyRules(b *block, variable string, rules []rbacv1.PolicyRule) {
+	for _, r := range rules {
+		b.add(variable, "AddPolicyRule", api.PolicyRuleConfig{
+			Verbs:           r.Verbs,
+			APIGroups:       r.APIGroups,
+			Resources:       r.Resources,
+			ResourceNames:   r.ResourceNames,
+			NonResourceURLs: r.NonResourceURLs,
+		})
+	}
+}
+
+func (g *generator) clusterRole(b *block, cr *rbacv1.ClusterRole) {
+	b.variable = g.variable(cr.Name, "ClusterRole")
+	b.assign(b.variable, "NewClusterRole", api.ClusterRoleConfig{
+		APIVersion: apiVersion(cr.APIVersion, "rbac.authorization.k8s.io/v1"),
+		Name:       cr.Name,
+	})
+	b.metadata(b.variable, cr.ObjectMeta)
+	policyRules(b, b.variable, cr.Rules)
+	if cr.AggregationRule != nil {
+		for _, selector := range cr.AggregationRule.ClusterRoleSelectors {
+			b.add(b.variable, "AddAggregationRule", selectorConfig(selector))
+		}
+	}
+}
+
+func (g *generator) role(b *block, r *rbacv1.Role) {
+	b.variable = g.variable(r.Name, "Role")
+	b.assign(b.variable, "NewRole", api.RoleConfig{
+		APIVersion: apiVersion(r.APIVersion, "rbac.authorization.k8s.io/v1"),
+		Name:       r.Name,
+		Namespace:  r.Namespace,
+	})
+	b.metadata(b.variable, r.ObjectMeta)
+	policyRules(b, b.variable, r.Rules)
+}
+
+func bindingCalls(b *block, variable string, subjects []rbacv1.Subject, ref rbacv1.RoleRef) {
+	for _, s := range subjects {
+		b.add(variable, "AddSubject", api.SubjectConfig{
+			Kind:      s.Kind,
+			APIGroup:  s.APIGroup,
+			Name:      s.Name,
+			Namespace: s.Namespace,
+		})
+	}
+	b.add(variable, "AddRoleRef", api.RoleRefConfig{
+		APIGroup: ref.APIGroup,
+		Kind:     ref.Kind,
+		Name:     ref.Name,
+	})
+}
+
+func (g *generator) clusterRoleBinding(b *block, crb *rbacv1.ClusterRoleBinding) {
+	b.variable = g.variable(crb.Name, "ClusterRoleBinding")
+	b.assign(b.variable, "NewClusterRoleBinding", api.ClusterRoleBindingConfig{
+		APIVersion: apiVersion(crb.APIVersion, "rbac.authorization.k8s.io/v1"),
+		Name:       crb.Name,
+	})
+	b.metadata(b.variable, crb.ObjectMeta)
+	bindingCalls(b, b.variable, crb.Subjects, crb.RoleRef)
+}
+
+func (g *generator) roleBinding(b *block, rb *rbacv1.RoleBinding) {
+	b.variable = g.variable(rb.Name, "RoleBinding")
+	b.assign(b.variable, "NewRoleBinding", api.RoleBindingConfig{
+		APIVersion: apiVersion(rb.APIVersion, "rbac.authorization.k8s.io/v1"),
+		Name:       rb.Name,
+		Namespace:  rb.Namespace,
+	})
+	b.metadata(b.variable, rb.ObjectMeta)
+	bindingCalls(b, b.variable, rb.Subjects, rb.RoleRef)
+}
+
+var protocols = map[v1.Protocol]api.ProtocolType{
+	v1.ProtocolTCP:  api.ProtocolTCP,
+	v1.ProtocolUDP:  api.ProtocolUDP,
+	v1.ProtocolSCTP: api.ProtocolSCTP,
+}
+
+var serviceTypes = map[v1.ServiceType]api.ServiceType{
+	v1.ServiceTypeClusterIP:    api.ServiceTypeServiceIP,
+	v1.ServiceTypeNodePort:     api.ServiceTypeNodePort,
+	v1.ServiceTypeLoadBalancer: api.ServiceTypeLoadBalancer,
+	v1.ServiceTypeExternalName: api.ServiceTypeExternalName,
+}
+
+func (g *generator) service(b *block, s *v1.Service) {
+	config := api.ServiceConfig{
+		APIVersion:               apiVersion(s.APIVersion, "v1"),
+		Name:                     s.Name,
+		Namespace:                s.Namespace,
+		ExternalName:             s.Spec.ExternalName,
+		Type:                     serviceTypes[s.Spec.Type],
+		ClusterIP:                s.Spec.ClusterIP,
+		PublishNotReadyAddresses: s.Spec.PublishNotReadyAddresses,
+	}
+	switch s.Spec.ExternalTrafficPolicy {
+	case v1.ServiceExternalTrafficPolicyTypeLocal:
+		config.TrafficPolicy = api.ServiceTrafficPolicyLocal
+	case v1.ServiceExternalTrafficPolicyTypeCluster:
+		config.TrafficPolicy = api.ServiceTrafficPolicyCluster
+	}
+	switch s.Spec.SessionAffinity {
+	case v1.ServiceAffinityClientIP:
+		config.Affinity = api.ServiceAffinityTypeClientIP
+	case v1.ServiceAffinityNone:
+		config.Affinity = api.ServiceAffinityTypeNone
+	}
+	if s.Spec.SessionAffinityConfig != nil && s.Spec.SessionAffinityConfig.ClientIP != nil {
+		config.IPTimeout = s.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds
+	}
+
+	b.variable = g.variable(s.Name, "Service")
+	b.assign(b.variable, "NewService", config)
+	b.metadata(b.variable, s.ObjectMeta)
+	if len(s.Spec.Selector) > 0 {
+		b.add(b.variable, "AddSelectors", s.Spec.Selector)
+	}
+	for _, p := range s.Spec.Ports {
+		port := api.ServicePortConfig{
+			Name:     p.Name,
+			Port:     p.Port,
+			NodePort: p.NodePort,
+			Protocol: protocols[p.Protocol],
+		}
+		if p.TargetPort.Type == intstr.String || p.TargetPort.IntVal != 0 {
+			port.TargetPort = p.TargetPort.String()
+		}
+		b.add(b.variable, "AddPort", port)
+	}
+	if len(s.Spec.ExternalIPs) > 0 {
+		b.add(b.variable, "AddExternalIPs", s.Spec.ExternalIPs)
+	}
+	if s.Spec.Type == v1.ServiceTypeLoadBalancer && (len(s.Spec.LoadBalancerIP) > 0 || len(s.Spec.LoadBalancerSourceRanges) > 0 || s.Spec.HealthCheckNodePort != 0) {
+		b.add(b.variable, "AddLoadBalancer", api.LoadBalancerConfig{
+			IP:                  s.Spec.LoadBalancerIP,
+			AllowedIPs:          s.Spec.LoadBalancerSourceRanges,
+			HealthCheckNodePort: s.Spec.HealthCheckNodePort,
+		})
+	}
+}
+
+var accessModes = map[v1.PersistentVolumeAccessMode]api.PVCAccessModeType{
+	v1.ReadWriteOnce: api.ReadWriteOnce,
+	v1.ReadOnlyMany:  api.ReadOnlyMany,
+	v1.ReadWriteMany: api.ReadWriteMany,
+}
+
+func (g *generator) persistentVolumeClaim(b *block, variable string, pvc *v1.PersistentVolumeClaim) {
+	config := api.PVCConfig{
+		APIVersion: apiVersion(pvc.APIVersion, "v1"),
+		Name:       pvc.Name,
+		Namespace:  pvc.Namespace,
+		Class:      pvc.Spec.StorageClassName,
+		VolumeName: pvc.Spec.VolumeName,
+	}
+	if size, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		config.Size = size.String()
+	}
+	if pvc.Spec.VolumeMode != nil {
+		switch *pvc.Spec.VolumeMode {
+		case v1.PersistentVolumeBlock:
+			config.Mode = api.PVCModeBLock
+		case v1.PersistentVolumeFilesystem:
+			config.Mode = api.PVCModeFilesystem
+		}
+	}
+	if pvc.Spec.DataSource != nil {
+		config.DataSourceAPIGroup = pvc.Spec.DataSource.APIGroup
+		config.DataSourceKind = pvc.Spec.DataSource.Kind
+		config.DataSourceName = pvc.Spec.DataSource.Name
+	}
+
+	b.assign(variable, "NewPersistentVolumeClaim", config)
+	b.metadata(variable, pvc.ObjectMeta)
+	for _, mode := range pvc.Spec.AccessModes {
+		b.add(variable, "AddAccessMode", accessModes[mode])
+	}
+	if pvc.Spec.Selector != nil {
+		b.selector(variable, pvc.Spec.Selector)
+	}
+}
+
+func (g *generator) deployment(b *block, d *appsv1.Deployment) {
+	config := api.DeploymentConfig{
+		APIVersion:              apiVersion(d.APIVersion, "apps/v1"),
+		Name:                    d.Name,
+		Namespace:               d.Namespace,
+		Replicas:                d.Spec.Replicas,
+		MinReadySeconds:         d.Spec.MinReadySeconds,
+		RevisionHistoryLimit:    d.Spec.RevisionHistoryLimit,
+		Paused:                  d.Spec.Paused,
+		ProgressDeadlineSeconds: d.Spec.ProgressDeadlineSeconds,
+	}
+	switch d.Spec.Strategy.Type {
+	case appsv1.RecreateDeploymentStrategyType:
+		config.Strategy = api.DeploymentStrategyTypeRecreate
+	case appsv1.RollingUpdateDeploymentStrategyType:
+		config.Strategy = api.DeploymentStrategyTypeRollingUpdate
+		if d.Spec.Strategy.RollingUpdate != nil {
+			config.MaxUnavailable = intOrString(d.Spec.Strategy.RollingUpdate.MaxUnavailable)
+			config.MaxExtra = intOrString(d.Spec.Strategy.RollingUpdate.MaxSurge)
+		}
+	}
+
+	b.variable = g.variable(d.Name, "Deployment")
+	b.assign(b.variable, "NewDeployment", config)
+	b.metadata(b.variable, d.ObjectMeta)
+	b.selector(b.variable, d.Spec.Selector)
+	g.podTemplate(b, b.variable, d.Spec.Template)
+}
+
+func (g *generator) statefulSet(b *block, s *appsv1.StatefulSet) {
+	config := api.StatefulSetConfig{
+		APIVersion:           apiVersion(s.APIVersion, "apps/v1"),
+		Name:                 s.Name,
+		Namespace:            s.Namespace,
+		Replicas:             s.Spec.Replicas,
+		RevisionHistoryLimit: s.Spec.RevisionHistoryLimit,
+		Service:              s.Spec.ServiceName,
+	}
+	switch s.Spec.UpdateStrategy.Type {
+	case appsv1.OnDeleteStatefulSetStrategyType:
+		config.UpdateStrategy = api.StatefulSetUpdateStrategyOnDelete
+	case appsv1.RollingUpdateStatefulSetStrategyType:
+		config.UpdateStrategy = api.StatefulSetUpdateStrategyRollingUpdate
+		if s.Spec.UpdateStrategy.RollingUpdate != nil {
+			config.Partition = s.Spec.UpdateStrategy.RollingUpdate.Partition
+		}
+	}
+	switch s.Spec.PodManagementPolicy {
+	case appsv1.OrderedReadyPodManagement:
+		config.PodManagementPolicy = api.PodManagementPolicyOrdered
+	case appsv1.ParallelPodManagement:
+		config.PodManagementPolicy = api.PodManagementPolicyParallel
+	}
+
+	b.variable = g.variable(s.Name, "StatefulSet")
+	b.assign(b.variable, "NewStatefulSet", config)
+	b.metadata(b.variable, s.ObjectMeta)
+	b.selector(b.variable, s.Spec.Selector)
+	g.podTemplate(b, b.variable, s.Spec.Template)
+	for i := range s.Spec.VolumeClaimTemplates {
+		claim := s.Spec.VolumeClaimTemplates[i]
+		variable := g.variable(claim.Name, "ClaimTemplate")
+		g.persistentVolumeClaim(b, variable, &claim)
+		b.add(b.variable, "AddVolumeClaimTemplate", derefVar(variable))
+	}
+}
+
+func (g *generator) daemonSet(b *block, d *appsv1.DaemonSet) {
+	config := api.DaemonSetConfig{
+		APIVersion:           apiVersion(d.APIVersion, "apps/v1"),
+		Name:                 d.Name,
+		Namespace:            d.Namespace,
+		MinReadySeconds:      d.Spec.MinReadySeconds,
+		RevisionHistoryLimit: d.Spec.RevisionHistoryLimit,
+	}
+	switch d.Spec.UpdateStrategy.Type {
+	case appsv1.OnDeleteDaemonSetStrategyType:
+		config.Strategy = api.DaemonSetUpdateStrategyOnDelete
+	case appsv1.RollingUpdateDaemonSetStrategyType:
+		config.Strategy = api.DaemonSetUpdateStrategyRollingUpdate
+		if d.Spec.UpdateStrategy.RollingUpdate != nil {
+			config.MaxUnavailable = intOrString(d.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable)
+		}
+	}
+
+	b.variable = g.variable(d.Name, "DaemonSet")
+	b.assign(b.variable, "NewDaemonSet", config)
+	b.metadata(b.variable, d.ObjectMeta)
+	b.selector(b.variable, d.Spec.Selector)
+	g.podTemplate(b, b.variable, d.Spec.Template)
+}
+
+func (g *generator) job(b *block, j *batchv1.Job) {
+	b.variable = g.variable(j.Name, "Job")
+	b.assign(b.variable, "NewJob", api.JobConfig{
+		APIVersion:            apiVersion(j.APIVersion, "batch/v1"),
+		Name:                  j.Name,
+		Namespace:             j.Namespace,
+		Parallelism:           j.Spec.Parallelism,
+		Completions:           j.Spec.Completions,
+		MaxRetries:            j.Spec.BackoffLimit,
+		ActiveDeadlineSeconds: j.Spec.ActiveDeadlineSeconds,
+		SelectManually:        j.Spec.ManualSelector,
+		DeletionTTL:           j.Spec.TTLSecondsAfterFinished,
+	})
+	b.metadata(b.variable, j.ObjectMeta)
+	b.selector(b.variable, j.Spec.Selector)
+	g.podTemplate(b, b.variable, j.Spec.Template)
+}
+
+func (g *generator) replicationController(b *block, rc *v1.ReplicationController) {
+	b.variable = g.variable(rc.Name, "ReplicationController")
+	b.assign(b.variable, "NewReplicationController", api.ReplicationControllerConfig{
+		APIVersion:   apiVersion(rc.APIVersion, "v1"),
+		Name:         rc.Name,
+		Namespace:    rc.Namespace,
+		Replicas:     rc.Spec.Replicas,
+		ReadySeconds: rc.Spec.MinReadySeconds,
+	})
+	b.metadata(b.variable, rc.ObjectMeta)
+	if len(rc.Spec.Selector) > 0 {
+		b.add(b.variable, "AddSelectors", rc.Spec.Selector)
+	}
+	if rc.Spec.Template != nil {
+		g.podTemplate(b, b.variable, *rc.Spec.Template)
+	}
+}
+
+func (g *generator) horizontalPodAutoscaler(b *block, hpa *components.HorizontalPodAutoscaler) {
+	b.variable = g.variable(hpa.Name, "HPA")
+	b.assign(b.variable, "NewHorizontalPodAutoscaler", api.HPAConfig{
+		APIVersion:                     apiVersion(hpa.APIVersion, "autoscaling/v1"),
+		Name:                           hpa.Name,
+		Namespace:                      hpa.Namespace,
+		MinReplicas:                    hpa.Spec.MinReplicas,
+		MaxReplicas:                    hpa.Spec.MaxReplicas,
+		TargetCPUUtilizationPercentage: hpa.Spec.TargetCPUUtilizationPercentage,
+		ScaleTargetKind:                hpa.Spec.ScaleTargetRef.Kind,
+		ScaleTargetName:                hpa.Spec.ScaleTargetRef.Name,
+		ScaleTargetAPIVersion:          hpa.Spec.ScaleTargetRef.APIVersion,
+	})
+	b.metadata(b.variable, hpa.ObjectMeta)
+}
+
+func (g *generator) ingress(b *block, i *components.Ingress) {
+	config := api.IngressConfig{
+		APIVersion: apiVersion(i.APIVersion, "extensions/v1beta1"),
+		Name:       i.Name,
+		Namespace:  i.Namespace,
+	}
+	if i.Spec.Backend != nil {
+		config.ServiceName = i.Spec.Backend.ServiceName
+		config.ServicePort = i.Spec.Backend.ServicePort.String()
+	}
+
+	b.variable = g.variable(i.Name, "Ingress")
+	b.assign(b.variable, "NewIngress", config)
+	b.metadata(b.variable, i.ObjectMeta)
+	for _, tls := range i.Spec.TLS {
+		b.add(b.variable, "AddTLS", api.IngressTLSConfig{Hosts: tls.Hosts, SecretName: tls.SecretName})
+	}
+	for _, rule := range i.Spec.Rules {
+		config := api.IngressHostRuleConfig{Host: rule.Host}
+		if rule.HTTP != nil {
+			for _, p := range rule.HTTP.Paths {
+				config.Paths = append(config.Paths, api.HTTPIngressPathConfig{
+					Path:        p.Path,
+					ServiceName: p.Backend.ServiceName,
+					ServicePort: p.Backend.ServicePort.String(),
+				})
+			}
+		}
+		b.add(b.variable, "AddHostRule", config)
+	}
+}
+
+func (g *generator) customResourceDefinition(b *block, crd *apiextv1beta1.CustomResourceDefinition) {
+	config := api.CRDConfig{
+		APIVersion:   apiVersion(crd.APIVersion, "apiextensions.k8s.io/v1beta1"),
+		Name:         crd.Name,
+		Group:        crd.Spec.Group,
+		CRDVersion:   crd.Spec.Version,
+		Plural:       crd.Spec.Names.Plural,
+		Singular:     crd.Spec.Names.Singular,
+		ShortNames:   crd.Spec.Names.ShortNames,
+		Kind:         crd.Spec.Names.Kind,
+		ListKind:     crd.Spec.Names.ListKind,
+		Categories:   crd.Spec.Names.Categories,
+		ExtraColumns: crdColumns(crd.Spec.AdditionalPrinterColumns),
+	}
+	switch crd.Spec.Scope {
+	case apiextv1beta1.ClusterScoped:
+		config.Scope = api.CRDClusterScoped
+	case apiextv1beta1.NamespaceScoped:
+		config.Scope = api.CRDNamespaceScoped
+	}
+	config.ScaleSubresources = crdScale(crd.Spec.Subresources)
+	for _, v := range crd.Spec.Versions {
+		config.Versions = append(config.Versions, api.CRDVersion{
+			Name:              v.Name,
+			Enabled:           v.Served,
+			Storage:           v.Storage,
+			ScaleSubresources: crdScale(v.Subresources),
+			ExtraColumns:      crdColumns(v.AdditionalPrinterColumns),
+		})
+	}
+	if c := crd.Spec.Conversion; c != nil {
+		config.ConversionReviewVersions = c.ConversionReviewVersions
+		switch c.Strategy {
+		case apiextv1beta1.NoneConverter:
+			config.ConversionStrategy = api.CRDConversionStraegyTypeNone
+		case apiextv1beta1.WebhookConverter:
+			config.ConversionStrategy = api.CRDConversionStraegyTypeWebhook
+		}
+		if w := c.WebhookClientConfig; w != nil {
+			config.ConversionWebhookURL = w.URL
+			config.ConversionWebhookCABundle = w.CABundle
+			if w.Service != nil {
+				config.ConversionWebhookServiceNamespace = w.Service.Namespace
+				config.ConversionWebhookServiceName = w.Service.Name
+				config.ConversionWebhookServicePath = w.Service.Path
+			}
+		}
+	}
+
+	b.variable = g.variable(crd.Name, "CRD")
+	b.assign(b.variable, "NewCustomResourceDefintion", config)
+	b.metadata(b.variable, crd.ObjectMeta)
+}
+
+func crdScale(s *apiextv1beta1.CustomResourceSubresources) *api.CRDScaleSubresources {
+	if s == nil || s.Scale == nil {
+		return nil
+	}
+	return &api.CRDScaleSubresources{
+		SpecPath:     s.Scale.SpecReplicasPath,
+		StatusPath:   s.Scale.StatusReplicasPath,
+		SelectorPath: s.Scale.LabelSelectorPath,
+	}
+}
+
+func crdColumns(columns []apiextv1beta1.CustomResourceColumnDefinition) []api.CRDColumn {
+	var converted []api.CRDColumn
+	for _, c := range columns {
+		converted = append(converted, api.CRDColumn{
+			Name:        c.Name,
+			Type:        c.Type,
+			Format:      c.Format,
+			Description: c.Description,
+			Priority:    c.Priority,
+			Path:        c.JSONPath,
+		})
+	}
+	return converted
+}
+
+// podTemplate adds the calls creating the pod of a template and adding it to a component
+func (g *generator) podTemplate(b *block, variable string, template v1.PodTemplateSpec) {
+	name := template.Name
+	if len(name) == 0 {
+		name = b.variable
+	}
+	pod := g.variable(name, "Pod")
+	g.pod(b, pod, template.ObjectMeta, template.Spec, "")
+	b.add(variable, "AddPod", varRef(pod))
+}
+
+var restartPolicies = map[v1.RestartPolicy]api.RestartPolicyType{
+	v1.RestartPolicyAlways:    api.RestartPolicyAlways,
+	v1.RestartPolicyOnFailure: api.RestartPolicyOnFailure,
+	v1.RestartPolicyNever:     api.RestartPolicyNever,
+}
+
+var dnsPolicies = map[v1.DNSPolicy]api.DNSPolicyType{
+	v1.DNSClusterFirstWithHostNet: api.DNSClusterFirstWithHostNet,
+	v1.DNSClusterFirst:            api.DNSClusterFirst,
+	v1.DNSDefault:                 api.DNSDefault,
+}
+
+// pod adds the calls creating a pod with the given metadata and spec
+func (g *generator) pod(b *block, variable string, m metav1.ObjectMeta, spec v1.PodSpec, version string) {
+	config := api.PodConfig{
+		APIVersion:             apiVersion(version, "v1"),
+		Name:                   m.Name,
+		Namespace:              m.Namespace,
+		ServiceAccount:         spec.ServiceAccountName,
+		RestartPolicy:          restartPolicies[spec.RestartPolicy],
+		TerminationGracePeriod: spec.TerminationGracePeriodSeconds,
+		ActiveDeadline:         spec.ActiveDeadlineSeconds,
+		Node:                   spec.NodeName,
+		Hostname:               spec.Hostname,
+		SchedulerName:          spec.SchedulerName,
+		PriorityValue:          spec.Priority,
+		PriorityClass:          spec.PriorityClassName,
+		MountSAToken:           spec.AutomountServiceAccountToken,
+		ShareNamespace:         spec.ShareProcessNamespace,
+		RuntimeClass:           spec.RuntimeClassName,
+		ServiceLinks:           spec.EnableServiceLinks,
+	}
+	if len(spec.Subdomain) > 0 {
+		config.Hostname = spec.Hostname + "." + spec.Subdomain
+	}
+	if policy, ok := dnsPolicies[spec.DNSPolicy]; ok && policy != api.DNSClusterFirst {
+		config.DNSPolicy = policy
+	}
+	if sc := spec.SecurityContext; sc != nil {
+		config.SELinux = seLinux(sc.SELinuxOptions)
+		config.RunAsUser = sc.RunAsUser
+		config.RunAsGroup = sc.RunAsGroup
+		config.ForceNonRoot = sc.RunAsNonRoot
+		config.FSGID = sc.FSGroup
+	}
+
+	b.assign(variable, "NewPod", config)
+	b.metadata(variable, m)
+
+	for _, v := range spec.Volumes {
+		if volume := g.volume(b, v); len(volume) > 0 {
+			b.add(variable, "AddVolume", varRef(volume))
+		}
+	}
+	for _, c := range spec.InitContainers {
+		b.add(variable, "AddInitContainer", varRef(g.container(b, c)))
+	}
+	for _, c := range spec.Containers {
+		b.add(variable, "AddContainer", varRef(g.container(b, c)))
+	}
+
+	if spec.HostNetwork {
+		b.add(variable, "AddHostMode", api.HostModeNet)
+	}
+	if spec.HostPID {
+		b.add(variable, "AddHostMode", api.HostModePID)
+	}
+	if spec.HostIPC {
+		b.add(variable, "AddHostMode", api.HostModeIPC)
+	}
+	if sc := spec.SecurityContext; sc != nil {
+		if len(sc.SupplementalGroups) > 0 {
+			b.add(variable, "AddSupplementalGIDs", sc.SupplementalGroups)
+		}
+		if len(sc.Sysctls) > 0 {
+			sysctls := map[string]string{}
+			for _, s := range sc.Sysctls {
+				sysctls[s.Name] = s.Value
+			}
+			b.add(variable, "AddSysctls", sysctls)
+		}
+	}
+	if len(spec.ImagePullSecrets) > 0 {
+		b.add(variable, "AddImagePullSecrets", localObjectNames(spec.ImagePullSecrets))
+	}
+	if len(spec.HostAliases) > 0 {
+		aliases := []api.HostAliasConfig{}
+		for _, a := range spec.HostAliases {
+			aliases = append(aliases, api.HostAliasConfig{IP: a.IP, Hostnames: a.Hostnames})
+		}
+		b.add(variable, "AddHostAliases", aliases)
+	}
+	if len(spec.Tolerations) > 0 {
+		tolerations := []api.TolerationConfig{}
+		for _, t := range spec.Tolerations {
+			tolerations = append(tolerations, toleration(t))
+		}
+		b.add(variable, "AddTolerations", tolerations)
+	}
+	if len(spec.NodeSelector) > 0 {
+		b.add(variable, "AddNodeSelectors", spec.NodeSelector)
+	}
+	if dns := spec.DNSConfig; dns != nil {
+		config := api.PodDNSConfig{Nameservers: dns.Nameservers, SearchDomains: dns.Searches}
+		if len(dns.Options) > 0 {
+			config.ResolverOptions = map[string]string{}
+			for _, o := range dns.Options {
+				config.ResolverOptions[o.Name] = ""
+				if o.Value != nil {
+					config.ResolverOptions[o.Name] = *o.Value
+				}
+			}
+		}
+		b.add(variable, "AddDNSConfig", config)
+	}
+}
+
+func seLinux(options *v1.SELinuxOptions) *api.SELinuxType {
+	if options == nil {
+		return nil
+	}
+	return &api.SELinuxType{Level: options.Level, Role: options.Role, Type: options.Type, User: options.User}
+}
+
+func toleration(t v1.Toleration) api.TolerationConfig {
+	config := api.TolerationConfig{Key: t.Key, Value: t.Value, Duration: t.TolerationSeconds}
+	switch t.Operator {
+	case v1.TolerationOpExists:
+		config.Op = api.TolerationOpExists
+	case v1.TolerationOpEqual:
+		config.Op = api.TolerationOpEqual
+	}
+	switch t.Effect {
+	case v1.TaintEffectNoSchedule:
+		config.Effect = api.TolerationEffectNoSchedule
+	case v1.TaintEffectPreferNoSchedule:
+		config.Effect = api.TolerationEffectPreferNoSchedule
+	case v1.TaintEffectNoExecute:
+		config.Effect = api.TolerationEffectNoExecute
+	}
+	return config
+}
+
+var hostPathTypes = map[v1.HostPathType]api.HostPathType{
+	v1.HostPathUnset:             api.HostPathUnset,
+	v1.HostPathDirectoryOrCreate: api.HostPathDirectoryOrCreate,
+	v1.HostPathDirectory:         api.HostPathDirectory,
+	v1.HostPathFileOrCreate:      api.HostPathFileOrCreate,
+	v1.HostPathFile:              api.HostPathFile,
+	v1.HostPathSocket:            api.HostPathSocket,
+	v1.HostPathCharDev:           api.HostPathCharDev,
+	v1.HostPathBlockDev:          api.HostPathBlockDev,
+}
+
+var storageMediums = map[v1.StorageMedium]api.StorageMediumType{
+	v1.StorageMediumMemory:    api.StorageMediumMemory,
+	v1.StorageMediumHugePages: api.StorageMediumHugePages,
+}
+
+// volume adds the call creating a volume, and returns the name of its variable.
+// An empty name is returned for the volume types the components don't support
+func (g *generator) volume(b *block, v v1.Volume) string {
+	var fn string
+	var config interface{}
+	switch {
+	case v.EmptyDir != nil:
+		c := api.EmptyDirVolumeConfig{VolumeName: v.Name, Medium: storageMediums[v.EmptyDir.Medium]}
+		if v.EmptyDir.SizeLimit != nil {
+			c.SizeLimit = v.EmptyDir.SizeLimit.String()
+		}
+		fn, config = "NewEmptyDirVolume", c
+	case v.HostPath != nil:
+		c := api.HostPathVolumeConfig{VolumeName: v.Name, Path: v.HostPath.Path}
+		if v.HostPath.Type != nil && *v.HostPath.Type != v1.HostPathUnset {
+			c.Type = hostPathTypes[*v.HostPath.Type]
+		}
+		fn, config = "NewHostPathVolume", c
+	case v.ConfigMap != nil:
+		fn, config = "NewConfigMapVolume", api.ConfigMapOrSecretVolumeConfig{
+			VolumeName:      v.Name,
+			MapOrSecretName: v.ConfigMap.Name,
+			Items:           keyPaths(v.ConfigMap.Items),
+			DefaultMode:     v.ConfigMap.DefaultMode,
+			Optional:        v.ConfigMap.Optional,
+		}
+	case v.Secret != nil:
+		fn, config = "NewSecretVolume", api.ConfigMapOrSecretVolumeConfig{
+			VolumeName:      v.Name,
+			MapOrSecretName: v.Secret.SecretName,
+			Items:           keyPaths(v.Secret.Items),
+			DefaultMode:     v.Secret.DefaultMode,
+			Optional:        v.Secret.Optional,
+		}
+	case v.GCEPersistentDisk != nil:
+		fn, config = "NewGCEPersistentDiskVolume", api.GCEPersistentDiskVolumeConfig{
+			VolumeName: v.Name,
+			DiskName:   v.GCEPersistentDisk.PDName,
+			FSType:     v.GCEPersistentDisk.FSType,
+			Partition:  v.GCEPersistentDisk.Partition,
+			ReadOnly:   v.GCEPersistentDisk.ReadOnly,
+		}
+	case v.PersistentVolumeClaim != nil:
+		fn, config = "NewPVCVolume", api.PVCVolumeConfig{
+			VolumeName: v.Name,
+			PVCName:    v.PersistentVolumeClaim.ClaimName,
+			ReadOnly:   v.PersistentVolumeClaim.ReadOnly,
+		}
+	default:
+		return ""
+	}
+
+	variable := g.variable(v.Name, "Volume")
+	b.assign(variable, fn, config)
+	return variable
+}
+
+func keyPaths(items []v1.KeyToPath) []api.KeyPath {
+	var converted []api.KeyPath
+	for _, item := range items {
+		converted = append(converted, api.KeyPath{Key: item.Key, Path: item.Path, Mode: item.Mode})
+	}
+	return converted
+}
+
+var pullPolicies = map[v1.PullPolicy]api.PullPolicyType{
+	v1.PullAlways:       api.PullAlways,
+	v1.PullNever:        api.PullNever,
+	v1.PullIfNotPresent: api.PullIfNotPresent,
+}
+
+var mountPropagations = map[v1.MountPropagationMode]api.MountPropagationType{
+	v1.MountPropagationHostToContainer: api.MountPropagationHostToContainer,
+	v1.MountPropagationBidirectional:   api.MountPropagationBidirectional,
+	v1.MountPropagationNone:            api.MountPropagationNone,
+}
+
+// fieldRefEnvs maps the field paths of the downward API to the env types
+var fieldRefEnvs = map[string]api.EnvType{
+	"metadata.name":           api.EnvFromName,
+	"metadata.namespace":      api.EnvFromNamespace,
+	"metadata.labels":         api.EnvFromLabels,
+	"metadata.annotations":    api.EnvFromAnnotation,
+	"spec.nodeName":           api.EnvFromNodename,
+	"spec.serviceAccountName": api.EnvFromServiceAccountName,
+	"status.hostIP":           api.EnvFromHostIP,
+	"status.podIP":            api.EnvFromPodIP,
+}
+
+var resourceFieldRefEnvs = map[string]api.EnvType{
+	"limits.cpu":                 api.EnvFromCPULimits,
+	"limits.memory":              api.EnvFromMemLimits,
+	"limits.ephemeral-storage":   api.EnvFromEphemeralStorageLimits,
+	"requests.cpu":               api.EnvFromCPURequests,
+	"requests.memory":            api.EnvFromMemRequests,
+	"requests.ephemeral-storage": api.EnvFromEphemeralStorageRequests,
+}
+
+// container adds the calls creating a container, and returns the name of its variable
+func (g *generator) container(b *block, c v1.Container) string {
+	config := api.ContainerConfig{
+		Name:               c.Name,
+		Args:               c.Args,
+		Command:            c.Command,
+		Image:              c.Image,
+		PullPolicy:         pullPolicies[c.ImagePullPolicy],
+		AllocateStdin:      c.Stdin,
+		StdinOnce:          c.StdinOnce,
+		AllocateTTY:        c.TTY,
+		WorkingDirectory:   c.WorkingDir,
+		TerminationMsgPath: c.TerminationMessagePath,
+	}
+	switch c.TerminationMessagePolicy {
+	case v1.TerminationMessageReadFile:
+		config.TerminationMsgPolicy = api.TerminationMessageReadFile
+	case v1.TerminationMessageFallbackToLogsOnError:
+		config.TerminationMsgPolicy = api.TerminationMessageFallbackToLogsOnError
+	}
+	if q, ok := c.Resources.Requests[v1.ResourceCPU]; ok {
+		config.MinCPU = q.String()
+	}
+	if q, ok := c.Resources.Limits[v1.ResourceCPU]; ok {
+		config.MaxCPU = q.String()
+	}
+	if q, ok := c.Resources.Requests[v1.ResourceMemory]; ok {
+		config.MinMem = q.String()
+	}
+	if q, ok := c.Resources.Limits[v1.ResourceMemory]; ok {
+		config.MaxMem = q.String()
+	}
+	if sc := c.SecurityContext; sc != nil {
+		config.Privileged = sc.Privileged
+		config.AllowPrivilegeEscalation = sc.AllowPrivilegeEscalation
+		config.ReadOnlyFS = sc.ReadOnlyRootFilesystem
+		config.ForceNonRoot = sc.RunAsNonRoot
+		config.UID = sc.RunAsUser
+		config.GID = sc.RunAsGroup
+		config.SELinux = seLinux(sc.SELinuxOptions)
+		if sc.ProcMount != nil {
+			procMount := api.ProcMountTypeDefault
+			if *sc.ProcMount == v1.UnmaskedProcMount {
+				procMount = api.ProcMountTypeUmasked
+			}
+			config.ProcMount = &procMount
+		}
+	}
+
+	variable := g.variable(c.Name, "Container")
+	b.assign(variable, "NewContainer", config)
+
+	for _, e := range c.Env {
+		if env, ok := envConfig(e); ok {
+			b.add(variable, "AddEnv", env)
+		}
+	}
+	for _, e := range c.EnvFrom {
+		env := api.EnvConfig{NameOrPrefix: e.Prefix}
+		switch {
+		case e.ConfigMapRef != nil:
+			env.Type, env.FromName, env.Optional = api.EnvFromConfigMap, e.ConfigMapRef.Name, e.ConfigMapRef.Optional
+		case e.SecretRef != nil:
+			env.Type, env.FromName, env.Optional = api.EnvFromSecret, e.SecretRef.Name, e.SecretRef.Optional
+		default:
+			continue
+		}
+		b.add(variable, "AddEnv", env)
+	}
+	for _, p := range c.Ports {
+		b.add(variable, "AddPort", api.PortConfig{
+			Name:          p.Name,
+			Protocol:      protocols[p.Protocol],
+			IP:            p.HostIP,
+			HostPort:      p.HostPort,
+			ContainerPort: p.ContainerPort,
+		})
+	}
+	for _, m := range c.VolumeMounts {
+		mount := api.VolumeMountConfig{MountPath: m.MountPath, Name: m.Name, SubPath: m.SubPath, ReadOnly: m.ReadOnly}
+		if m.MountPropagation != nil {
+			if propagation, ok := mountPropagations[*m.MountPropagation]; ok {
+				mount.Propagation = &propagation
+			}
+		}
+		b.add(variable, "AddVolumeMount", mount)
+	}
+	for _, d := range c.VolumeDevices {
+		b.add(variable, "AddVolumeDevice", api.VolumeDeviceConfig{Name: d.Name, Path: d.DevicePath})
+	}
+	if c.LivenessProbe != nil {
+		if probe, ok := probeConfig(c.LivenessProbe); ok {
+			b.add(variable, "AddLivenessProbe", probe)
+		}
+	}
+	if c.ReadinessProbe != nil {
+		if probe, ok := probeConfig(c.ReadinessProbe); ok {
+			b.add(variable, "AddReadinessProbe", probe)
+		}
+	}
+	if c.Lifecycle != nil {
+		if c.Lifecycle.PostStart != nil {
+			if action, ok := actionConfig(*c.Lifecycle.PostStart); ok {
+				b.add(variable, "AddPostStartAction", action)
+			}
+		}
+		if c.Lifecycle.PreStop != nil {
+			if action, ok := actionConfig(*c.Lifecycle.PreStop); ok {
+				b.add(variable, "AddPreStopAction", action)
+			}
+		}
+	}
+	if c.SecurityContext != nil && c.SecurityContext.Capabilities != nil {
+		if add := capabilities(c.SecurityContext.Capabilities.Add); len(add) > 0 {
+			b.add(variable, "AddAddCapabilities", add)
+		}
+		if drop := capabilities(c.SecurityContext.Capabilities.Drop); len(drop) > 0 {
+			b.add(variable, "AddDeleteCapabilities", drop)
+		}
+	}
+
+	return variable
+}
+
+func capabilities(caps []v1.Capability) []string {
+	converted := []string{}
+	for _, c := range caps {
+		converted = append(converted, string(c))
+	}
+	return converted
+}
+
+func envConfig(e v1.EnvVar) (api.EnvConfig, bool) {
+	env := api.EnvConfig{NameOrPrefix: e.Name}
+	if e.ValueFrom == nil {
+		env.Type, env.KeyOrVal = api.EnvVal, e.Value
+		return env, true
+	}
+
+	from := e.ValueFrom
+	switch {
+	case from.ConfigMapKeyRef != nil:
+		env.Type, env.FromName, env.KeyOrVal, env.Optional = api.EnvFromConfigMap, from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key, from.ConfigMapKeyRef.Optional
+	case from.SecretKeyRef != nil:
+		env.Type, env.FromName, env.KeyOrVal, env.Optional = api.EnvFromSecret, from.SecretKeyRef.Name, from.SecretKeyRef.Key, from.SecretKeyRef.Optional
+	case from.FieldRef != nil:
+		t, ok := fieldRefEnvs[from.FieldRef.FieldPath]
+		if !ok {
+			return env, false
+		}
+		env.Type = t
+	case from.ResourceFieldRef != nil:
+		t, ok := resourceFieldRefEnvs[from.ResourceFieldRef.Resource]
+		if !ok {
+			return env, false
+		}
+		env.Type = t
+	default:
+		return env, false
+	}
+	return env, true
+}
+
+func probeConfig(p *v1.Probe) (api.ProbeConfig, bool) {
+	action, ok := actionConfig(p.Handler)
+	return api.ProbeConfig{
+		ActionConfig:    action,
+		Delay:           p.InitialDelaySeconds,
+		Interval:        p.PeriodSeconds,
+		MinCountSuccess: p.SuccessThreshold,
+		MinCountFailure: p.FailureThreshold,
+		Timeout:         p.TimeoutSeconds,
+	}, ok
+}
+
+func actionConfig(h v1.Handler) (api.ActionConfig, bool) {
+	switch {
+	case h.Exec != nil:
+		return api.ActionConfig{Type: api.ActionTypeCommand, Command: h.Exec.Command}, true
+	case h.HTTPGet != nil:
+		action := api.ActionConfig{
+			Type: api.ActionTypeHTTP,
+			Host: h.HTTPGet.Host,
+			Port: h.HTTPGet.Port.String(),
+			Path: h.HTTPGet.Path,
+		}
+		if h.HTTPGet.Scheme == v1.URISchemeHTTPS {
+			action.Type = api.ActionTypeHTTPS
+		}
+		if len(h.HTTPGet.HTTPHeaders) > 0 {
+			action.Headers = map[string]string{}
+			for _, header := range h.HTTPGet.HTTPHeaders {
+				action.Headers[header.Name] = header.Value
+			}
+		}
+		return action, true
+	case h.TCPSocket != nil:
+		return api.ActionConfig{Type: api.ActionTypeTCP, Host: h.TCPSocket.Host, Port: h.TCPSocket.Port.String()}, true
+	}
+	return api.ActionConfig{}, false
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package codegen
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+)
+
+const apiPackage = "github.com/blackducksoftware/horizon/pkg/api"
+
+// enumNames maps the values of the api enums to the name of their constant
+var enumNames = map[reflect.Type]map[interface{}]string{}
+
+func init() {
+	for name, value := range api.Constants {
+		t := reflect.TypeOf(value)
+		if _, ok := enumNames[t]; !ok {
+			enumNames[t] = map[interface{}]string{}
+		}
+		enumNames[t][value] = "api." + name
+	}
+}
+
+// printer renders values as Go literals, and records the helper functions
+// the literals need to take the address of basic values
+type printer struct {
+	helpers map[string]string
+}
+
+func newPrinter() *printer {
+	return &printer{helpers: map[string]string{}}
+}
+
+// literal returns the Go expression of a value.  The type of structs is
+// omitted when elide is set, as allowed in the elements of a composite literal
+func (p *printer) literal(v reflect.Value, elide bool) string {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			if elide {
+				return p.literal(v.Elem(), true)
+			}
+			return "&" + p.literal(v.Elem(), false)
+		}
+		return fmt.Sprintf("%s(%s)", p.pointerHelper(v.Elem().Type()), p.literal(v.Elem(), false))
+
+	case reflect.Struct:
+		fields := []string{}
+		for i := 0; i < v.NumField(); i++ {
+			if isZero(v.Field(i)) {
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s,\n", v.Type().Field(i).Name, p.literal(v.Field(i), false)))
+		}
+		prefix := typeName(v.Type())
+		if elide {
+			prefix = ""
+		}
+		if len(fields) == 0 {
+			return prefix + "{}"
+		}
+		return prefix + "{\n" + strings.Join(fields, "") + "}"
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return "nil"
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("[]byte(%s)", strconv.Quote(string(v.Bytes())))
+		}
+		elems := []string{}
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, p.literal(v.Index(i), true))
+		}
+		if v.Type().Elem().Kind() == reflect.Struct || v.Type().Elem().Kind() == reflect.Ptr {
+			return typeName(v.Type()) + "{\n" + strings.Join(elems, ",\n") + ",\n}"
+		}
+		return typeName(v.Type()) + "{" + strings.Join(elems, ", ") + "}"
+
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		keys := []string{}
+		entries := map[string]string{}
+		for _, k := range v.MapKeys() {
+			key := p.literal(k, false)
+			keys = append(keys, key)
+			entries[key] = p.literal(v.MapIndex(k), true)
+		}
+		sort.Strings(keys)
+		lines := []string{}
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %s,\n", k, entries[k]))
+		}
+		return typeName(v.Type()) + "{\n" + strings.Join(lines, "") + "}"
+
+	case reflect.String:
+		if name, ok := enumName(v); ok {
+			return name
+		}
+		if v.Type().PkgPath() != "" {
+			return fmt.Sprintf("%s(%s)", typeName(v.Type()), strconv.Quote(v.String()))
+		}
+		return strconv.Quote(v.String())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if name, ok := enumName(v); ok {
+			return name
+		}
+		if v.Type().PkgPath() != "" {
+			return fmt.Sprintf("%s(%d)", typeName(v.Type()), v.Int())
+		}
+		return strconv.FormatInt(v.Int(), 10)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+
+	return fmt.Sprintf("%#v", v.Interface())
+}
+
+// pointerHelper returns the name of the helper function returning a pointer
+// to a value of the given type
+func (p *printer) pointerHelper(t reflect.Type) string {
+	name := t.Name()
+	if len(name) > 0 {
+		runes := []rune(name)
+		runes[0] = unicode.ToLower(runes[0])
+		name = string(runes)
+	}
+	name = name + "Ptr"
+
+	p.helpers[name] = fmt.Sprintf("func %s(v %s) *%s {\n\treturn &v\n}\n", name, typeName(t), typeName(t))
+	return name
+}
+
+func enumName(v reflect.Value) (string, bool) {
+	names, ok := enumNames[v.Type()]
+	if !ok {
+		return "", false
+	}
+	name, ok := names[v.Interface()]
+	return name, ok
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + typeName(t.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && t.Elem().PkgPath() == "" {
+			return "[]byte"
+		}
+		return "[]" + typeName(t.Elem())
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeName(t.Key()), typeName(t.Elem()))
+	}
+
+	if t.PkgPath() == apiPackage {
+		return "api." + t.Name()
+	}
+	return t.String()
+}
+
+func isZero(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
@@ -0,0 +1,395 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AppliedHashAnnotation is the annotation used to record a hash of the
+// object that was last applied to the cluster
+const AppliedHashAnnotation = "horizon.blackducksoftware.com/applied-hash"
+
+const (
+	applyAttempts = 5
+	applyBackoff  = 200 * time.Millisecond
+)
+
+// Plan defines the change deploying a component would make to the cluster
+type Plan struct {
+	Action api.ApplyActionType
+	// Live is the object currently in the cluster, or nil if it doesn't exist
+	Live runtime.Object
+	// Desired is the object that would be sent to the cluster
+	Desired runtime.Object
+}
+
+// Change defines a change deploying a component made to the cluster
+type Change struct {
+	Action api.ApplyActionType
+	// Previous is the object that was in the cluster before an update
+	Previous runtime.Object
+}
+
+// applyable is implemented by every component in this package that
+// can be compared against the cluster
+type applyable interface {
+	applier(api.DeployerResources) *applier
+}
+
+// CanApply returns true if the component can be compared against the cluster
+func CanApply(c api.DeployableComponentInterface) bool {
+	_, ok := c.(applyable)
+	return ok
+}
+
+// PlanDeploy compares the component against the cluster and returns the change
+// deploying it would make, without modifying the cluster
+func PlanDeploy(c api.DeployableComponentInterface, res api.DeployerResources) (*Plan, error) {
+	a, ok := c.(applyable)
+	if !ok {
+		return nil, fmt.Errorf("%T can't be compared against the cluster", c)
+	}
+	return a.applier(res).plan()
+}
+
+// Apply creates or updates the component in the cluster like Deploy, and
+// returns the change that was made
+func Apply(ctx context.Context, c api.DeployableComponentInterface, res api.DeployerResources) (*Change, error) {
+	a, ok := c.(applyable)
+	if !ok {
+		return nil, fmt.Errorf("%T can't be compared against the cluster", c)
+	}
+	return a.applier(res).applyChange(ctx)
+}
+
+// Restore replaces the component in the cluster with a previous version of it,
+// creating it again if it was removed
+func Restore(ctx context.Context, c api.DeployableComponentInterface, res api.DeployerResources, previous runtime.Object) error {
+	a, ok := c.(applyable)
+	if !ok {
+		return fmt.Errorf("%T can't be compared against the cluster", c)
+	}
+	return a.applier(res).restore(ctx, previous)
+}
+
+// applier defines the cluster operations needed to create or update a component
+type applier struct {
+	obj    runtime.Object
+	get    func() (runtime.Object, error)
+	create func(runtime.Object) error
+	update func(runtime.Object) error
+
+	// merge copies the fields populated by the cluster from the live
+	// object to the object that will be used for the update
+	merge func(update runtime.Object, live runtime.Object)
+}
+
+// apply will create the object if it doesn't exist in the cluster, or update
+// the existing object if it differs from the desired object.  Updates use the
+// resource version of the live object and are retried if a conflict occurs,
+// until the context is done
+func (a *applier) apply(ctx context.Context) error {
+	_, err := a.applyChange(ctx)
+	return err
+}
+
+// applyChange applies the object and returns the change that was made
+func (a *applier) applyChange(ctx context.Context) (*Change, error) {
+	desired, err := a.desired()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < applyAttempts; attempt++ {
+		if err := backoff(ctx, attempt); err != nil {
+			return nil, err
+		}
+
+		live, err := a.get()
+		if errors.IsNotFound(err) {
+			err = a.create(desired.DeepCopyObject())
+			if errors.IsAlreadyExists(err) {
+				lastErr = err
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			return &Change{Action: api.ApplyActionCreate}, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		changed, err := needsUpdate(desired, live)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			return &Change{Action: api.ApplyActionUnchanged}, nil
+		}
+
+		update, err := a.prepareUpdate(desired, live)
+		if err != nil {
+			return nil, err
+		}
+		err = a.update(update)
+		if errors.IsConflict(err) {
+			lastErr = err
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return &Change{Action: api.ApplyActionUpdate, Previous: live}, nil
+	}
+
+	return nil, fmt.Errorf("failed to apply after %d attempts: %v", applyAttempts, lastErr)
+}
+
+// restore replaces the live object with the previous object.  If the object
+// no longer exists it is created without the metadata set by the cluster
+func (a *applier) restore(ctx context.Context, previous runtime.Object) error {
+	var lastErr error
+	for attempt := 0; attempt < applyAttempts; attempt++ {
+		if err := backoff(ctx, attempt); err != nil {
+			return err
+		}
+
+		obj := previous.DeepCopyObject()
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+
+		live, err := a.get()
+		if errors.IsNotFound(err) {
+			accessor.SetResourceVersion("")
+			accessor.SetUID("")
+			accessor.SetSelfLink("")
+			err = a.create(obj)
+			if errors.IsAlreadyExists(err) {
+				lastErr = err
+				continue
+			}
+			return err
+		} else if err != nil {
+			return err
+		}
+
+		liveAccessor, err := meta.Accessor(live)
+		if err != nil {
+			return err
+		}
+		accessor.SetResourceVersion(liveAccessor.GetResourceVersion())
+		err = a.update(obj)
+		if errors.IsConflict(err) {
+			lastErr = err
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("failed to restore after %d attempts: %v", applyAttempts, lastErr)
+}
+
+// backoff waits before retrying an attempt, returning early with the
+// context error if the context is done
+func backoff(ctx context.Context, attempt int) error {
+	if attempt == 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Duration(attempt) * applyBackoff):
+		return nil
+	}
+}
+
+// plan returns the change apply would make to the cluster
+func (a *applier) plan() (*Plan, error) {
+	desired, err := a.desired()
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := a.get()
+	if errors.IsNotFound(err) {
+		return &Plan{Action: api.ApplyActionCreate, Desired: desired}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	changed, err := needsUpdate(desired, live)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return &Plan{Action: api.ApplyActionUnchanged, Live: live, Desired: desired}, nil
+	}
+
+	update, err := a.prepareUpdate(desired, live)
+	if err != nil {
+		return nil, err
+	}
+	return &Plan{Action: api.ApplyActionUpdate, Live: live, Desired: update}, nil
+}
+
+// desired returns a copy of the object stamped with the hash of its content
+func (a *applier) desired() (runtime.Object, error) {
+	desired := a.obj.DeepCopyObject()
+	accessor, err := meta.Accessor(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := accessor.GetAnnotations()
+	delete(annotations, AppliedHashAnnotation)
+	accessor.SetAnnotations(annotations)
+
+	hash, err := hashObject(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AppliedHashAnnotation] = hash
+	accessor.SetAnnotations(annotations)
+	return desired, nil
+}
+
+// prepareUpdate returns a copy of the desired object that can be used to
+// replace the live object
+func (a *applier) prepareUpdate(desired runtime.Object, live runtime.Object) (runtime.Object, error) {
+	update := desired.DeepCopyObject()
+	updateAccessor, err := meta.Accessor(update)
+	if err != nil {
+		return nil, err
+	}
+	liveAccessor, err := meta.Accessor(live)
+	if err != nil {
+		return nil, err
+	}
+	updateAccessor.SetResourceVersion(liveAccessor.GetResourceVersion())
+
+	if a.merge != nil {
+		a.merge(update, live)
+	}
+	return update, nil
+}
+
+func hashObject(obj runtime.Object) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("unable to hash object: %v", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// needsUpdate returns true if the live object wasn't the last object applied,
+// or if any of the fields set in the desired object have a different value
+// in the live object
+func needsUpdate(desired runtime.Object, live runtime.Object) (bool, error) {
+	desiredAccessor, err := meta.Accessor(desired)
+	if err != nil {
+		return false, err
+	}
+	liveAccessor, err := meta.Accessor(live)
+	if err != nil {
+		return false, err
+	}
+	if desiredAccessor.GetAnnotations()[AppliedHashAnnotation] != liveAccessor.GetAnnotations()[AppliedHashAnnotation] {
+		return true, nil
+	}
+
+	desiredFields, err := toFields(desired)
+	if err != nil {
+		return false, err
+	}
+	liveFields, err := toFields(live)
+	if err != nil {
+		return false, err
+	}
+	return !isSubset(desiredFields, liveFields), nil
+}
+
+func toFields(obj runtime.Object) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields interface{}
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// isSubset returns true if every value set in desired has the same
+// value in live.  Fields that are only set in live are ignored since
+// they are usually populated by the cluster
+func isSubset(desired interface{}, live interface{}) bool {
+	switch d := desired.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return len(d) == 0 && live == nil
+		}
+		for k, v := range d {
+			if !isSubset(v, l[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return len(d) == 0 && live == nil
+		}
+		if len(d) != len(l) {
+			return false
+		}
+		for i := range d {
+			if !isSubset(d[i], l[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, live)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newMemoryApplier returns an applier that stores the object in the returned pointer
+func newMemoryApplier(obj runtime.Object) (*applier, *runtime.Object) {
+	var stored runtime.Object
+	a := &applier{
+		obj: obj,
+		get: func() (runtime.Object, error) {
+			if stored == nil {
+				return nil, errors.NewNotFound(v1.Resource("configmaps"), "cm")
+			}
+			return stored.DeepCopyObject(), nil
+		},
+		create: func(o runtime.Object) error {
+			stored = o
+			return nil
+		},
+		update: func(o runtime.Object) error {
+			stored = o
+			return nil
+		},
+	}
+	return a, &stored
+}
+
+func TestIsSubset(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Desired  interface{}
+		Live     interface{}
+		Expected bool
+	}{
+		{
+			Name:     "unset desired value",
+			Desired:  map[string]interface{}{"key": nil},
+			Live:     map[string]interface{}{"key": "value"},
+			Expected: true,
+		},
+		{
+			Name:     "extra live fields",
+			Desired:  map[string]interface{}{"key": "value"},
+			Live:     map[string]interface{}{"key": "value", "other": "value"},
+			Expected: true,
+		},
+		{
+			Name:     "different value",
+			Desired:  map[string]interface{}{"key": "value"},
+			Live:     map[string]interface{}{"key": "other"},
+			Expected: false,
+		},
+		{
+			Name:     "missing live field",
+			Desired:  map[string]interface{}{"key": "value"},
+			Live:     map[string]interface{}{},
+			Expected: false,
+		},
+		{
+			Name:     "list with defaulted fields",
+			Desired:  map[string]interface{}{"list": []interface{}{map[string]interface{}{"name": "a"}}},
+			Live:     map[string]interface{}{"list": []interface{}{map[string]interface{}{"name": "a", "default": float64(1)}}},
+			Expected: true,
+		},
+		{
+			Name:     "list with different length",
+			Desired:  map[string]interface{}{"list": []interface{}{"a"}},
+			Live:     map[string]interface{}{"list": []interface{}{"a", "b"}},
+			Expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		if result := isSubset(tc.Desired, tc.Live); result != tc.Expected {
+			t.Errorf("%s: expected %t got %t", tc.Name, tc.Expected, result)
+		}
+	}
+}
+
+func TestNeedsUpdate(t *testing.T) {
+	c := NewConfigMap(api.ConfigMapConfig{Name: "config", Namespace: "ns"})
+	c.AddData(map[string]string{"key": "value"})
+	a := applier{obj: c.ConfigMap}
+
+	desired, err := a.desired()
+	if err != nil {
+		t.Fatalf("failed to create the desired object: %v", err)
+	}
+
+	live := desired.DeepCopyObject().(*v1.ConfigMap)
+	live.ResourceVersion = "10"
+	if changed, _ := needsUpdate(desired, live); changed {
+		t.Errorf("expected no update for an identical object")
+	}
+
+	live.Data["key"] = "changed"
+	if changed, _ := needsUpdate(desired, live); !changed {
+		t.Errorf("expected an update when the live data changed")
+	}
+
+	live = desired.DeepCopyObject().(*v1.ConfigMap)
+	c.RemoveData([]string{"key"})
+	desired, _ = a.desired()
+	if changed, _ := needsUpdate(desired, live); !changed {
+		t.Errorf("expected an update when data was removed from the desired object")
+	}
+}
+
+func TestPrepareUpdate(t *testing.T) {
+	s := NewService(api.ServiceConfig{Name: "svc", Namespace: "ns", Type: api.ServiceTypeNodePort})
+	s.AddPort(api.ServicePortConfig{Port: 80, TargetPort: "8080", Protocol: api.ProtocolTCP})
+	a := applier{obj: s.Service, merge: mergeService}
+
+	live := s.Service.DeepCopy()
+	live.ResourceVersion = "5"
+	live.Spec.ClusterIP = "10.0.0.1"
+	live.Spec.Ports[0].NodePort = 30080
+
+	desired, _ := a.desired()
+	obj, err := a.prepareUpdate(desired, live)
+	if err != nil {
+		t.Fatalf("failed to prepare the update: %v", err)
+	}
+
+	update := obj.(*v1.Service)
+	if update.ResourceVersion != live.ResourceVersion {
+		t.Errorf("expected resource version %s got %s", live.ResourceVersion, update.ResourceVersion)
+	}
+	if update.Spec.ClusterIP != live.Spec.ClusterIP {
+		t.Errorf("expected cluster ip %s got %s", live.Spec.ClusterIP, update.Spec.ClusterIP)
+	}
+	if update.Spec.Ports[0].NodePort != live.Spec.Ports[0].NodePort {
+		t.Errorf("expected node port %d got %d", live.Spec.Ports[0].NodePort, update.Spec.Ports[0].NodePort)
+	}
+	if len(s.Spec.ClusterIP) > 0 || len(s.Annotations[AppliedHashAnnotation]) > 0 {
+		t.Errorf("expected the component to be unchanged")
+	}
+}
+
+func TestApplyChangeAndRestore(t *testing.T) {
+	cm := NewConfigMap(api.ConfigMapConfig{Name: "cm", Namespace: "ns"})
+	cm.AddData(map[string]string{"key": "value"})
+	a, stored := newMemoryApplier(cm.ConfigMap)
+
+	change, err := a.applyChange(context.Background())
+	if err != nil || change.Action != api.ApplyActionCreate {
+		t.Fatalf("expected the config map to be created, got %+v %v", change, err)
+	}
+
+	change, err = a.applyChange(context.Background())
+	if err != nil || change.Action != api.ApplyActionUnchanged {
+		t.Fatalf("expected the config map to be unchanged, got %+v %v", change, err)
+	}
+
+	cm.AddData(map[string]string{"key": "other"})
+	change, err = a.applyChange(context.Background())
+	if err != nil || change.Action != api.ApplyActionUpdate {
+		t.Fatalf("expected the config map to be updated, got %+v %v", change, err)
+	}
+	if change.Previous.(*v1.ConfigMap).Data["key"] != "value" {
+		t.Errorf("expected the previous config map to be returned, got %+v", change.Previous)
+	}
+
+	if err := a.restore(context.Background(), change.Previous); err != nil {
+		t.Fatalf("failed to restore the config map: %v", err)
+	}
+	if (*stored).(*v1.ConfigMap).Data["key"] != "value" {
+		t.Errorf("expected the previous config map to be restored, got %+v", *stored)
+	}
+}
@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
@@ -29,6 +30,8 @@ import (
 	"k8s.io/api/rbac/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // ClusterRole defines the cluster role component
@@ -112,13 +115,40 @@ func (cr *ClusterRole) RemoveAggregationRule(rule api.SelectorConfig) {
 	}
 }
 
-// Deploy will deploy the cluster role to the cluster
-func (cr *ClusterRole) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.RbacV1().ClusterRoles().Create(cr.ClusterRole)
-	return err
+// Deploy will create the cluster role in the cluster, or update it if it already exists
+func (cr *ClusterRole) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return cr.applier(res).apply(ctx)
+}
+
+func (cr *ClusterRole) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.RbacV1().ClusterRoles()
+	return &applier{
+		obj: cr.ClusterRole,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(cr.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.ClusterRole))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.ClusterRole))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the cluster role from the cluster
-func (cr *ClusterRole) Undeploy(res api.DeployerResources) error {
+func (cr *ClusterRole) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.RbacV1().ClusterRoles().Delete(cr.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the cluster role against the rules of the API server
+func (cr *ClusterRole) Validate() field.ErrorList {
+	errs := validateObjectMeta(&cr.ObjectMeta, false, rbacName, field.NewPath("metadata"))
+	if cr.AggregationRule != nil && len(cr.AggregationRule.ClusterRoleSelectors) > 0 {
+		return errs
+	}
+	return append(errs, validatePolicyRules(cr.Rules, field.NewPath("rules"))...)
+}
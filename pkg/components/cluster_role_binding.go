@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
@@ -29,6 +30,8 @@ import (
 	"k8s.io/api/rbac/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // ClusterRoleBinding defines the cluster role binding component
@@ -93,13 +96,37 @@ func (crb *ClusterRoleBinding) AddRoleRef(config api.RoleRefConfig) {
 	}
 }
 
-// Deploy will deploy the cluster role binding to the cluster
-func (crb *ClusterRoleBinding) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.RbacV1().ClusterRoleBindings().Create(crb.ClusterRoleBinding)
-	return err
+// Deploy will create the cluster role binding in the cluster, or update it if it already exists
+func (crb *ClusterRoleBinding) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return crb.applier(res).apply(ctx)
+}
+
+func (crb *ClusterRoleBinding) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.RbacV1().ClusterRoleBindings()
+	return &applier{
+		obj: crb.ClusterRoleBinding,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(crb.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.ClusterRoleBinding))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.ClusterRoleBinding))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the cluster role binding from the cluster
-func (crb *ClusterRoleBinding) Undeploy(res api.DeployerResources) error {
+func (crb *ClusterRoleBinding) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.RbacV1().ClusterRoleBindings().Delete(crb.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the cluster role binding against the rules of the API server
+func (crb *ClusterRoleBinding) Validate() field.ErrorList {
+	errs := validateObjectMeta(&crb.ObjectMeta, false, rbacName, field.NewPath("metadata"))
+	return append(errs, validateRoleBinding(crb.RoleRef, crb.Subjects, false)...)
+}
@@ -22,10 +22,15 @@ under the License.
 package components
 
 import (
+	"context"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 	"github.com/imdario/mergo"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // ConfigMap defines the config map component
@@ -78,13 +83,51 @@ func (c *ConfigMap) RemoveBinaryData(remove map[string][]byte) {
 	}
 }
 
-// Deploy will deploy the config map to the cluster
-func (c *ConfigMap) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().ConfigMaps(c.Namespace).Create(c.ConfigMap)
-	return err
+// Deploy will create the config map in the cluster, or update it if it already exists
+func (c *ConfigMap) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return c.applier(res).apply(ctx)
+}
+
+func (c *ConfigMap) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().ConfigMaps(c.Namespace)
+	return &applier{
+		obj: c.ConfigMap,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(c.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.ConfigMap))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.ConfigMap))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the config map from the cluster
-func (c *ConfigMap) Undeploy(res api.DeployerResources) error {
+func (c *ConfigMap) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().ConfigMaps(c.Namespace).Delete(c.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the config map against the rules of the API server
+func (c *ConfigMap) Validate() field.ErrorList {
+	errs := validateObjectMeta(&c.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	seen := sets.NewString()
+	errs = append(errs, validateDataKeys(sets.StringKeySet(c.Data), seen, field.NewPath("data"))...)
+	errs = append(errs, validateDataKeys(sets.StringKeySet(c.BinaryData), seen, field.NewPath("binaryData"))...)
+
+	size := 0
+	for k, v := range c.Data {
+		size += len(k) + len(v)
+	}
+	for k, v := range c.BinaryData {
+		size += len(k) + len(v)
+	}
+	if size > maxDataSize {
+		errs = append(errs, field.TooLong(field.NewPath("data"), "", maxDataSize))
+	}
+	return errs
+}
@@ -32,6 +32,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Container defines containers that can be added to other components
@@ -621,3 +622,9 @@ func (c *Container) appendCapabilityIfMissing(new v1.Capability, list []v1.Capab
 
 	return append(list, new)
 }
+
+// Validate checks the container against the rules of the API server.  The
+// volumes it mounts are checked when it is part of a pod
+func (c *Container) Validate() field.ErrorList {
+	return validateContainer(c.Container, nil)
+}
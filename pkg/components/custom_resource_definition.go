@@ -22,13 +22,19 @@ under the License.
 package components
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // CustomResourceDefinition defines a custom resource
@@ -39,7 +45,7 @@ type CustomResourceDefinition struct {
 
 // NewCustomResourceDefintion returns a CustomerResrouceDefinition object
 func NewCustomResourceDefintion(config api.CRDConfig) *CustomResourceDefinition {
-	version := "apiextensions/v1beta1"
+	version := "apiextensions.k8s.io/v1beta1"
 	if len(config.APIVersion) > 0 {
 		version = config.APIVersion
 	}
@@ -171,13 +177,63 @@ func createConversionStrategy(config api.CRDConfig) *v1beta1.CustomResourceConve
 	return strategy
 }
 
-// Deploy will deploy the custom resource definition to the cluster
-func (crd *CustomResourceDefinition) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Create(crd.CustomResourceDefinition)
-	return err
+// Deploy will create the custom resource definition in the cluster, or update it if it already exists
+func (crd *CustomResourceDefinition) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return crd.applier(res).apply(ctx)
+}
+
+func (crd *CustomResourceDefinition) applier(res api.DeployerResources) *applier {
+	client := res.KubeExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions()
+	return &applier{
+		obj: crd.CustomResourceDefinition,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(crd.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1beta1.CustomResourceDefinition))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1beta1.CustomResourceDefinition))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the custom resource definition from the cluster
-func (crd *CustomResourceDefinition) Undeploy(res api.DeployerResources) error {
+func (crd *CustomResourceDefinition) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Delete(crd.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the custom resource definition against the rules of the API
+// server
+func (c *CustomResourceDefinition) Validate() field.ErrorList {
+	errs := validateObjectMeta(&c.ObjectMeta, false, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	if len(c.Spec.Group) == 0 {
+		errs = append(errs, field.Required(spec.Child("group"), "the group is required"))
+	} else if len(strings.Split(c.Spec.Group, ".")) < 2 {
+		errs = append(errs, field.Invalid(spec.Child("group"), c.Spec.Group, "should be a domain with at least one dot"))
+	}
+	if len(c.Spec.Names.Plural) == 0 {
+		errs = append(errs, field.Required(spec.Child("names", "plural"), "the plural name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1035Label(c.Spec.Names.Plural) {
+			errs = append(errs, field.Invalid(spec.Child("names", "plural"), c.Spec.Names.Plural, msg))
+		}
+	}
+	if len(c.Spec.Names.Kind) == 0 {
+		errs = append(errs, field.Required(spec.Child("names", "kind"), "the kind is required"))
+	}
+	if expected := c.Spec.Names.Plural + "." + c.Spec.Group; c.Name != expected {
+		errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), c.Name, fmt.Sprintf("must be spec.names.plural+\".\"+spec.group, %s", expected)))
+	}
+	if len(c.Spec.Version) == 0 && len(c.Spec.Versions) == 0 {
+		errs = append(errs, field.Required(spec.Child("versions"), "a version is required"))
+	}
+	if c.Spec.Scope != v1beta1.ClusterScoped && c.Spec.Scope != v1beta1.NamespaceScoped {
+		errs = append(errs, field.NotSupported(spec.Child("scope"), c.Spec.Scope, []string{string(v1beta1.ClusterScoped), string(v1beta1.NamespaceScoped)}))
+	}
+	return errs
+}
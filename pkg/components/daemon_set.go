@@ -22,11 +22,15 @@ under the License.
 package components
 
 import (
+	"context"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/api/apps/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // DaemonSet defines the daemon set component
@@ -74,13 +78,50 @@ func NewDaemonSet(config api.DaemonSetConfig) *DaemonSet {
 	return &DaemonSet{&d, MetadataFuncs{&d}, LabelSelectorFuncs{&d}, PodFuncs{&d}}
 }
 
-// Deploy will deploy the daemon set to the cluster
-func (ds *DaemonSet) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.AppsV1().DaemonSets(ds.Namespace).Create(ds.DaemonSet)
-	return err
+// Deploy will create the daemon set in the cluster, or update it if it already exists
+func (ds *DaemonSet) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return ds.applier(res).apply(ctx)
+}
+
+func (ds *DaemonSet) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.AppsV1().DaemonSets(ds.Namespace)
+	return &applier{
+		obj: ds.DaemonSet,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(ds.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.DaemonSet))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.DaemonSet))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the daemon set from the cluster
-func (ds *DaemonSet) Undeploy(res api.DeployerResources) error {
+func (ds *DaemonSet) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.AppsV1().DaemonSets(ds.Namespace).Delete(ds.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the daemon set against the rules of the API server
+func (ds *DaemonSet) Validate() field.ErrorList {
+	errs := validateObjectMeta(&ds.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	errs = append(errs, validateNonNegative(&ds.Spec.MinReadySeconds, spec.Child("minReadySeconds"))...)
+	errs = append(errs, validateNonNegative(ds.Spec.RevisionHistoryLimit, spec.Child("revisionHistoryLimit"))...)
+	if rollingUpdate := ds.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		path := spec.Child("updateStrategy", "rollingUpdate", "maxUnavailable")
+		errs = append(errs, validateIntOrPercent(rollingUpdate.MaxUnavailable, path)...)
+		if isZeroIntOrPercent(rollingUpdate.MaxUnavailable) {
+			errs = append(errs, field.Invalid(path, rollingUpdate.MaxUnavailable.String(), "may not be 0"))
+		}
+	}
+
+	errs = append(errs, validateSelector(ds.Spec.Selector, &ds.Spec.Template, spec.Child("selector"))...)
+	errs = append(errs, validatePodTemplate(&ds.Spec.Template, spec.Child("template"))...)
+	return append(errs, validateRestartPolicy(&ds.Spec.Template.Spec, spec.Child("template", "spec", "restartPolicy"), restartAlways)...)
+}
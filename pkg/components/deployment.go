@@ -22,11 +22,15 @@ under the License.
 package components
 
 import (
+	"context"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/api/apps/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Deployment defines the deployment component
@@ -78,13 +82,59 @@ func NewDeployment(config api.DeploymentConfig) *Deployment {
 	return &Deployment{&d, MetadataFuncs{&d}, LabelSelectorFuncs{&d}, PodFuncs{&d}}
 }
 
-// Deploy will deploy the deployment to the cluster
-func (d *Deployment) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.AppsV1().Deployments(d.Namespace).Create(d.Deployment)
-	return err
+// Deploy will create the deployment in the cluster, or update it if it already exists
+func (d *Deployment) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return d.applier(res).apply(ctx)
+}
+
+func (d *Deployment) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.AppsV1().Deployments(d.Namespace)
+	return &applier{
+		obj: d.Deployment,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(d.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.Deployment))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.Deployment))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the deployment from the cluster
-func (d *Deployment) Undeploy(res api.DeployerResources) error {
+func (d *Deployment) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.AppsV1().Deployments(d.Namespace).Delete(d.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the deployment against the rules of the API server
+func (d *Deployment) Validate() field.ErrorList {
+	errs := validateObjectMeta(&d.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	errs = append(errs, validateNonNegative(d.Spec.Replicas, spec.Child("replicas"))...)
+	errs = append(errs, validateNonNegative(&d.Spec.MinReadySeconds, spec.Child("minReadySeconds"))...)
+	errs = append(errs, validateNonNegative(d.Spec.RevisionHistoryLimit, spec.Child("revisionHistoryLimit"))...)
+	if d.Spec.ProgressDeadlineSeconds != nil && *d.Spec.ProgressDeadlineSeconds <= d.Spec.MinReadySeconds {
+		errs = append(errs, field.Invalid(spec.Child("progressDeadlineSeconds"), *d.Spec.ProgressDeadlineSeconds, "must be greater than minReadySeconds"))
+	}
+
+	if rollingUpdate := d.Spec.Strategy.RollingUpdate; rollingUpdate != nil {
+		rollingPath := spec.Child("strategy", "rollingUpdate")
+		if d.Spec.Strategy.Type == v1.RecreateDeploymentStrategyType {
+			errs = append(errs, field.Forbidden(rollingPath, "may not be specified when the strategy is Recreate"))
+		}
+		errs = append(errs, validateIntOrPercent(rollingUpdate.MaxUnavailable, rollingPath.Child("maxUnavailable"))...)
+		errs = append(errs, validateIntOrPercent(rollingUpdate.MaxSurge, rollingPath.Child("maxSurge"))...)
+		if isZeroIntOrPercent(rollingUpdate.MaxUnavailable) && isZeroIntOrPercent(rollingUpdate.MaxSurge) {
+			errs = append(errs, field.Invalid(rollingPath.Child("maxUnavailable"), rollingUpdate.MaxUnavailable.String(), "may not be 0 when maxSurge is 0"))
+		}
+	}
+
+	errs = append(errs, validateSelector(d.Spec.Selector, &d.Spec.Template, spec.Child("selector"))...)
+	errs = append(errs, validatePodTemplate(&d.Spec.Template, spec.Child("template"))...)
+	return append(errs, validateRestartPolicy(&d.Spec.Template.Spec, spec.Child("template", "spec", "restartPolicy"), restartAlways)...)
+}
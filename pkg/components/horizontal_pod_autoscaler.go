@@ -22,11 +22,15 @@ under the License.
 package components
 
 import (
+	"context"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/api/autoscaling/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // HorizontalPodAutoscaler defines the HorizontalPodAutoscaler component
@@ -63,13 +67,58 @@ func NewHorizontalPodAutoscaler(config api.HPAConfig) *HorizontalPodAutoscaler {
 	return &HorizontalPodAutoscaler{&hpa, MetadataFuncs{&hpa}}
 }
 
-// Deploy will deploy the horizontal pod autoscaler to the cluster
-func (hpa *HorizontalPodAutoscaler) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Create(hpa.HorizontalPodAutoscaler)
-	return err
+// Deploy will create the horizontal pod autoscaler in the cluster, or update it if it already exists
+func (hpa *HorizontalPodAutoscaler) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return hpa.applier(res).apply(ctx)
+}
+
+func (hpa *HorizontalPodAutoscaler) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace)
+	return &applier{
+		obj: hpa.HorizontalPodAutoscaler,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(hpa.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.HorizontalPodAutoscaler))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.HorizontalPodAutoscaler))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the horizontal pod autoscaler from the cluster
-func (hpa *HorizontalPodAutoscaler) Undeploy(res api.DeployerResources) error {
+func (hpa *HorizontalPodAutoscaler) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.AutoscalingV1().HorizontalPodAutoscalers(hpa.Namespace).Delete(hpa.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the horizontal pod autoscaler against the rules of the API server
+func (h *HorizontalPodAutoscaler) Validate() field.ErrorList {
+	errs := validateObjectMeta(&h.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	if len(h.Spec.ScaleTargetRef.Kind) == 0 {
+		errs = append(errs, field.Required(spec.Child("scaleTargetRef", "kind"), "the kind of the target is required"))
+	}
+	if len(h.Spec.ScaleTargetRef.Name) == 0 {
+		errs = append(errs, field.Required(spec.Child("scaleTargetRef", "name"), "the name of the target is required"))
+	}
+
+	minReplicas := int32(1)
+	if h.Spec.MinReplicas != nil {
+		minReplicas = *h.Spec.MinReplicas
+		if minReplicas < 1 {
+			errs = append(errs, field.Invalid(spec.Child("minReplicas"), minReplicas, "must be greater than or equal to 1"))
+		}
+	}
+	if h.Spec.MaxReplicas < minReplicas {
+		errs = append(errs, field.Invalid(spec.Child("maxReplicas"), h.Spec.MaxReplicas, "must be greater than or equal to minReplicas"))
+	}
+	if target := h.Spec.TargetCPUUtilizationPercentage; target != nil && *target < 1 {
+		errs = append(errs, field.Invalid(spec.Child("targetCPUUtilizationPercentage"), *target, "must be greater than 0"))
+	}
+	return errs
+}
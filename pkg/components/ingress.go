@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -31,6 +32,10 @@ import (
 	"k8s.io/api/extensions/v1beta1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Ingress defines the Ingress component
@@ -48,7 +53,7 @@ func NewIngress(config api.IngressConfig) (*Ingress, error) {
 
 	i := v1beta1.Ingress{
 		TypeMeta: metav1.TypeMeta{
-			Kind:       "HorizontalPodAutoscaler",
+			Kind:       "Ingress",
 			APIVersion: version,
 		},
 		ObjectMeta: generateObjectMeta(config.Name, config.Namespace, config.ClusterName),
@@ -130,13 +135,97 @@ func (i *Ingress) createHostRule(config api.IngressHostRuleConfig) v1beta1.Ingre
 	return rule
 }
 
-// Deploy will deploy the ingress to the cluster
-func (i *Ingress) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.ExtensionsV1beta1().Ingresses(i.Namespace).Create(i.Ingress)
-	return err
+// Deploy will create the ingress in the cluster, or update it if it already exists
+func (i *Ingress) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return i.applier(res).apply(ctx)
+}
+
+func (i *Ingress) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.ExtensionsV1beta1().Ingresses(i.Namespace)
+	return &applier{
+		obj: i.Ingress,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(i.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1beta1.Ingress))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1beta1.Ingress))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the ingress from the cluster
-func (i *Ingress) Undeploy(res api.DeployerResources) error {
+func (i *Ingress) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.ExtensionsV1beta1().Ingresses(i.Namespace).Delete(i.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the ingress against the rules of the API server
+func (i *Ingress) Validate() field.ErrorList {
+	errs := validateObjectMeta(&i.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	if i.Spec.Backend != nil {
+		errs = append(errs, validateIngressBackend(i.Spec.Backend, spec.Child("backend"))...)
+	} else if len(i.Spec.Rules) == 0 {
+		errs = append(errs, field.Invalid(spec.Child("rules"), i.Spec.Rules, "either a backend or rules must be specified"))
+	}
+
+	for j, rule := range i.Spec.Rules {
+		rulePath := spec.Child("rules").Index(j)
+		if len(rule.Host) > 0 {
+			errs = append(errs, validateIngressHost(rule.Host, rulePath.Child("host"))...)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for k, p := range rule.HTTP.Paths {
+			pathPath := rulePath.Child("http", "paths").Index(k)
+			if len(p.Path) > 0 && !strings.HasPrefix(p.Path, "/") {
+				errs = append(errs, field.Invalid(pathPath.Child("path"), p.Path, "must be an absolute path"))
+			}
+			errs = append(errs, validateIngressBackend(&p.Backend, pathPath.Child("backend"))...)
+		}
+	}
+
+	for j, tls := range i.Spec.TLS {
+		for k, host := range tls.Hosts {
+			errs = append(errs, validateIngressHost(host, spec.Child("tls").Index(j).Child("hosts").Index(k))...)
+		}
+	}
+	return errs
+}
+
+func validateIngressHost(host string, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	validate := validation.IsDNS1123Subdomain
+	if strings.HasPrefix(host, "*.") {
+		validate = validation.IsWildcardDNS1123Subdomain
+	}
+	for _, msg := range validate(host) {
+		errs = append(errs, field.Invalid(path, host, msg))
+	}
+	return errs
+}
+
+func validateIngressBackend(backend *v1beta1.IngressBackend, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(backend.ServiceName) == 0 {
+		errs = append(errs, field.Required(path.Child("serviceName"), "the service is required"))
+	} else {
+		for _, msg := range validation.IsDNS1035Label(backend.ServiceName) {
+			errs = append(errs, field.Invalid(path.Child("serviceName"), backend.ServiceName, msg))
+		}
+	}
+	if backend.ServicePort.Type == intstr.String {
+		for _, msg := range validation.IsValidPortName(backend.ServicePort.StrVal) {
+			errs = append(errs, field.Invalid(path.Child("servicePort"), backend.ServicePort.StrVal, msg))
+		}
+	} else {
+		errs = append(errs, validatePort(backend.ServicePort.IntVal, path.Child("servicePort"))...)
+	}
+	return errs
+}
@@ -22,11 +22,15 @@ under the License.
 package components
 
 import (
+	"context"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/api/batch/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Job defines the job component
@@ -63,13 +67,75 @@ func NewJob(config api.JobConfig) *Job {
 	return &Job{&job, MetadataFuncs{&job}, LabelSelectorFuncs{&job}, PodFuncs{&job}}
 }
 
-// Deploy will deploy the job to the cluster
-func (j *Job) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.BatchV1().Jobs(j.Namespace).Create(j.Job)
-	return err
+// Deploy will create the job in the cluster, or update it if it already exists
+func (j *Job) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return j.applier(res).apply(ctx)
+}
+
+func (j *Job) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.BatchV1().Jobs(j.Namespace)
+	return &applier{
+		obj:   j.Job,
+		merge: mergeJob,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(j.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.Job))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.Job))
+			return err
+		},
+	}
+}
+
+// mergeJob keeps the selector and pod template labels generated by the cluster
+// since they can't be changed after the job is created
+func mergeJob(update runtime.Object, live runtime.Object) {
+	u := update.(*v1.Job)
+	l := live.(*v1.Job)
+
+	if u.Spec.Selector == nil {
+		u.Spec.Selector = l.Spec.Selector
+	}
+
+	if u.Spec.ManualSelector == nil {
+		u.Spec.ManualSelector = l.Spec.ManualSelector
+	}
+
+	for k, v := range l.Spec.Template.Labels {
+		if _, exists := u.Spec.Template.Labels[k]; !exists {
+			if u.Spec.Template.Labels == nil {
+				u.Spec.Template.Labels = make(map[string]string)
+			}
+			u.Spec.Template.Labels[k] = v
+		}
+	}
 }
 
 // Undeploy will remove the job from the cluster
-func (j *Job) Undeploy(res api.DeployerResources) error {
+func (j *Job) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.BatchV1().Jobs(j.Namespace).Delete(j.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the job against the rules of the API server.  The selector is
+// only validated when it is set, the API server generating it otherwise
+func (j *Job) Validate() field.ErrorList {
+	errs := validateObjectMeta(&j.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	errs = append(errs, validateNonNegative(j.Spec.Parallelism, spec.Child("parallelism"))...)
+	errs = append(errs, validateNonNegative(j.Spec.Completions, spec.Child("completions"))...)
+	errs = append(errs, validateNonNegative(j.Spec.BackoffLimit, spec.Child("backoffLimit"))...)
+	if j.Spec.ActiveDeadlineSeconds != nil && *j.Spec.ActiveDeadlineSeconds <= 0 {
+		errs = append(errs, field.Invalid(spec.Child("activeDeadlineSeconds"), *j.Spec.ActiveDeadlineSeconds, "must be greater than 0"))
+	}
+
+	if j.Spec.Selector != nil {
+		errs = append(errs, validateSelector(j.Spec.Selector, &j.Spec.Template, spec.Child("selector"))...)
+	}
+	errs = append(errs, validatePodTemplate(&j.Spec.Template, spec.Child("template"))...)
+	return append(errs, validateRestartPolicy(&j.Spec.Template.Spec, spec.Child("template", "spec", "restartPolicy"), restartOnFailure, restartNever)...)
+}
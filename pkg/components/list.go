@@ -0,0 +1,220 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListComponents returns the objects of the given type in the cluster that match
+// the label selector, across all namespaces
+func ListComponents(kind api.ComponentType, res api.DeployerResources, selector string) ([]api.DeployableComponentInterface, error) {
+	opts := metav1.ListOptions{LabelSelector: selector}
+	objs := []runtime.Object{}
+	switch kind {
+	case api.ClusterRoleComponent:
+		list, err := res.KubeClient.RbacV1().ClusterRoles().List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.ClusterRoleBindingComponent:
+		list, err := res.KubeClient.RbacV1().ClusterRoleBindings().List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.ConfigMapComponent:
+		list, err := res.KubeClient.CoreV1().ConfigMaps(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.CRDComponent:
+		list, err := res.KubeExtensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.DaemonSetComponent:
+		list, err := res.KubeClient.AppsV1().DaemonSets(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.DeploymentComponent:
+		list, err := res.KubeClient.AppsV1().Deployments(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.HorizontalPodAutoscalerComponent:
+		list, err := res.KubeClient.AutoscalingV1().HorizontalPodAutoscalers(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.IngressComponent:
+		list, err := res.KubeClient.ExtensionsV1beta1().Ingresses(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.JobComponent:
+		list, err := res.KubeClient.BatchV1().Jobs(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.NamespaceComponent:
+		list, err := res.KubeClient.CoreV1().Namespaces().List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.NetworkPolicyComponent:
+		list, err := res.KubeClient.NetworkingV1().NetworkPolicies(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.PersistentVolumeClaimComponent:
+		list, err := res.KubeClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.PodComponent:
+		list, err := res.KubeClient.CoreV1().Pods(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.PodDisruptionBudgetComponent:
+		list, err := res.KubeClient.PolicyV1beta1().PodDisruptionBudgets(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.ReplicationControllerComponent:
+		list, err := res.KubeClient.CoreV1().ReplicationControllers(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.RoleComponent:
+		list, err := res.KubeClient.RbacV1().Roles(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.RoleBindingComponent:
+		list, err := res.KubeClient.RbacV1().RoleBindings(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.SecretComponent:
+		list, err := res.KubeClient.CoreV1().Secrets(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.ServiceComponent:
+		list, err := res.KubeClient.CoreV1().Services(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.ServiceAccountComponent:
+		list, err := res.KubeClient.CoreV1().ServiceAccounts(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case api.StatefulSetComponent:
+		list, err := res.KubeClient.AppsV1().StatefulSets(metav1.NamespaceAll).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	default:
+		return nil, fmt.Errorf("unable to list components of type %s", kind)
+	}
+
+	comps := []api.DeployableComponentInterface{}
+	for _, obj := range objs {
+		_, c, err := NewComponentFromObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		comps = append(comps, c)
+	}
+	return comps, nil
+}
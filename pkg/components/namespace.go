@@ -22,11 +22,15 @@ under the License.
 package components
 
 import (
+	"context"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Namespace defines the namespace component
@@ -53,13 +57,47 @@ func NewNamespace(config api.NamespaceConfig) *Namespace {
 	return &Namespace{&n, MetadataFuncs{&n}}
 }
 
-// Deploy will deploy the namespace to the cluster
-func (n *Namespace) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().Namespaces().Create(n.Namespace)
-	return err
+// Deploy will create the namespace in the cluster, or update it if it already exists
+func (n *Namespace) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return n.applier(res).apply(ctx)
+}
+
+func (n *Namespace) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().Namespaces()
+	return &applier{
+		obj:   n.Namespace,
+		merge: mergeNamespace,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(n.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.Namespace))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.Namespace))
+			return err
+		},
+	}
+}
+
+// mergeNamespace keeps the finalizers added by the cluster
+func mergeNamespace(update runtime.Object, live runtime.Object) {
+	u := update.(*v1.Namespace)
+	l := live.(*v1.Namespace)
+
+	if len(u.Spec.Finalizers) == 0 {
+		u.Spec.Finalizers = l.Spec.Finalizers
+	}
 }
 
 // Undeploy will remove the namespace from the cluster
-func (n *Namespace) Undeploy(res api.DeployerResources) error {
+func (n *Namespace) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().Namespaces().Delete(n.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the namespace against the rules of the API server
+func (n *Namespace) Validate() field.ErrorList {
+	return validateObjectMeta(&n.ObjectMeta, false, dns1123Label, field.NewPath("metadata"))
+}
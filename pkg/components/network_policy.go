@@ -0,0 +1,225 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"context"
+	"net"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// NetworkPolicy defines the network policy component
+type NetworkPolicy struct {
+	*networkingv1.NetworkPolicy
+	MetadataFuncs
+}
+
+// NewNetworkPolicy creates a NetworkPolicy object.  An empty pod selector selects
+// all the pods of the namespace
+func NewNetworkPolicy(config api.NetworkPolicyConfig) *NetworkPolicy {
+	version := "networking.k8s.io/v1"
+	if len(config.APIVersion) > 0 {
+		version = config.APIVersion
+	}
+
+	np := networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: version,
+		},
+		ObjectMeta: generateObjectMeta(config.Name, config.Namespace, config.ClusterName),
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: createLabelSelector(config.PodSelector),
+		},
+	}
+
+	return &NetworkPolicy{&np, MetadataFuncs{&np}}
+}
+
+// AddIngressRule will add a rule allowing traffic to the selected pods
+func (np *NetworkPolicy) AddIngressRule(config api.NetworkPolicyRuleConfig) {
+	np.Spec.Ingress = append(np.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+		Ports: createNetworkPolicyPorts(config.Ports),
+		From:  createNetworkPolicyPeers(config.Peers),
+	})
+}
+
+// AddEgressRule will add a rule allowing traffic from the selected pods
+func (np *NetworkPolicy) AddEgressRule(config api.NetworkPolicyRuleConfig) {
+	np.Spec.Egress = append(np.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+		Ports: createNetworkPolicyPorts(config.Ports),
+		To:    createNetworkPolicyPeers(config.Peers),
+	})
+}
+
+func createNetworkPolicyPorts(configs []api.NetworkPolicyPortConfig) []networkingv1.NetworkPolicyPort {
+	var ports []networkingv1.NetworkPolicyPort
+	for _, config := range configs {
+		port := networkingv1.NetworkPolicyPort{Port: createIntOrStr(config.Port)}
+		if config.Protocol != 0 {
+			protocol := convertProtocol(config.Protocol)
+			port.Protocol = &protocol
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func createNetworkPolicyPeers(configs []api.NetworkPolicyPeerConfig) []networkingv1.NetworkPolicyPeer {
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, config := range configs {
+		peer := networkingv1.NetworkPolicyPeer{}
+		if config.PodSelector != nil {
+			selector := createLabelSelector(*config.PodSelector)
+			peer.PodSelector = &selector
+		}
+		if config.NamespaceSelector != nil {
+			selector := createLabelSelector(*config.NamespaceSelector)
+			peer.NamespaceSelector = &selector
+		}
+		if len(config.CIDR) > 0 {
+			peer.IPBlock = &networkingv1.IPBlock{CIDR: config.CIDR, Except: config.Except}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// Deploy will create the network policy in the cluster, or update it if it already exists
+func (np *NetworkPolicy) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return np.applier(res).apply(ctx)
+}
+
+func (np *NetworkPolicy) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.NetworkingV1().NetworkPolicies(np.Namespace)
+	return &applier{
+		obj: np.NetworkPolicy,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(np.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*networkingv1.NetworkPolicy))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*networkingv1.NetworkPolicy))
+			return err
+		},
+	}
+}
+
+// Undeploy will remove the network policy from the cluster
+func (np *NetworkPolicy) Undeploy(ctx context.Context, res api.DeployerResources) error {
+	return res.KubeClient.NetworkingV1().NetworkPolicies(np.Namespace).Delete(np.Name, &metav1.DeleteOptions{})
+}
+
+// Validate checks the network policy against the rules of the API server
+func (np *NetworkPolicy) Validate() field.ErrorList {
+	errs := validateObjectMeta(&np.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	errs = append(errs, validateNetworkPolicySelector(&np.Spec.PodSelector, spec.Child("podSelector"))...)
+	for i, rule := range np.Spec.Ingress {
+		rulePath := spec.Child("ingress").Index(i)
+		errs = append(errs, validateNetworkPolicyPorts(rule.Ports, rulePath.Child("ports"))...)
+		errs = append(errs, validateNetworkPolicyPeers(rule.From, rulePath.Child("from"))...)
+	}
+	for i, rule := range np.Spec.Egress {
+		rulePath := spec.Child("egress").Index(i)
+		errs = append(errs, validateNetworkPolicyPorts(rule.Ports, rulePath.Child("ports"))...)
+		errs = append(errs, validateNetworkPolicyPeers(rule.To, rulePath.Child("to"))...)
+	}
+	return errs
+}
+
+func validateNetworkPolicySelector(selector *metav1.LabelSelector, path *field.Path) field.ErrorList {
+	errs := validateLabels(selector.MatchLabels, path.Child("matchLabels"))
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		errs = append(errs, field.Invalid(path, selector, err.Error()))
+	}
+	return errs
+}
+
+func validateNetworkPolicyPorts(ports []networkingv1.NetworkPolicyPort, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, port := range ports {
+		portPath := path.Index(i)
+		if port.Protocol != nil && *port.Protocol != v1.ProtocolTCP && *port.Protocol != v1.ProtocolUDP && *port.Protocol != v1.ProtocolSCTP {
+			errs = append(errs, field.NotSupported(portPath.Child("protocol"), *port.Protocol, []string{string(v1.ProtocolTCP), string(v1.ProtocolUDP), string(v1.ProtocolSCTP)}))
+		}
+		if port.Port == nil {
+			continue
+		}
+		if port.Port.Type == intstr.Int {
+			errs = append(errs, validatePort(port.Port.IntVal, portPath.Child("port"))...)
+		} else {
+			for _, msg := range validation.IsValidPortName(port.Port.StrVal) {
+				errs = append(errs, field.Invalid(portPath.Child("port"), port.Port.StrVal, msg))
+			}
+		}
+	}
+	return errs
+}
+
+func validateNetworkPolicyPeers(peers []networkingv1.NetworkPolicyPeer, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, peer := range peers {
+		peerPath := path.Index(i)
+		if peer.IPBlock != nil {
+			if peer.PodSelector != nil || peer.NamespaceSelector != nil {
+				errs = append(errs, field.Forbidden(peerPath, "may not specify both ipBlock and a selector"))
+			}
+			_, cidr, err := net.ParseCIDR(peer.IPBlock.CIDR)
+			if err != nil {
+				errs = append(errs, field.Invalid(peerPath.Child("ipBlock", "cidr"), peer.IPBlock.CIDR, "must be a valid CIDR"))
+			}
+			for j, except := range peer.IPBlock.Except {
+				exceptPath := peerPath.Child("ipBlock", "except").Index(j)
+				ip, _, err := net.ParseCIDR(except)
+				if err != nil {
+					errs = append(errs, field.Invalid(exceptPath, except, "must be a valid CIDR"))
+				} else if cidr != nil && !cidr.Contains(ip) {
+					errs = append(errs, field.Invalid(exceptPath, except, "must be a strict subset of the cidr"))
+				}
+			}
+		} else if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+			errs = append(errs, field.Required(peerPath, "must specify a peer"))
+		}
+		if peer.PodSelector != nil {
+			errs = append(errs, validateNetworkPolicySelector(peer.PodSelector, peerPath.Child("podSelector"))...)
+		}
+		if peer.NamespaceSelector != nil {
+			errs = append(errs, validateNetworkPolicySelector(peer.NamespaceSelector, peerPath.Child("namespaceSelector"))...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,388 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// NewComponentFromObject wraps a kubernetes object in the component for its type
+func NewComponentFromObject(obj runtime.Object) (api.ComponentType, api.DeployableComponentInterface, error) {
+	switch o := obj.(type) {
+	case *rbacv1.ClusterRole:
+		return api.ClusterRoleComponent, &ClusterRole{o, MetadataFuncs{o}}, nil
+	case *rbacv1.ClusterRoleBinding:
+		return api.ClusterRoleBindingComponent, &ClusterRoleBinding{o, MetadataFuncs{o}}, nil
+	case *v1.ConfigMap:
+		return api.ConfigMapComponent, &ConfigMap{o, MetadataFuncs{o}}, nil
+	case *apiextensionsv1beta1.CustomResourceDefinition:
+		return api.CRDComponent, &CustomResourceDefinition{o, MetadataFuncs{o}}, nil
+	case *appsv1.DaemonSet:
+		return api.DaemonSetComponent, &DaemonSet{o, MetadataFuncs{o}, LabelSelectorFuncs{o}, PodFuncs{o}}, nil
+	case *appsv1.Deployment:
+		return api.DeploymentComponent, &Deployment{o, MetadataFuncs{o}, LabelSelectorFuncs{o}, PodFuncs{o}}, nil
+	case *autoscalingv1.HorizontalPodAutoscaler:
+		return api.HorizontalPodAutoscalerComponent, &HorizontalPodAutoscaler{o, MetadataFuncs{o}}, nil
+	case *extensionsv1beta1.Ingress:
+		return api.IngressComponent, &Ingress{o, MetadataFuncs{o}}, nil
+	case *batchv1.Job:
+		return api.JobComponent, &Job{o, MetadataFuncs{o}, LabelSelectorFuncs{o}, PodFuncs{o}}, nil
+	case *networkingv1.NetworkPolicy:
+		return api.NetworkPolicyComponent, &NetworkPolicy{o, MetadataFuncs{o}}, nil
+	case *v1.Namespace:
+		return api.NamespaceComponent, &Namespace{o, MetadataFuncs{o}}, nil
+	case *v1.PersistentVolumeClaim:
+		return api.PersistentVolumeClaimComponent, &PersistentVolumeClaim{o, MetadataFuncs{o}, LabelSelectorFuncs{o}}, nil
+	case *v1.Pod:
+		return api.PodComponent, &Pod{o, MetadataFuncs{o}}, nil
+	case *policyv1beta1.PodDisruptionBudget:
+		return api.PodDisruptionBudgetComponent, &PodDisruptionBudget{o, MetadataFuncs{o}, LabelSelectorFuncs{o}}, nil
+	case *v1.ReplicationController:
+		return api.ReplicationControllerComponent, &ReplicationController{o, MetadataFuncs{o}, PodFuncs{o}}, nil
+	case *rbacv1.Role:
+		return api.RoleComponent, &Role{o, MetadataFuncs{o}}, nil
+	case *rbacv1.RoleBinding:
+		return api.RoleBindingComponent, &RoleBinding{o, MetadataFuncs{o}}, nil
+	case *v1.Secret:
+		return api.SecretComponent, &Secret{o, MetadataFuncs{o}}, nil
+	case *v1.Service:
+		return api.ServiceComponent, &Service{o, MetadataFuncs{o}}, nil
+	case *v1.ServiceAccount:
+		return api.ServiceAccountComponent, &ServiceAccount{o, MetadataFuncs{o}}, nil
+	case *appsv1.StatefulSet:
+		return api.StatefulSetComponent, &StatefulSet{o, MetadataFuncs{o}, LabelSelectorFuncs{o}, PodFuncs{o}}, nil
+	}
+	return "", nil, fmt.Errorf("%T is not a supported component type", obj)
+}
+
+// decoderScheme knows the kubernetes types supported by the components and the
+// older versions of them that can be converted
+var decoderScheme = newDecoderScheme()
+
+// decoder decodes the kubernetes types supported by the components
+var decoder = serializer.NewCodecFactory(decoderScheme).UniversalDeserializer()
+
+func newDecoderScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(scheme.AddToScheme(s))
+	utilruntime.Must(apiextensionsv1beta1.AddToScheme(s))
+	return s
+}
+
+// legacyGroupVersions maps the older versions of the supported kinds to the
+// version used by the components
+var legacyGroupVersions = map[schema.GroupVersionKind]schema.GroupVersion{
+	appsv1beta1.SchemeGroupVersion.WithKind("Deployment"):          appsv1.SchemeGroupVersion,
+	appsv1beta1.SchemeGroupVersion.WithKind("StatefulSet"):         appsv1.SchemeGroupVersion,
+	appsv1beta2.SchemeGroupVersion.WithKind("DaemonSet"):           appsv1.SchemeGroupVersion,
+	appsv1beta2.SchemeGroupVersion.WithKind("Deployment"):          appsv1.SchemeGroupVersion,
+	appsv1beta2.SchemeGroupVersion.WithKind("StatefulSet"):         appsv1.SchemeGroupVersion,
+	extensionsv1beta1.SchemeGroupVersion.WithKind("DaemonSet"):     appsv1.SchemeGroupVersion,
+	extensionsv1beta1.SchemeGroupVersion.WithKind("Deployment"):    appsv1.SchemeGroupVersion,
+	extensionsv1beta1.SchemeGroupVersion.WithKind("NetworkPolicy"): networkingv1.SchemeGroupVersion,
+	networkingv1beta1.SchemeGroupVersion.WithKind("Ingress"):       extensionsv1beta1.SchemeGroupVersion,
+	rbacv1beta1.SchemeGroupVersion.WithKind("ClusterRole"):         rbacv1.SchemeGroupVersion,
+	rbacv1beta1.SchemeGroupVersion.WithKind("ClusterRoleBinding"):  rbacv1.SchemeGroupVersion,
+	rbacv1beta1.SchemeGroupVersion.WithKind("Role"):                rbacv1.SchemeGroupVersion,
+	rbacv1beta1.SchemeGroupVersion.WithKind("RoleBinding"):         rbacv1.SchemeGroupVersion,
+}
+
+// legacyDefaultsSelector lists the older versions that default the selector of
+// workloads to the labels of their pods
+var legacyDefaultsSelector = map[schema.GroupVersion]bool{
+	appsv1beta1.SchemeGroupVersion:       true,
+	extensionsv1beta1.SchemeGroupVersion: true,
+}
+
+// convertLegacy converts an object of an older version to the version used by
+// the components.  The versions share their fields apart from a few that were
+// dropped, so the object is converted through its JSON encoding, and an error is
+// returned if a field that is set would be lost.  The defaults of the older version
+// that differ from the new one are set explicitly, so that the object behaves the
+// same once the new defaults are applied by the cluster
+func convertLegacy(obj runtime.Object, gvk schema.GroupVersionKind) (runtime.Object, schema.GroupVersionKind, error) {
+	gv, ok := legacyGroupVersions[gvk]
+	if !ok {
+		return obj, gvk, nil
+	}
+
+	target := gv.WithKind(gvk.Kind)
+	out, err := decoderScheme.New(target)
+	if err != nil {
+		return nil, gvk, err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, gvk, err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, gvk, fmt.Errorf("unable to convert %s to %s: %v", gvk.GroupVersion(), gv, err)
+	}
+	converted, err := json.Marshal(out)
+	if err != nil {
+		return nil, gvk, err
+	}
+	if field, err := droppedField(data, converted); err != nil {
+		return nil, gvk, err
+	} else if len(field) > 0 {
+		return nil, gvk, fmt.Errorf("unable to convert %s to %s: field %s is not supported by %s", gvk.GroupVersion(), gv, field, gv)
+	}
+
+	if err := setLegacyDefaults(out, gvk.GroupVersion()); err != nil {
+		return nil, gvk, fmt.Errorf("unable to convert %s to %s: %v", gvk.GroupVersion(), gv, err)
+	}
+	return out, target, nil
+}
+
+// setLegacyDefaults sets the defaults of an older version that differ from the
+// defaults of the version of the object
+func setLegacyDefaults(obj runtime.Object, gv schema.GroupVersion) error {
+	var selector **metav1.LabelSelector
+	var template *v1.PodTemplateSpec
+	switch o := obj.(type) {
+	case *appsv1.DaemonSet:
+		selector, template = &o.Spec.Selector, &o.Spec.Template
+		if gv == extensionsv1beta1.SchemeGroupVersion && len(o.Spec.UpdateStrategy.Type) == 0 {
+			o.Spec.UpdateStrategy.Type = appsv1.OnDeleteDaemonSetStrategyType
+		}
+	case *appsv1.Deployment:
+		selector, template = &o.Spec.Selector, &o.Spec.Template
+		switch gv {
+		case extensionsv1beta1.SchemeGroupVersion:
+			unlimited := int32(math.MaxInt32)
+			if o.Spec.RevisionHistoryLimit == nil {
+				o.Spec.RevisionHistoryLimit = &unlimited
+			}
+			if o.Spec.ProgressDeadlineSeconds == nil {
+				o.Spec.ProgressDeadlineSeconds = &unlimited
+			}
+			if len(o.Spec.Strategy.Type) == 0 || o.Spec.Strategy.Type == appsv1.RollingUpdateDeploymentStrategyType {
+				o.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+				if o.Spec.Strategy.RollingUpdate == nil {
+					o.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+				}
+				one := intstr.FromInt(1)
+				if o.Spec.Strategy.RollingUpdate.MaxSurge == nil {
+					o.Spec.Strategy.RollingUpdate.MaxSurge = &one
+				}
+				if o.Spec.Strategy.RollingUpdate.MaxUnavailable == nil {
+					o.Spec.Strategy.RollingUpdate.MaxUnavailable = &one
+				}
+			}
+		case appsv1beta1.SchemeGroupVersion:
+			if o.Spec.RevisionHistoryLimit == nil {
+				limit := int32(2)
+				o.Spec.RevisionHistoryLimit = &limit
+			}
+		}
+	case *appsv1.StatefulSet:
+		selector, template = &o.Spec.Selector, &o.Spec.Template
+		if gv == appsv1beta1.SchemeGroupVersion && len(o.Spec.UpdateStrategy.Type) == 0 {
+			o.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+		}
+	default:
+		return nil
+	}
+
+	if *selector == nil && legacyDefaultsSelector[gv] {
+		*selector = defaultSelector(template.Labels)
+	}
+	if *selector == nil {
+		return fmt.Errorf("spec.selector is required, and can't default to the labels of the pod template")
+	}
+	return nil
+}
+
+func defaultSelector(podLabels map[string]string) *metav1.LabelSelector {
+	if len(podLabels) == 0 {
+		return nil
+	}
+	matchLabels := map[string]string{}
+	for k, v := range podLabels {
+		matchLabels[k] = v
+	}
+	return &metav1.LabelSelector{MatchLabels: matchLabels}
+}
+
+// droppedField returns the path of the first field set in the JSON encoding of an
+// object that is missing from the JSON encoding of its conversion.  The status is
+// ignored, since it isn't deployed
+func droppedField(original []byte, converted []byte) (string, error) {
+	from, to := map[string]interface{}{}, map[string]interface{}{}
+	if err := json.Unmarshal(original, &from); err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(converted, &to); err != nil {
+		return "", err
+	}
+	delete(from, "status")
+	delete(from, "apiVersion")
+	return missingField("", from, to), nil
+}
+
+func missingField(path string, from interface{}, to interface{}) string {
+	switch f := from.(type) {
+	case map[string]interface{}:
+		t, _ := to.(map[string]interface{})
+		keys := []string{}
+		for k := range f {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := k
+			if len(path) > 0 {
+				child = path + "." + k
+			}
+			value, ok := t[k]
+			if !ok {
+				return child
+			}
+			if missing := missingField(child, f[k], value); len(missing) > 0 {
+				return missing
+			}
+		}
+	case []interface{}:
+		t, _ := to.([]interface{})
+		for i := range f {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			if i >= len(t) {
+				return child
+			}
+			if missing := missingField(child, f[i], t[i]); len(missing) > 0 {
+				return missing
+			}
+		}
+	}
+	return ""
+}
+
+// DecodedComponent defines a component read from a manifest
+type DecodedComponent struct {
+	Kind      api.ComponentType
+	Component api.DeployableComponentInterface
+}
+
+// DecodeComponent decodes a YAML or JSON kubernetes object into a component.  Objects
+// of older versions, such as extensions/v1beta1 deployments, are converted to the
+// version used by the component
+func DecodeComponent(data []byte) (api.ComponentType, api.DeployableComponentInterface, error) {
+	obj, decodedGVK, err := decoder.Decode(data, nil, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	obj, gvk, err := convertLegacy(obj, *decodedGVK)
+	if err != nil {
+		return "", nil, err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	kind, c, err := NewComponentFromObject(obj)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s is not a supported component type", gvk)
+	}
+	return kind, c, nil
+}
+
+// DecodeComponents decodes a stream of YAML documents or JSON objects into
+// components.  The items of v1 Lists are decoded as separate components
+func DecodeComponents(r io.Reader) ([]DecodedComponent, error) {
+	decoded := []DecodedComponent{}
+	stream := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for doc := 1; ; doc++ {
+		raw := runtime.RawExtension{}
+		if err := stream.Decode(&raw); err == io.EOF {
+			return decoded, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("document %d: %v", doc, err)
+		}
+
+		data := bytes.TrimSpace(raw.Raw)
+		if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+			continue
+		}
+
+		comps, err := decodeObjectOrList(data)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %v", doc, err)
+		}
+		decoded = append(decoded, comps...)
+	}
+}
+
+func decodeObjectOrList(data []byte) ([]DecodedComponent, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(data, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	if typeMeta.Kind != "List" {
+		kind, c, err := DecodeComponent(data)
+		if err != nil {
+			return nil, err
+		}
+		return []DecodedComponent{{Kind: kind, Component: c}}, nil
+	}
+
+	list := v1.List{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	decoded := []DecodedComponent{}
+	for i, item := range list.Items {
+		kind, c, err := DecodeComponent(item.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %v", i, err)
+		}
+		decoded = append(decoded, DecodedComponent{Kind: kind, Component: c})
+	}
+	return decoded, nil
+}
@@ -0,0 +1,291 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestDecodeComponent(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Data     string
+		Expected api.ComponentType
+		Error    bool
+	}{
+		{
+			Name:     "yaml deployment",
+			Data:     "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  namespace: ns\nspec:\n  replicas: 2\n",
+			Expected: api.DeploymentComponent,
+		},
+		{
+			Name:     "json config map",
+			Data:     `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app"}, "data": {"key": "value"}}`,
+			Expected: api.ConfigMapComponent,
+		},
+		{
+			Name:     "pod disruption budget",
+			Data:     "apiVersion: policy/v1beta1\nkind: PodDisruptionBudget\nmetadata:\n  name: app\nspec:\n  minAvailable: 1\n",
+			Expected: api.PodDisruptionBudgetComponent,
+		},
+		{
+			Name:  "unsupported kind",
+			Data:  "apiVersion: v1\nkind: Endpoints\nmetadata:\n  name: app\n",
+			Error: true,
+		},
+		{
+			Name:  "missing kind",
+			Data:  "metadata:\n  name: app\n",
+			Error: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		kind, c, err := DecodeComponent([]byte(tc.Data))
+		if tc.Error {
+			if err == nil {
+				t.Errorf("%s: expected an error", tc.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.Name, err)
+			continue
+		}
+		if kind != tc.Expected || c.GetName() != "app" {
+			t.Errorf("%s: expected %s app, got %s %s", tc.Name, tc.Expected, kind, c.GetName())
+		}
+	}
+}
+
+func TestDecodeLegacyComponent(t *testing.T) {
+	testcases := []struct {
+		Name       string
+		Data       string
+		Expected   api.ComponentType
+		APIVersion string
+	}{
+		{
+			Name:       "extensions deployment",
+			Data:       "apiVersion: extensions/v1beta1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 2\n  template:\n    metadata:\n      labels:\n        app: app\n",
+			Expected:   api.DeploymentComponent,
+			APIVersion: "apps/v1",
+		},
+		{
+			Name:       "apps v1beta2 deployment",
+			Data:       "apiVersion: apps/v1beta2\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 2\n  selector:\n    matchLabels:\n      app: app\n",
+			Expected:   api.DeploymentComponent,
+			APIVersion: "apps/v1",
+		},
+		{
+			Name:       "networking ingress",
+			Data:       "apiVersion: networking.k8s.io/v1beta1\nkind: Ingress\nmetadata:\n  name: app\nspec:\n  backend:\n    serviceName: app\n    servicePort: 80\n",
+			Expected:   api.IngressComponent,
+			APIVersion: "extensions/v1beta1",
+		},
+		{
+			Name:       "rbac v1beta1 role",
+			Data:       "apiVersion: rbac.authorization.k8s.io/v1beta1\nkind: Role\nmetadata:\n  name: app\nrules:\n- apiGroups: [\"\"]\n  resources: [pods]\n  verbs: [get]\n",
+			Expected:   api.RoleComponent,
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		{
+			Name:       "extensions network policy",
+			Data:       "apiVersion: extensions/v1beta1\nkind: NetworkPolicy\nmetadata:\n  name: app\nspec:\n  podSelector:\n    matchLabels:\n      app: app\n",
+			Expected:   api.NetworkPolicyComponent,
+			APIVersion: "networking.k8s.io/v1",
+		},
+	}
+
+	for _, tc := range testcases {
+		kind, c, err := DecodeComponent([]byte(tc.Data))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.Name, err)
+			continue
+		}
+		if kind != tc.Expected || c.GetName() != "app" {
+			t.Errorf("%s: expected %s app, got %s %s", tc.Name, tc.Expected, kind, c.GetName())
+		}
+		if apiVersion := c.GetObjectKind().GroupVersionKind().GroupVersion().String(); apiVersion != tc.APIVersion {
+			t.Errorf("%s: expected %s, got %s", tc.Name, tc.APIVersion, apiVersion)
+		}
+	}
+
+	_, c, _ := DecodeComponent([]byte(testcases[0].Data))
+	d := c.(*Deployment)
+	if *d.Spec.Replicas != 2 || d.Spec.Selector == nil || d.Spec.Selector.MatchLabels["app"] != "app" {
+		t.Errorf("expected the replicas to be kept and the selector to default to the pod labels, got %+v", d.Spec)
+	}
+	_, c, _ = DecodeComponent([]byte(testcases[2].Data))
+	if i := c.(*Ingress); i.Spec.Backend == nil || i.Spec.Backend.ServiceName != "app" {
+		t.Errorf("expected the backend to be kept, got %+v", i.Spec)
+	}
+	_, c, _ = DecodeComponent([]byte(testcases[3].Data))
+	if r := c.(*Role); len(r.Rules) != 1 || r.Rules[0].Resources[0] != "pods" {
+		t.Errorf("expected the rules to be kept, got %+v", r.Rules)
+	}
+	_, c, _ = DecodeComponent([]byte(testcases[4].Data))
+	if np := c.(*NetworkPolicy); np.Spec.PodSelector.MatchLabels["app"] != "app" {
+		t.Errorf("expected the pod selector to be kept, got %+v", np.Spec)
+	}
+}
+
+func TestDecodeLegacyComponentDefaults(t *testing.T) {
+	_, c, err := DecodeComponent([]byte("apiVersion: extensions/v1beta1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  template:\n    metadata:\n      labels:\n        app: app\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spec := c.(*Deployment).Spec
+	if *spec.RevisionHistoryLimit != math.MaxInt32 || *spec.ProgressDeadlineSeconds != math.MaxInt32 {
+		t.Errorf("expected the extensions defaults to be kept, got %+v", spec)
+	}
+	if rolling := spec.Strategy.RollingUpdate; rolling == nil || rolling.MaxSurge.IntValue() != 1 || rolling.MaxUnavailable.IntValue() != 1 {
+		t.Errorf("expected the extensions rolling update defaults to be kept, got %+v", spec.Strategy)
+	}
+
+	_, c, err = DecodeComponent([]byte("apiVersion: extensions/v1beta1\nkind: DaemonSet\nmetadata:\n  name: app\nspec:\n  template:\n    metadata:\n      labels:\n        app: app\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strategy := c.(*DaemonSet).Spec.UpdateStrategy.Type; strategy != appsv1.OnDeleteDaemonSetStrategyType {
+		t.Errorf("expected the daemon set to keep the OnDelete strategy, got %s", strategy)
+	}
+
+	_, c, err = DecodeComponent([]byte("apiVersion: apps/v1beta1\nkind: StatefulSet\nmetadata:\n  name: app\nspec:\n  template:\n    metadata:\n      labels:\n        app: app\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strategy := c.(*StatefulSet).Spec.UpdateStrategy.Type; strategy != appsv1.OnDeleteStatefulSetStrategyType {
+		t.Errorf("expected the stateful set to keep the OnDelete strategy, got %s", strategy)
+	}
+}
+
+func TestDecodeLegacyComponentErrors(t *testing.T) {
+	testcases := []struct {
+		Name  string
+		Data  string
+		Error string
+	}{
+		{
+			Name:  "no selector or pod labels",
+			Data:  "apiVersion: extensions/v1beta1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 2\n",
+			Error: "spec.selector is required",
+		},
+		{
+			Name:  "no selector in a version without defaulting",
+			Data:  "apiVersion: apps/v1beta2\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  template:\n    metadata:\n      labels:\n        app: app\n",
+			Error: "spec.selector is required",
+		},
+		{
+			Name:  "dropped field",
+			Data:  "apiVersion: extensions/v1beta1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  rollbackTo:\n    revision: 2\n  template:\n    metadata:\n      labels:\n        app: app\n",
+			Error: "field spec.rollbackTo is not supported by apps/v1",
+		},
+		{
+			Name:  "dropped daemon set field",
+			Data:  "apiVersion: extensions/v1beta1\nkind: DaemonSet\nmetadata:\n  name: app\nspec:\n  templateGeneration: 3\n  template:\n    metadata:\n      labels:\n        app: app\n",
+			Error: "field spec.templateGeneration is not supported by apps/v1",
+		},
+	}
+
+	for _, tc := range testcases {
+		_, _, err := DecodeComponent([]byte(tc.Data))
+		if err == nil || !strings.Contains(err.Error(), tc.Error) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.Name, tc.Error, err)
+		}
+	}
+}
+
+func TestNewComponentFromObjectDeployment(t *testing.T) {
+	_, c, err := DecodeComponent([]byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 2\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := c.(*Deployment)
+	if *d.Spec.Replicas != 2 {
+		t.Errorf("expected 2 replicas, got %d", *d.Spec.Replicas)
+	}
+	d.AddLabels(map[string]string{"app": "app"})
+	if d.Labels["app"] != "app" {
+		t.Errorf("expected the metadata functions to update the deployment")
+	}
+}
+
+func TestDecodeComponents(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Data     string
+		Expected []api.ComponentType
+		Error    bool
+	}{
+		{
+			Name:     "yaml stream",
+			Data:     "---\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\n---\n---\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n",
+			Expected: []api.ComponentType{api.ServiceComponent, api.DeploymentComponent},
+		},
+		{
+			Name:     "json objects",
+			Data:     `{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "a"}} {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b"}}`,
+			Expected: []api.ComponentType{api.SecretComponent, api.ConfigMapComponent},
+		},
+		{
+			Name:     "json list",
+			Data:     `{"apiVersion": "v1", "kind": "List", "items": [{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "ns"}}, {"apiVersion": "apiextensions.k8s.io/v1beta1", "kind": "CustomResourceDefinition", "metadata": {"name": "crd"}}]}`,
+			Expected: []api.ComponentType{api.NamespaceComponent, api.CRDComponent},
+		},
+		{
+			Name:  "unsupported version",
+			Data:  "apiVersion: rbac.authorization.k8s.io/v1alpha1\nkind: Role\nmetadata:\n  name: web\n",
+			Error: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		decoded, err := DecodeComponents(strings.NewReader(tc.Data))
+		if tc.Error {
+			if err == nil {
+				t.Errorf("%s: expected an error", tc.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.Name, err)
+			continue
+		}
+		if len(decoded) != len(tc.Expected) {
+			t.Errorf("%s: expected %d components, got %d", tc.Name, len(tc.Expected), len(decoded))
+			continue
+		}
+		for i, d := range decoded {
+			if d.Kind != tc.Expected[i] {
+				t.Errorf("%s: expected component %d to be a %s, got %s", tc.Name, i, tc.Expected[i], d.Kind)
+			}
+		}
+	}
+}
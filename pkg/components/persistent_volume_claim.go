@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
@@ -30,6 +31,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // PersistentVolumeClaim defines the persistent volume claim component
@@ -128,13 +131,57 @@ func (p *PersistentVolumeClaim) convertType(mode api.PVCAccessModeType) v1.Persi
 	return m
 }
 
-// Deploy will deploy the persistent volume claim to the cluster
-func (p *PersistentVolumeClaim) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().PersistentVolumeClaims(p.Namespace).Create(p.PersistentVolumeClaim)
-	return err
+// Deploy will create the persistent volume claim in the cluster, or update it if it already exists
+func (p *PersistentVolumeClaim) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return p.applier(res).apply(ctx)
+}
+
+func (p *PersistentVolumeClaim) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().PersistentVolumeClaims(p.Namespace)
+	return &applier{
+		obj:   p.PersistentVolumeClaim,
+		merge: mergePersistentVolumeClaim,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(p.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.PersistentVolumeClaim))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.PersistentVolumeClaim))
+			return err
+		},
+	}
+}
+
+// mergePersistentVolumeClaim keeps the volume binding and defaults assigned by
+// the cluster since they can't be changed after the claim is created
+func mergePersistentVolumeClaim(update runtime.Object, live runtime.Object) {
+	u := update.(*v1.PersistentVolumeClaim)
+	l := live.(*v1.PersistentVolumeClaim)
+
+	if len(u.Spec.VolumeName) == 0 {
+		u.Spec.VolumeName = l.Spec.VolumeName
+	}
+
+	if u.Spec.StorageClassName == nil {
+		u.Spec.StorageClassName = l.Spec.StorageClassName
+	}
+
+	if u.Spec.VolumeMode == nil {
+		u.Spec.VolumeMode = l.Spec.VolumeMode
+	}
 }
 
 // Undeploy will remove the persistent volume claim from the cluster
-func (p *PersistentVolumeClaim) Undeploy(res api.DeployerResources) error {
+func (p *PersistentVolumeClaim) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().PersistentVolumeClaims(p.Namespace).Delete(p.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the persistent volume claim against the rules of the API server
+func (p *PersistentVolumeClaim) Validate() field.ErrorList {
+	errs := validateObjectMeta(&p.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	return append(errs, validateClaimSpec(&p.Spec, field.NewPath("spec"))...)
+}
@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -30,6 +31,8 @@ import (
 	"github.com/imdario/mergo"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Pod defines the pod component
@@ -576,13 +579,86 @@ func (p *Pod) RemoveSysctls(sysctls []string) {
 	}
 }
 
-// Deploy will deploy the pod to the cluster
-func (p *Pod) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().Pods(p.Namespace).Create(p.Pod)
-	return err
+// Deploy will create the pod in the cluster, or update it if it already exists
+func (p *Pod) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return p.applier(res).apply(ctx)
+}
+
+func (p *Pod) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().Pods(p.Namespace)
+	return &applier{
+		obj:   p.Pod,
+		merge: mergePod,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(p.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.Pod))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.Pod))
+			return err
+		},
+	}
+}
+
+// mergePod keeps the node assignment and the volumes and mounts added by the
+// cluster since they can't be changed after the pod is created
+func mergePod(update runtime.Object, live runtime.Object) {
+	u := update.(*v1.Pod)
+	l := live.(*v1.Pod)
+
+	if len(u.Spec.NodeName) == 0 {
+		u.Spec.NodeName = l.Spec.NodeName
+	}
+
+	if len(u.Spec.ServiceAccountName) == 0 {
+		u.Spec.ServiceAccountName = l.Spec.ServiceAccountName
+	}
+
+	for _, lv := range l.Spec.Volumes {
+		found := false
+		for _, uv := range u.Spec.Volumes {
+			if uv.Name == lv.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			u.Spec.Volumes = append(u.Spec.Volumes, lv)
+		}
+	}
+
+	for i := range u.Spec.Containers {
+		for _, lc := range l.Spec.Containers {
+			if u.Spec.Containers[i].Name != lc.Name {
+				continue
+			}
+			for _, lm := range lc.VolumeMounts {
+				found := false
+				for _, um := range u.Spec.Containers[i].VolumeMounts {
+					if um.MountPath == lm.MountPath {
+						found = true
+						break
+					}
+				}
+				if !found {
+					u.Spec.Containers[i].VolumeMounts = append(u.Spec.Containers[i].VolumeMounts, lm)
+				}
+			}
+		}
+	}
 }
 
 // Undeploy will remove the pod from the cluster
-func (p *Pod) Undeploy(res api.DeployerResources) error {
+func (p *Pod) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().Pods(p.Namespace).Delete(p.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the pod against the rules of the API server
+func (p *Pod) Validate() field.ErrorList {
+	errs := validateObjectMeta(&p.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	return append(errs, validatePodSpec(&p.Spec, field.NewPath("spec"))...)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"context"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/policy/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PodDisruptionBudget defines the pod disruption budget component
+type PodDisruptionBudget struct {
+	*v1beta1.PodDisruptionBudget
+	MetadataFuncs
+	LabelSelectorFuncs
+}
+
+// NewPodDisruptionBudget creates a PodDisruptionBudget object
+func NewPodDisruptionBudget(config api.PodDisruptionBudgetConfig) *PodDisruptionBudget {
+	version := "policy/v1beta1"
+	if len(config.APIVersion) > 0 {
+		version = config.APIVersion
+	}
+
+	pdb := v1beta1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: version,
+		},
+		ObjectMeta: generateObjectMeta(config.Name, config.Namespace, config.ClusterName),
+		Spec: v1beta1.PodDisruptionBudgetSpec{
+			MinAvailable:   createIntOrStr(config.MinAvailable),
+			MaxUnavailable: createIntOrStr(config.MaxUnavailable),
+		},
+	}
+
+	return &PodDisruptionBudget{&pdb, MetadataFuncs{&pdb}, LabelSelectorFuncs{&pdb}}
+}
+
+// Deploy will create the pod disruption budget in the cluster, or update it if it already exists
+func (p *PodDisruptionBudget) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return p.applier(res).apply(ctx)
+}
+
+func (p *PodDisruptionBudget) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.PolicyV1beta1().PodDisruptionBudgets(p.Namespace)
+	return &applier{
+		obj: p.PodDisruptionBudget,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(p.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1beta1.PodDisruptionBudget))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1beta1.PodDisruptionBudget))
+			return err
+		},
+	}
+}
+
+// Undeploy will remove the pod disruption budget from the cluster
+func (p *PodDisruptionBudget) Undeploy(ctx context.Context, res api.DeployerResources) error {
+	return res.KubeClient.PolicyV1beta1().PodDisruptionBudgets(p.Namespace).Delete(p.Name, &metav1.DeleteOptions{})
+}
+
+// Validate checks the pod disruption budget against the rules of the API server
+func (p *PodDisruptionBudget) Validate() field.ErrorList {
+	errs := validateObjectMeta(&p.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	if p.Spec.MinAvailable != nil && p.Spec.MaxUnavailable != nil {
+		errs = append(errs, field.Invalid(spec, p.Spec, "minAvailable and maxUnavailable cannot both be set"))
+	}
+	errs = append(errs, validateIntOrPercent(p.Spec.MinAvailable, spec.Child("minAvailable"))...)
+	errs = append(errs, validateIntOrPercent(p.Spec.MaxUnavailable, spec.Child("maxUnavailable"))...)
+	if p.Spec.Selector != nil {
+		errs = append(errs, validateLabels(p.Spec.Selector.MatchLabels, spec.Child("selector", "matchLabels"))...)
+		if _, err := metav1.LabelSelectorAsSelector(p.Spec.Selector); err != nil {
+			errs = append(errs, field.Invalid(spec.Child("selector"), p.Spec.Selector, err.Error()))
+		}
+	}
+	return errs
+}
@@ -26,6 +26,8 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/blackducksoftware/horizon/pkg/api"
+
 	"k8s.io/api/core/v1"
 )
 
@@ -66,3 +68,25 @@ func (pf *PodFuncs) RemovePod(name string) error {
 	}
 	return nil
 }
+
+// GetPodSpec returns the spec of a pod component, or the spec of the pod template
+// of a component containing a pod.  Nil is returned for all other components
+func GetPodSpec(c api.DeployableComponentInterface) *v1.PodSpec {
+	switch obj := c.(type) {
+	case *Pod:
+		return &obj.Spec
+	case *Deployment:
+		return &obj.Spec.Template.Spec
+	case *StatefulSet:
+		return &obj.Spec.Template.Spec
+	case *DaemonSet:
+		return &obj.Spec.Template.Spec
+	case *Job:
+		return &obj.Spec.Template.Spec
+	case *ReplicationController:
+		if obj.Spec.Template != nil {
+			return &obj.Spec.Template.Spec
+		}
+	}
+	return nil
+}
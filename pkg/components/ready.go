@@ -0,0 +1,245 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// IsReady returns true if the component has been deployed to the cluster and
+// is ready to be used.  An error is returned if the component can't be retrieved
+// from the cluster or if it has failed and won't become ready.  Components that
+// weren't created by this package are always considered ready
+func IsReady(c api.DeployableComponentInterface, res api.DeployerResources) (bool, error) {
+	a, ok := c.(applyable)
+	if !ok {
+		return true, nil
+	}
+
+	live, err := a.applier(res).get()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return isLiveObjectReady(live, res)
+}
+
+func isLiveObjectReady(live runtime.Object, res api.DeployerResources) (bool, error) {
+	switch obj := live.(type) {
+	case *apiextensionsv1beta1.CustomResourceDefinition:
+		return isCRDReady(obj)
+	case *corev1.Namespace:
+		return obj.Status.Phase == corev1.NamespaceActive, nil
+	case *corev1.PersistentVolumeClaim:
+		return isPVCReady(obj, res)
+	case *corev1.Pod:
+		return isPodReady(obj)
+	case *corev1.ReplicationController:
+		return isReplicationControllerReady(obj), nil
+	case *corev1.Service:
+		return isServiceReady(obj, res)
+	case *appsv1.Deployment:
+		return isDeploymentReady(obj)
+	case *appsv1.StatefulSet:
+		return isStatefulSetReady(obj), nil
+	case *appsv1.DaemonSet:
+		return isDaemonSetReady(obj), nil
+	case *batchv1.Job:
+		return isJobReady(obj)
+	}
+
+	return true, nil
+}
+
+func isCRDReady(crd *apiextensionsv1beta1.CustomResourceDefinition) (bool, error) {
+	for _, c := range crd.Status.Conditions {
+		switch c.Type {
+		case apiextensionsv1beta1.Established:
+			if c.Status == apiextensionsv1beta1.ConditionTrue {
+				return true, nil
+			}
+		case apiextensionsv1beta1.NamesAccepted:
+			if c.Status == apiextensionsv1beta1.ConditionFalse {
+				return false, fmt.Errorf("custom resource definition %s names were not accepted: %s", crd.Name, c.Message)
+			}
+		}
+	}
+	return false, nil
+}
+
+func isPVCReady(pvc *corev1.PersistentVolumeClaim, res api.DeployerResources) (bool, error) {
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return true, nil
+	case corev1.ClaimLost:
+		return false, fmt.Errorf("persistent volume claim %s lost its volume", pvc.Name)
+	}
+
+	// claims using a storage class that delays binding won't be bound
+	// until a pod using them is scheduled
+	if pvc.Spec.StorageClassName != nil && len(*pvc.Spec.StorageClassName) > 0 {
+		sc, err := res.KubeClient.StorageV1().StorageClasses().Get(*pvc.Spec.StorageClassName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isPodReady(pod *corev1.Pod) (bool, error) {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		return false, fmt.Errorf("pod %s failed: %s", pod.Name, pod.Status.Message)
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
+func isReplicationControllerReady(rc *corev1.ReplicationController) bool {
+	if rc.Generation > rc.Status.ObservedGeneration {
+		return false
+	}
+	return rc.Status.ReadyReplicas >= replicaCount(rc.Spec.Replicas)
+}
+
+// isServiceReady returns true once the service has endpoints.  Services without
+// a selector, including headless services without one, and external name services
+// never get endpoints from their pods, so they are ready as soon as they exist
+func isServiceReady(svc *corev1.Service, res api.DeployerResources) (bool, error) {
+	if svc.Spec.Type == corev1.ServiceTypeExternalName || len(svc.Spec.Selector) == 0 {
+		return true, nil
+	}
+
+	endpoints, err := res.KubeClient.CoreV1().Endpoints(svc.Namespace).Get(svc.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isDeploymentReady(d *appsv1.Deployment) (bool, error) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false, nil
+	}
+
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Errorf("deployment %s exceeded its progress deadline", d.Name)
+		}
+	}
+
+	if d.Status.UpdatedReplicas < replicaCount(d.Spec.Replicas) {
+		return false, nil
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return false, nil
+	}
+	return d.Status.AvailableReplicas >= d.Status.UpdatedReplicas, nil
+}
+
+func isStatefulSetReady(s *appsv1.StatefulSet) bool {
+	if s.Generation > s.Status.ObservedGeneration {
+		return false
+	}
+
+	replicas := replicaCount(s.Spec.Replicas)
+	if s.Status.ReadyReplicas < replicas {
+		return false
+	}
+
+	if s.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType &&
+		s.Spec.UpdateStrategy.RollingUpdate != nil && s.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		return s.Status.UpdatedReplicas >= replicas-*s.Spec.UpdateStrategy.RollingUpdate.Partition
+	}
+
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return true
+	}
+	return s.Status.UpdateRevision == s.Status.CurrentRevision
+}
+
+func isDaemonSetReady(ds *appsv1.DaemonSet) bool {
+	if ds.Generation > ds.Status.ObservedGeneration {
+		return false
+	}
+
+	if ds.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType &&
+		ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
+		return false
+	}
+	return ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled
+}
+
+func isJobReady(job *batchv1.Job) (bool, error) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("job %s failed: %s", job.Name, c.Message)
+		}
+	}
+	return false, nil
+}
+
+func replicaCount(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestIsDeploymentReady(t *testing.T) {
+	replicas := int32(2)
+	testcases := []struct {
+		Name     string
+		Status   appsv1.DeploymentStatus
+		Expected bool
+		Error    bool
+	}{
+		{
+			Name:     "not observed",
+			Status:   appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 2, Replicas: 2, AvailableReplicas: 2},
+			Expected: false,
+		},
+		{
+			Name:     "old replicas still running",
+			Status:   appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, Replicas: 3, AvailableReplicas: 2},
+			Expected: false,
+		},
+		{
+			Name:     "replicas not available",
+			Status:   appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, Replicas: 2, AvailableReplicas: 1},
+			Expected: false,
+		},
+		{
+			Name:     "rollout complete",
+			Status:   appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, Replicas: 2, AvailableReplicas: 2},
+			Expected: true,
+		},
+		{
+			Name: "progress deadline exceeded",
+			Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"},
+			}},
+			Error: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		d := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &replicas}, Status: tc.Status}
+		d.Generation = 2
+		ready, err := isDeploymentReady(d)
+		if tc.Error != (err != nil) {
+			t.Errorf("%s: unexpected error result %v", tc.Name, err)
+		}
+		if ready != tc.Expected {
+			t.Errorf("%s: expected %t got %t", tc.Name, tc.Expected, ready)
+		}
+	}
+}
+
+func TestIsJobReady(t *testing.T) {
+	testcases := []struct {
+		Name       string
+		Conditions []batchv1.JobCondition
+		Expected   bool
+		Error      bool
+	}{
+		{
+			Name:     "running",
+			Expected: false,
+		},
+		{
+			Name:       "complete",
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			Expected:   true,
+		},
+		{
+			Name:       "failed",
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			Error:      true,
+		},
+	}
+
+	for _, tc := range testcases {
+		job := &batchv1.Job{Status: batchv1.JobStatus{Conditions: tc.Conditions}}
+		ready, err := isJobReady(job)
+		if tc.Error != (err != nil) {
+			t.Errorf("%s: unexpected error result %v", tc.Name, err)
+		}
+		if ready != tc.Expected {
+			t.Errorf("%s: expected %t got %t", tc.Name, tc.Expected, ready)
+		}
+	}
+}
+
+func TestIsStatefulSetReady(t *testing.T) {
+	replicas := int32(3)
+	partition := int32(1)
+	s := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: 3, CurrentRevision: "a", UpdateRevision: "b", UpdatedReplicas: 2},
+	}
+
+	if isStatefulSetReady(s) {
+		t.Errorf("expected stateful set with a pending revision to not be ready")
+	}
+
+	s.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition}
+	if !isStatefulSetReady(s) {
+		t.Errorf("expected partitioned stateful set to be ready")
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// Reference defines a reference from a component to another object
+type Reference struct {
+	Kind      api.ComponentType
+	Namespace string
+	Name      string
+
+	// Key is the key within a config map or secret, if a single key is referenced
+	Key string
+	// Optional is true if the referenced object doesn't need to exist
+	Optional bool
+	// Field is the path of the field containing the reference
+	Field string
+	// Weak is true if the component can be deployed before the referenced object,
+	// such as the governing service of a stateful set whose endpoints come from
+	// the stateful set's own pods
+	Weak bool
+}
+
+// String returns a description of the referenced object
+func (r Reference) String() string {
+	if len(r.Namespace) > 0 {
+		return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+	}
+	return fmt.Sprintf("%s %s", r.Kind, r.Name)
+}
+
+// GetReferences returns the objects referenced by the component, such as the config maps,
+// secrets, claims and service account used by a pod or the role used by a role binding
+func GetReferences(c api.DeployableComponentInterface) []Reference {
+	accessor, err := meta.Accessor(c)
+	if err != nil {
+		return nil
+	}
+	ns := accessor.GetNamespace()
+
+	refs := []Reference{}
+	if spec := GetPodSpec(c); spec != nil {
+		field := "spec"
+		if _, ok := c.(*Pod); !ok {
+			field = "spec.template.spec"
+		}
+		refs = append(refs, podSpecReferences(ns, field, spec)...)
+	}
+
+	switch obj := c.(type) {
+	case *StatefulSet:
+		if len(obj.Spec.ServiceName) > 0 {
+			refs = append(refs, Reference{Kind: api.ServiceComponent, Namespace: ns, Name: obj.Spec.ServiceName, Field: "spec.serviceName", Weak: true})
+		}
+	case *ServiceAccount:
+		for i, s := range obj.ImagePullSecrets {
+			refs = append(refs, Reference{Kind: api.SecretComponent, Namespace: ns, Name: s.Name, Field: fmt.Sprintf("imagePullSecrets[%d]", i)})
+		}
+	case *RoleBinding:
+		refs = append(refs, roleRefReference(ns, obj.RoleRef))
+		refs = append(refs, subjectReferences(ns, obj.Subjects)...)
+	case *ClusterRoleBinding:
+		refs = append(refs, roleRefReference("", obj.RoleRef))
+		refs = append(refs, subjectReferences("", obj.Subjects)...)
+	case *HorizontalPodAutoscaler:
+		refs = append(refs, Reference{Kind: kindToComponentType(obj.Spec.ScaleTargetRef.Kind), Namespace: ns, Name: obj.Spec.ScaleTargetRef.Name, Field: "spec.scaleTargetRef"})
+	case *Ingress:
+		if obj.Spec.Backend != nil {
+			refs = append(refs, Reference{Kind: api.ServiceComponent, Namespace: ns, Name: obj.Spec.Backend.ServiceName, Key: obj.Spec.Backend.ServicePort.String(), Field: "spec.backend"})
+		}
+		for i, rule := range obj.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for j, path := range rule.HTTP.Paths {
+				refs = append(refs, Reference{Kind: api.ServiceComponent, Namespace: ns, Name: path.Backend.ServiceName, Key: path.Backend.ServicePort.String(), Field: fmt.Sprintf("spec.rules[%d].http.paths[%d].backend", i, j)})
+			}
+		}
+	}
+
+	return refs
+}
+
+func podSpecReferences(ns string, field string, spec *v1.PodSpec) []Reference {
+	refs := []Reference{}
+
+	if len(spec.ServiceAccountName) > 0 {
+		refs = append(refs, Reference{Kind: api.ServiceAccountComponent, Namespace: ns, Name: spec.ServiceAccountName, Field: field + ".serviceAccountName"})
+	}
+
+	for i, s := range spec.ImagePullSecrets {
+		refs = append(refs, Reference{Kind: api.SecretComponent, Namespace: ns, Name: s.Name, Field: fmt.Sprintf("%s.imagePullSecrets[%d]", field, i)})
+	}
+
+	for i, v := range spec.Volumes {
+		vf := fmt.Sprintf("%s.volumes[%d]", field, i)
+		switch {
+		case v.ConfigMap != nil:
+			refs = append(refs, Reference{Kind: api.ConfigMapComponent, Namespace: ns, Name: v.ConfigMap.Name, Optional: isOptional(v.ConfigMap.Optional), Field: vf + ".configMap"})
+		case v.Secret != nil:
+			refs = append(refs, Reference{Kind: api.SecretComponent, Namespace: ns, Name: v.Secret.SecretName, Optional: isOptional(v.Secret.Optional), Field: vf + ".secret"})
+		case v.PersistentVolumeClaim != nil:
+			refs = append(refs, Reference{Kind: api.PersistentVolumeClaimComponent, Namespace: ns, Name: v.PersistentVolumeClaim.ClaimName, Field: vf + ".persistentVolumeClaim"})
+		case v.Projected != nil:
+			for j, source := range v.Projected.Sources {
+				sf := fmt.Sprintf("%s.projected.sources[%d]", vf, j)
+				if source.ConfigMap != nil {
+					refs = append(refs, Reference{Kind: api.ConfigMapComponent, Namespace: ns, Name: source.ConfigMap.Name, Optional: isOptional(source.ConfigMap.Optional), Field: sf + ".configMap"})
+				}
+				if source.Secret != nil {
+					refs = append(refs, Reference{Kind: api.SecretComponent, Namespace: ns, Name: source.Secret.Name, Optional: isOptional(source.Secret.Optional), Field: sf + ".secret"})
+				}
+			}
+		}
+	}
+
+	for i, c := range spec.InitContainers {
+		refs = append(refs, containerReferences(ns, fmt.Sprintf("%s.initContainers[%d]", field, i), c)...)
+	}
+	for i, c := range spec.Containers {
+		refs = append(refs, containerReferences(ns, fmt.Sprintf("%s.containers[%d]", field, i), c)...)
+	}
+
+	return refs
+}
+
+func containerReferences(ns string, field string, c v1.Container) []Reference {
+	refs := []Reference{}
+
+	for i, env := range c.Env {
+		if env.ValueFrom == nil {
+			continue
+		}
+		ef := fmt.Sprintf("%s.env[%d].valueFrom", field, i)
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+			refs = append(refs, Reference{Kind: api.ConfigMapComponent, Namespace: ns, Name: ref.Name, Key: ref.Key, Optional: isOptional(ref.Optional), Field: ef + ".configMapKeyRef"})
+		}
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+			refs = append(refs, Reference{Kind: api.SecretComponent, Namespace: ns, Name: ref.Name, Key: ref.Key, Optional: isOptional(ref.Optional), Field: ef + ".secretKeyRef"})
+		}
+	}
+
+	for i, env := range c.EnvFrom {
+		ef := fmt.Sprintf("%s.envFrom[%d]", field, i)
+		if ref := env.ConfigMapRef; ref != nil {
+			refs = append(refs, Reference{Kind: api.ConfigMapComponent, Namespace: ns, Name: ref.Name, Optional: isOptional(ref.Optional), Field: ef + ".configMapRef"})
+		}
+		if ref := env.SecretRef; ref != nil {
+			refs = append(refs, Reference{Kind: api.SecretComponent, Namespace: ns, Name: ref.Name, Optional: isOptional(ref.Optional), Field: ef + ".secretRef"})
+		}
+	}
+
+	return refs
+}
+
+func roleRefReference(ns string, ref rbacv1.RoleRef) Reference {
+	if ref.Kind == "ClusterRole" {
+		return Reference{Kind: api.ClusterRoleComponent, Name: ref.Name, Field: "roleRef"}
+	}
+	return Reference{Kind: api.RoleComponent, Namespace: ns, Name: ref.Name, Field: "roleRef"}
+}
+
+func subjectReferences(ns string, subjects []rbacv1.Subject) []Reference {
+	refs := []Reference{}
+	for i, s := range subjects {
+		if s.Kind != rbacv1.ServiceAccountKind {
+			continue
+		}
+		subjectNamespace := s.Namespace
+		if len(subjectNamespace) == 0 {
+			subjectNamespace = ns
+		}
+		refs = append(refs, Reference{Kind: api.ServiceAccountComponent, Namespace: subjectNamespace, Name: s.Name, Field: fmt.Sprintf("subjects[%d]", i)})
+	}
+	return refs
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// kindToComponentType converts a kubernetes kind to the matching component type
+func kindToComponentType(kind string) api.ComponentType {
+	switch kind {
+	case "StatefulSet":
+		return api.StatefulSetComponent
+	case "DaemonSet":
+		return api.DaemonSetComponent
+	}
+	return api.ComponentType(kind)
+}
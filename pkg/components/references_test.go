@@ -0,0 +1,52 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+)
+
+func TestGetReferences(t *testing.T) {
+	pod := NewPod(api.PodConfig{Name: "pod", Namespace: "ns", ServiceAccount: "sa"})
+	pod.AddImagePullSecrets([]string{"pull"})
+	pod.AddVolume(NewSecretVolume(api.ConfigMapOrSecretVolumeConfig{VolumeName: "certs", MapOrSecretName: "certs"}))
+
+	refs := map[string]bool{}
+	for _, ref := range GetReferences(pod) {
+		if ref.Namespace != "ns" {
+			t.Errorf("expected reference %s to be in namespace ns", ref)
+		}
+		refs[string(ref.Kind)+"/"+ref.Name] = true
+	}
+
+	for _, expected := range []string{
+		string(api.ServiceAccountComponent) + "/sa",
+		string(api.SecretComponent) + "/pull",
+		string(api.SecretComponent) + "/certs",
+	} {
+		if !refs[expected] {
+			t.Errorf("expected reference to %s, got %v", expected, refs)
+		}
+	}
+}
@@ -22,11 +22,15 @@ under the License.
 package components
 
 import (
+	"context"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/imdario/mergo"
 )
@@ -72,13 +76,46 @@ func (rc *ReplicationController) RemoveSelectors(remove []string) {
 	}
 }
 
-// Deploy will deploy the replication controller to the cluster
-func (rc *ReplicationController) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().ReplicationControllers(rc.Namespace).Create(rc.ReplicationController)
-	return err
+// Deploy will create the replication controller in the cluster, or update it if it already exists
+func (rc *ReplicationController) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return rc.applier(res).apply(ctx)
+}
+
+func (rc *ReplicationController) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().ReplicationControllers(rc.Namespace)
+	return &applier{
+		obj: rc.ReplicationController,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(rc.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.ReplicationController))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.ReplicationController))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the replication controller from the cluster
-func (rc *ReplicationController) Undeploy(res api.DeployerResources) error {
+func (rc *ReplicationController) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().ReplicationControllers(rc.Namespace).Delete(rc.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the replication controller against the rules of the API server
+func (rc *ReplicationController) Validate() field.ErrorList {
+	errs := validateObjectMeta(&rc.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	errs = append(errs, validateNonNegative(rc.Spec.Replicas, spec.Child("replicas"))...)
+	errs = append(errs, validateNonNegative(&rc.Spec.MinReadySeconds, spec.Child("minReadySeconds"))...)
+
+	if rc.Spec.Template == nil {
+		return append(errs, field.Required(spec.Child("template"), "a pod template is required"))
+	}
+	errs = append(errs, validateSelector(&metav1.LabelSelector{MatchLabels: rc.Spec.Selector}, rc.Spec.Template, spec.Child("selector"))...)
+	errs = append(errs, validatePodTemplate(rc.Spec.Template, spec.Child("template"))...)
+	return append(errs, validateRestartPolicy(&rc.Spec.Template.Spec, spec.Child("template", "spec", "restartPolicy"), restartAlways)...)
+}
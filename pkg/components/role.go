@@ -22,11 +22,14 @@ under the License.
 package components
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
 	"k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Role defines the cluster role component
@@ -88,13 +91,37 @@ func (r *Role) RemovePolicyRule(config api.PolicyRuleConfig) {
 	}
 }
 
-// Deploy will deploy the cluster role to the cluster
-func (r *Role) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.RbacV1().Roles(r.Namespace).Create(r.Role)
-	return err
+// Deploy will create the cluster role in the cluster, or update it if it already exists
+func (r *Role) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return r.applier(res).apply(ctx)
+}
+
+func (r *Role) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.RbacV1().Roles(r.Namespace)
+	return &applier{
+		obj: r.Role,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(r.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.Role))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.Role))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the cluster role from the cluster
-func (r *Role) Undeploy(res api.DeployerResources) error {
+func (r *Role) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.RbacV1().Roles(r.Namespace).Delete(r.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the role against the rules of the API server
+func (r *Role) Validate() field.ErrorList {
+	errs := validateObjectMeta(&r.ObjectMeta, true, rbacName, field.NewPath("metadata"))
+	return append(errs, validatePolicyRules(r.Rules, field.NewPath("rules"))...)
+}
@@ -22,11 +22,14 @@ under the License.
 package components
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
 	"k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // RoleBinding defines the cluster role binding component
@@ -95,13 +98,37 @@ func (rb *RoleBinding) AddRoleRef(config api.RoleRefConfig) {
 	}
 }
 
-// Deploy will deploy the cluster role binding to the cluster
-func (rb *RoleBinding) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.RbacV1().RoleBindings(rb.Namespace).Create(rb.RoleBinding)
-	return err
+// Deploy will create the cluster role binding in the cluster, or update it if it already exists
+func (rb *RoleBinding) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return rb.applier(res).apply(ctx)
+}
+
+func (rb *RoleBinding) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.RbacV1().RoleBindings(rb.Namespace)
+	return &applier{
+		obj: rb.RoleBinding,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(rb.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.RoleBinding))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.RoleBinding))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the cluster role binding from the cluster
-func (rb *RoleBinding) Undeploy(res api.DeployerResources) error {
+func (rb *RoleBinding) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.RbacV1().RoleBindings(rb.Namespace).Delete(rb.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the role binding against the rules of the API server
+func (rb *RoleBinding) Validate() field.ErrorList {
+	errs := validateObjectMeta(&rb.ObjectMeta, true, rbacName, field.NewPath("metadata"))
+	return append(errs, validateRoleBinding(rb.RoleRef, rb.Subjects, true)...)
+}
@@ -22,11 +22,17 @@ under the License.
 package components
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/blackducksoftware/horizon/pkg/api"
 
 	"k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/imdario/mergo"
 )
@@ -104,13 +110,68 @@ func (s *Secret) RemoveData(remove []string) {
 	}
 }
 
-// Deploy will deploy the secret to the cluster
-func (s *Secret) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().Secrets(s.Namespace).Create(s.Secret)
-	return err
+// Deploy will create the secret in the cluster, or update it if it already exists
+func (s *Secret) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return s.applier(res).apply(ctx)
+}
+
+func (s *Secret) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().Secrets(s.Namespace)
+	return &applier{
+		obj: s.Secret,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(s.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.Secret))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.Secret))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the secret from the cluster
-func (s *Secret) Undeploy(res api.DeployerResources) error {
+func (s *Secret) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().Secrets(s.Namespace).Delete(s.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the secret against the rules of the API server, including
+// the keys required by its type
+func (s *Secret) Validate() field.ErrorList {
+	errs := validateObjectMeta(&s.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	seen := sets.NewString()
+	errs = append(errs, validateDataKeys(sets.StringKeySet(s.Data), seen, field.NewPath("data"))...)
+	errs = append(errs, validateDataKeys(sets.StringKeySet(s.StringData), seen, field.NewPath("stringData"))...)
+
+	size := 0
+	for k, v := range s.Data {
+		size += len(k) + len(v)
+	}
+	for k, v := range s.StringData {
+		size += len(k) + len(v)
+	}
+	if size > maxDataSize {
+		errs = append(errs, field.TooLong(field.NewPath("data"), "", maxDataSize))
+	}
+
+	required := map[v1.SecretType][]string{
+		v1.SecretTypeDockercfg:        {v1.DockerConfigKey},
+		v1.SecretTypeDockerConfigJson: {v1.DockerConfigJsonKey},
+		v1.SecretTypeBasicAuth:        {},
+		v1.SecretTypeSSHAuth:          {v1.SSHAuthPrivateKey},
+		v1.SecretTypeTLS:              {v1.TLSCertKey, v1.TLSPrivateKeyKey},
+	}
+	for _, key := range required[s.Type] {
+		if !seen.Has(key) {
+			errs = append(errs, field.Required(field.NewPath("data").Key(key), fmt.Sprintf("required for secrets of type %s", s.Type)))
+		}
+	}
+	if s.Type == v1.SecretTypeServiceAccountToken && len(s.Annotations[v1.ServiceAccountNameKey]) == 0 {
+		errs = append(errs, field.Required(field.NewPath("metadata", "annotations").Key(v1.ServiceAccountNameKey), "the service account is required"))
+	}
+	return errs
+}
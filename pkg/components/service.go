@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -30,7 +31,11 @@ import (
 	"k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/imdario/mergo"
 )
@@ -182,13 +187,122 @@ func (s *Service) RemoveLoadBalancer() {
 	}
 }
 
-// Deploy will deploy the service to the cluster
-func (s *Service) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().Services(s.Namespace).Create(s.Service)
-	return err
+// Deploy will create the service in the cluster, or update it if it already exists
+func (s *Service) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return s.applier(res).apply(ctx)
+}
+
+func (s *Service) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().Services(s.Namespace)
+	return &applier{
+		obj:   s.Service,
+		merge: mergeService,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(s.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.Service))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.Service))
+			return err
+		},
+	}
+}
+
+// mergeService keeps the cluster IP and node ports allocated by the cluster
+// if they weren't explicitly configured
+func mergeService(update runtime.Object, live runtime.Object) {
+	u := update.(*v1.Service)
+	l := live.(*v1.Service)
+
+	if len(u.Spec.ClusterIP) == 0 {
+		u.Spec.ClusterIP = l.Spec.ClusterIP
+	}
+
+	if u.Spec.HealthCheckNodePort == 0 {
+		u.Spec.HealthCheckNodePort = l.Spec.HealthCheckNodePort
+	}
+
+	for i, up := range u.Spec.Ports {
+		if up.NodePort != 0 {
+			continue
+		}
+		for _, lp := range l.Spec.Ports {
+			if up.Port == lp.Port && up.Protocol == lp.Protocol {
+				u.Spec.Ports[i].NodePort = lp.NodePort
+				break
+			}
+		}
+	}
 }
 
 // Undeploy will remove the service from the cluster
-func (s *Service) Undeploy(res api.DeployerResources) error {
+func (s *Service) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().Services(s.Namespace).Delete(s.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the service against the rules of the API server
+func (s *Service) Validate() field.ErrorList {
+	errs := validateObjectMeta(&s.ObjectMeta, true, dns1035Label, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+
+	if s.Spec.Type == v1.ServiceTypeExternalName {
+		if len(s.Spec.ExternalName) == 0 {
+			errs = append(errs, field.Required(spec.Child("externalName"), "required for services of type ExternalName"))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(s.Spec.ExternalName) {
+			errs = append(errs, field.Invalid(spec.Child("externalName"), s.Spec.ExternalName, msg))
+		}
+	} else if len(s.Spec.Ports) == 0 && s.Spec.ClusterIP != v1.ClusterIPNone {
+		// headless services can have no ports, they only publish the addresses of their pods
+		errs = append(errs, field.Required(spec.Child("ports"), "a service needs at least one port"))
+	}
+
+	names := sets.NewString()
+	for i, port := range s.Spec.Ports {
+		portPath := spec.Child("ports").Index(i)
+		if len(s.Spec.Ports) > 1 && len(port.Name) == 0 {
+			errs = append(errs, field.Required(portPath.Child("name"), "required when the service has several ports"))
+		} else if len(port.Name) > 0 {
+			for _, msg := range validation.IsDNS1123Label(port.Name) {
+				errs = append(errs, field.Invalid(portPath.Child("name"), port.Name, msg))
+			}
+			if names.Has(port.Name) {
+				errs = append(errs, field.Duplicate(portPath.Child("name"), port.Name))
+			}
+			names.Insert(port.Name)
+		}
+		errs = append(errs, validatePort(port.Port, portPath.Child("port"))...)
+		switch port.TargetPort.Type {
+		case intstr.Int:
+			if port.TargetPort.IntVal != 0 {
+				errs = append(errs, validatePort(port.TargetPort.IntVal, portPath.Child("targetPort"))...)
+			}
+		case intstr.String:
+			// an empty target port defaults to the port
+			if len(port.TargetPort.StrVal) == 0 {
+				break
+			}
+			for _, msg := range validation.IsValidPortName(port.TargetPort.StrVal) {
+				errs = append(errs, field.Invalid(portPath.Child("targetPort"), port.TargetPort.StrVal, msg))
+			}
+		}
+		if port.NodePort != 0 {
+			errs = append(errs, validatePort(port.NodePort, portPath.Child("nodePort"))...)
+			if s.Spec.Type != v1.ServiceTypeNodePort && s.Spec.Type != v1.ServiceTypeLoadBalancer {
+				errs = append(errs, field.Forbidden(portPath.Child("nodePort"), "only allowed for services of type NodePort or LoadBalancer"))
+			}
+		}
+	}
+
+	errs = append(errs, validateLabels(s.Spec.Selector, spec.Child("selector"))...)
+	for i, ip := range s.Spec.ExternalIPs {
+		for _, msg := range validation.IsValidIP(ip) {
+			errs = append(errs, field.Invalid(spec.Child("externalIPs").Index(i), ip, msg))
+		}
+	}
+	return errs
+}
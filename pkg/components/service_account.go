@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"reflect"
 	"strings"
 
@@ -30,7 +31,9 @@ import (
 	"k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // ServiceAccount defines the service account component
@@ -108,13 +111,58 @@ func (sa *ServiceAccount) RemoveObjectReference(config api.ServiceAccountSecretC
 	}
 }
 
-// Deploy will deploy the service account to the cluster
-func (sa *ServiceAccount) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.CoreV1().ServiceAccounts(sa.Namespace).Create(sa.ServiceAccount)
-	return err
+// Deploy will create the service account in the cluster, or update it if it already exists
+func (sa *ServiceAccount) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return sa.applier(res).apply(ctx)
+}
+
+func (sa *ServiceAccount) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.CoreV1().ServiceAccounts(sa.Namespace)
+	return &applier{
+		obj:   sa.ServiceAccount,
+		merge: mergeServiceAccount,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(sa.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.ServiceAccount))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.ServiceAccount))
+			return err
+		},
+	}
+}
+
+// mergeServiceAccount keeps the token secrets added by the cluster
+func mergeServiceAccount(update runtime.Object, live runtime.Object) {
+	u := update.(*v1.ServiceAccount)
+	l := live.(*v1.ServiceAccount)
+
+	if len(u.Secrets) == 0 {
+		u.Secrets = l.Secrets
+	}
 }
 
 // Undeploy will remove the service account from the cluster
-func (sa *ServiceAccount) Undeploy(res api.DeployerResources) error {
+func (sa *ServiceAccount) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.CoreV1().ServiceAccounts(sa.Namespace).Delete(sa.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the service account against the rules of the API server
+func (sa *ServiceAccount) Validate() field.ErrorList {
+	errs := validateObjectMeta(&sa.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	for i, secret := range sa.Secrets {
+		if len(secret.Name) == 0 {
+			errs = append(errs, field.Required(field.NewPath("secrets").Index(i).Child("name"), "the secret is required"))
+		}
+	}
+	for i, secret := range sa.ImagePullSecrets {
+		if len(secret.Name) == 0 {
+			errs = append(errs, field.Required(field.NewPath("imagePullSecrets").Index(i).Child("name"), "the secret is required"))
+		}
+	}
+	return errs
+}
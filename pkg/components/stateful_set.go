@@ -22,6 +22,7 @@ under the License.
 package components
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
@@ -29,6 +30,9 @@ import (
 	"k8s.io/api/apps/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // StatefulSet defines the stateful set component
@@ -96,13 +100,57 @@ func (s *StatefulSet) RemoveVolumeClaimTemplate(claim PersistentVolumeClaim) {
 	}
 }
 
-// Deploy will deploy the stateful set to the cluster
-func (s *StatefulSet) Deploy(res api.DeployerResources) error {
-	_, err := res.KubeClient.AppsV1().StatefulSets(s.Namespace).Create(s.StatefulSet)
-	return err
+// Deploy will create the stateful set in the cluster, or update it if it already exists
+func (s *StatefulSet) Deploy(ctx context.Context, res api.DeployerResources) error {
+	return s.applier(res).apply(ctx)
+}
+
+func (s *StatefulSet) applier(res api.DeployerResources) *applier {
+	client := res.KubeClient.AppsV1().StatefulSets(s.Namespace)
+	return &applier{
+		obj: s.StatefulSet,
+		get: func() (runtime.Object, error) {
+			obj, err := client.Get(s.Name, metav1.GetOptions{})
+			return obj, err
+		},
+		create: func(obj runtime.Object) error {
+			_, err := client.Create(obj.(*v1.StatefulSet))
+			return err
+		},
+		update: func(obj runtime.Object) error {
+			_, err := client.Update(obj.(*v1.StatefulSet))
+			return err
+		},
+	}
 }
 
 // Undeploy will remove the stateful set from the cluster
-func (s *StatefulSet) Undeploy(res api.DeployerResources) error {
+func (s *StatefulSet) Undeploy(ctx context.Context, res api.DeployerResources) error {
 	return res.KubeClient.AppsV1().StatefulSets(s.Namespace).Delete(s.Name, &metav1.DeleteOptions{})
 }
+
+// Validate checks the stateful set against the rules of the API server
+func (s *StatefulSet) Validate() field.ErrorList {
+	errs := validateObjectMeta(&s.ObjectMeta, true, dns1123Subdomain, field.NewPath("metadata"))
+	spec := field.NewPath("spec")
+	errs = append(errs, validateNonNegative(s.Spec.Replicas, spec.Child("replicas"))...)
+	errs = append(errs, validateNonNegative(s.Spec.RevisionHistoryLimit, spec.Child("revisionHistoryLimit"))...)
+	if rollingUpdate := s.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		errs = append(errs, validateNonNegative(rollingUpdate.Partition, spec.Child("updateStrategy", "rollingUpdate", "partition"))...)
+	}
+
+	claims := []string{}
+	for i := range s.Spec.VolumeClaimTemplates {
+		claim := &s.Spec.VolumeClaimTemplates[i]
+		claims = append(claims, claim.Name)
+		claimPath := spec.Child("volumeClaimTemplates").Index(i)
+		for _, msg := range validation.IsDNS1123Label(claim.Name) {
+			errs = append(errs, field.Invalid(claimPath.Child("metadata", "name"), claim.Name, msg))
+		}
+		errs = append(errs, validateClaimSpec(&claim.Spec, claimPath.Child("spec"))...)
+	}
+
+	errs = append(errs, validateSelector(s.Spec.Selector, &s.Spec.Template, spec.Child("selector"))...)
+	errs = append(errs, validatePodTemplate(&s.Spec.Template, spec.Child("template"), claims...)...)
+	return append(errs, validateRestartPolicy(&s.Spec.Template.Spec, spec.Child("template", "spec", "restartPolicy"), restartAlways)...)
+}
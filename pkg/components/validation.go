@@ -0,0 +1,491 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validatable is implemented by every component in this package
+type validatable interface {
+	Validate() field.ErrorList
+}
+
+// Validate checks a component against the rules the API server applies to its
+// object, so that an invalid component fails before it is deployed.  The
+// components that weren't created by this package are always valid
+func Validate(c api.DeployableComponentInterface) field.ErrorList {
+	v, ok := c.(validatable)
+	if !ok {
+		return nil
+	}
+	return v.Validate()
+}
+
+// nameValidator returns the reasons a name is invalid
+type nameValidator func(name string) []string
+
+var (
+	dns1123Label     nameValidator = validation.IsDNS1123Label
+	dns1123Subdomain nameValidator = validation.IsDNS1123Subdomain
+	dns1035Label     nameValidator = validation.IsDNS1035Label
+	rbacName         nameValidator = isValidPathSegmentName
+)
+
+// isValidPathSegmentName is the name validation of the RBAC objects
+func isValidPathSegmentName(name string) []string {
+	if name == "." || name == ".." {
+		return []string{fmt.Sprintf("may not be '%s'", name)}
+	}
+	if strings.ContainsAny(name, "/%") {
+		return []string{"may not contain '/' or '%'"}
+	}
+	return nil
+}
+
+const totalAnnotationSizeLimit = 256 * 1024
+
+func validateObjectMeta(meta *metav1.ObjectMeta, namespaced bool, isValidName nameValidator, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(meta.Name) == 0 {
+		errs = append(errs, field.Required(path.Child("name"), "name is required"))
+	} else {
+		for _, msg := range isValidName(meta.Name) {
+			errs = append(errs, field.Invalid(path.Child("name"), meta.Name, msg))
+		}
+	}
+
+	if !namespaced && len(meta.Namespace) > 0 {
+		errs = append(errs, field.Forbidden(path.Child("namespace"), "not allowed on cluster-scoped objects"))
+	} else if len(meta.Namespace) > 0 {
+		for _, msg := range validation.IsDNS1123Label(meta.Namespace) {
+			errs = append(errs, field.Invalid(path.Child("namespace"), meta.Namespace, msg))
+		}
+	}
+
+	errs = append(errs, validateLabels(meta.Labels, path.Child("labels"))...)
+	errs = append(errs, validateAnnotations(meta.Annotations, path.Child("annotations"))...)
+	return errs
+}
+
+func validateLabels(l map[string]string, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, k := range sets.StringKeySet(l).List() {
+		v := l[k]
+		for _, msg := range validation.IsQualifiedName(k) {
+			errs = append(errs, field.Invalid(path, k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(v) {
+			errs = append(errs, field.Invalid(path, v, msg))
+		}
+	}
+	return errs
+}
+
+func validateAnnotations(annotations map[string]string, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	size := 0
+	for _, k := range sets.StringKeySet(annotations).List() {
+		v := annotations[k]
+		for _, msg := range validation.IsQualifiedName(strings.ToLower(k)) {
+			errs = append(errs, field.Invalid(path, k, msg))
+		}
+		size += len(k) + len(v)
+	}
+	if size > totalAnnotationSizeLimit {
+		errs = append(errs, field.TooLong(path, "", totalAnnotationSizeLimit))
+	}
+	return errs
+}
+
+func validateNonNegative(value *int32, path *field.Path) field.ErrorList {
+	if value != nil && *value < 0 {
+		return field.ErrorList{field.Invalid(path, *value, "must be greater than or equal to 0")}
+	}
+	return nil
+}
+
+func validatePort(port int32, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, msg := range validation.IsValidPortNum(int(port)) {
+		errs = append(errs, field.Invalid(path, port, msg))
+	}
+	return errs
+}
+
+// validateIntOrPercent validates the MaxUnavailable and MaxExtra settings of
+// the rolling updates
+func validateIntOrPercent(value *intstr.IntOrString, path *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	errs := field.ErrorList{}
+	switch value.Type {
+	case intstr.Int:
+		if value.IntVal < 0 {
+			errs = append(errs, field.Invalid(path, value.IntVal, "must be greater than or equal to 0"))
+		}
+	case intstr.String:
+		for _, msg := range validation.IsValidPercent(value.StrVal) {
+			errs = append(errs, field.Invalid(path, value.StrVal, msg))
+		}
+		if len(errs) == 0 {
+			if percent, _ := intstr.GetValueFromIntOrPercent(value, 100, false); percent > 100 {
+				errs = append(errs, field.Invalid(path, value.StrVal, "must not be greater than 100%"))
+			}
+		}
+	}
+	return errs
+}
+
+func isZeroIntOrPercent(value *intstr.IntOrString) bool {
+	if value == nil {
+		return false
+	}
+	percent, err := intstr.GetValueFromIntOrPercent(value, 100, false)
+	return err == nil && percent == 0
+}
+
+// maxDataSize is the size limit of the data of config maps and secrets
+const maxDataSize = 1024 * 1024
+
+// validateDataKeys validates the keys of a data field of a config map or
+// secret, which must be unique across all its data fields
+func validateDataKeys(keys sets.String, seen sets.String, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, k := range keys.List() {
+		for _, msg := range validation.IsConfigMapKey(k) {
+			errs = append(errs, field.Invalid(path, k, msg))
+		}
+		if seen.Has(k) {
+			errs = append(errs, field.Invalid(path, k, "duplicate of a key in another data field"))
+		}
+		seen.Insert(k)
+	}
+	return errs
+}
+
+// validateSelector validates the selector of a controller, which must select
+// the pods of its template
+func validateSelector(selector *metav1.LabelSelector, template *v1.PodTemplateSpec, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return append(errs, field.Required(path, "a selector is required"))
+	}
+	errs = append(errs, validateLabels(selector.MatchLabels, path.Child("matchLabels"))...)
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return append(errs, field.Invalid(path, selector, err.Error()))
+	}
+	if template != nil && !s.Matches(labels.Set(template.Labels)) {
+		errs = append(errs, field.Invalid(path.Root().Child("template", "metadata", "labels"), template.Labels, "selector does not match template labels"))
+	}
+	return errs
+}
+
+// validatePodTemplate validates the pod template of a controller.  The claims
+// are the names of the volumes the controller adds to the pods
+func validatePodTemplate(template *v1.PodTemplateSpec, path *field.Path, claims ...string) field.ErrorList {
+	errs := validateLabels(template.Labels, path.Child("metadata", "labels"))
+	errs = append(errs, validateAnnotations(template.Annotations, path.Child("metadata", "annotations"))...)
+	return append(errs, validatePodSpec(&template.Spec, path.Child("spec"), claims...)...)
+}
+
+func validatePodSpec(spec *v1.PodSpec, path *field.Path, claims ...string) field.ErrorList {
+	errs := field.ErrorList{}
+
+	volumes := sets.NewString(claims...)
+	for i := range spec.Volumes {
+		volumePath := path.Child("volumes").Index(i)
+		errs = append(errs, validateVolume(&spec.Volumes[i], volumePath)...)
+		if volumes.Has(spec.Volumes[i].Name) {
+			errs = append(errs, field.Duplicate(volumePath.Child("name"), spec.Volumes[i].Name))
+		}
+		volumes.Insert(spec.Volumes[i].Name)
+	}
+
+	if len(spec.Containers) == 0 {
+		errs = append(errs, field.Required(path.Child("containers"), "a pod needs at least one container"))
+	}
+	names := sets.NewString()
+	for _, containers := range []struct {
+		name string
+		list []v1.Container
+	}{{"initContainers", spec.InitContainers}, {"containers", spec.Containers}} {
+		for i := range containers.list {
+			c := &containers.list[i]
+			containerPath := path.Child(containers.name).Index(i)
+			errs = append(errs, validateContainer(c, containerPath)...)
+			if names.Has(c.Name) {
+				errs = append(errs, field.Duplicate(containerPath.Child("name"), c.Name))
+			}
+			names.Insert(c.Name)
+			for j, mount := range c.VolumeMounts {
+				if !volumes.Has(mount.Name) {
+					errs = append(errs, field.NotFound(containerPath.Child("volumeMounts").Index(j).Child("name"), mount.Name))
+				}
+			}
+			for j, device := range c.VolumeDevices {
+				if !volumes.Has(device.Name) {
+					errs = append(errs, field.NotFound(containerPath.Child("volumeDevices").Index(j).Child("name"), device.Name))
+				}
+			}
+		}
+	}
+
+	if len(spec.ServiceAccountName) > 0 {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.ServiceAccountName) {
+			errs = append(errs, field.Invalid(path.Child("serviceAccountName"), spec.ServiceAccountName, msg))
+		}
+	}
+	errs = append(errs, validateLabels(spec.NodeSelector, path.Child("nodeSelector"))...)
+	for i, alias := range spec.HostAliases {
+		for _, msg := range validation.IsValidIP(alias.IP) {
+			errs = append(errs, field.Invalid(path.Child("hostAliases").Index(i).Child("ip"), alias.IP, msg))
+		}
+	}
+	return errs
+}
+
+func validateVolume(volume *v1.Volume, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(volume.Name) == 0 {
+		errs = append(errs, field.Required(path.Child("name"), "name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(volume.Name) {
+			errs = append(errs, field.Invalid(path.Child("name"), volume.Name, msg))
+		}
+	}
+
+	switch {
+	case volume.ConfigMap != nil:
+		if len(volume.ConfigMap.Name) == 0 {
+			errs = append(errs, field.Required(path.Child("configMap", "name"), "the config map is required"))
+		}
+		errs = append(errs, validateKeyToPaths(volume.ConfigMap.Items, path.Child("configMap", "items"))...)
+	case volume.Secret != nil:
+		if len(volume.Secret.SecretName) == 0 {
+			errs = append(errs, field.Required(path.Child("secret", "secretName"), "the secret is required"))
+		}
+		errs = append(errs, validateKeyToPaths(volume.Secret.Items, path.Child("secret", "items"))...)
+	case volume.PersistentVolumeClaim != nil:
+		if len(volume.PersistentVolumeClaim.ClaimName) == 0 {
+			errs = append(errs, field.Required(path.Child("persistentVolumeClaim", "claimName"), "the claim is required"))
+		}
+	case volume.HostPath != nil:
+		if len(volume.HostPath.Path) == 0 {
+			errs = append(errs, field.Required(path.Child("hostPath", "path"), "the path is required"))
+		}
+	case volume.GCEPersistentDisk != nil:
+		if len(volume.GCEPersistentDisk.PDName) == 0 {
+			errs = append(errs, field.Required(path.Child("gcePersistentDisk", "pdName"), "the disk is required"))
+		}
+	}
+	return errs
+}
+
+func validateKeyToPaths(items []v1.KeyToPath, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, item := range items {
+		for _, msg := range validation.IsConfigMapKey(item.Key) {
+			errs = append(errs, field.Invalid(path.Index(i).Child("key"), item.Key, msg))
+		}
+		if len(item.Path) == 0 {
+			errs = append(errs, field.Required(path.Index(i).Child("path"), "the path is required"))
+		} else if strings.HasPrefix(item.Path, "/") || strings.Contains(item.Path, "..") {
+			errs = append(errs, field.Invalid(path.Index(i).Child("path"), item.Path, "must be a relative path without '..'"))
+		}
+	}
+	return errs
+}
+
+func validateContainer(c *v1.Container, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(c.Name) == 0 {
+		errs = append(errs, field.Required(path.Child("name"), "name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(c.Name) {
+			errs = append(errs, field.Invalid(path.Child("name"), c.Name, msg))
+		}
+	}
+	if len(strings.TrimSpace(c.Image)) == 0 {
+		errs = append(errs, field.Required(path.Child("image"), "image is required"))
+	}
+
+	ports := sets.NewString()
+	for i, port := range c.Ports {
+		portPath := path.Child("ports").Index(i)
+		errs = append(errs, validatePort(port.ContainerPort, portPath.Child("containerPort"))...)
+		if port.HostPort != 0 {
+			errs = append(errs, validatePort(port.HostPort, portPath.Child("hostPort"))...)
+		}
+		if len(port.Name) > 0 {
+			for _, msg := range validation.IsValidPortName(port.Name) {
+				errs = append(errs, field.Invalid(portPath.Child("name"), port.Name, msg))
+			}
+			if ports.Has(port.Name) {
+				errs = append(errs, field.Duplicate(portPath.Child("name"), port.Name))
+			}
+			ports.Insert(port.Name)
+		}
+	}
+
+	for i, env := range c.Env {
+		for _, msg := range validation.IsEnvVarName(env.Name) {
+			errs = append(errs, field.Invalid(path.Child("env").Index(i).Child("name"), env.Name, msg))
+		}
+	}
+
+	mounts := sets.NewString()
+	for i, mount := range c.VolumeMounts {
+		mountPath := path.Child("volumeMounts").Index(i).Child("mountPath")
+		if len(mount.MountPath) == 0 {
+			errs = append(errs, field.Required(mountPath, "the mount path is required"))
+		} else if mounts.Has(mount.MountPath) {
+			errs = append(errs, field.Invalid(mountPath, mount.MountPath, "must be unique"))
+		}
+		mounts.Insert(mount.MountPath)
+	}
+
+	limits := sets.NewString()
+	for name := range c.Resources.Limits {
+		limits.Insert(string(name))
+	}
+	for _, key := range limits.List() {
+		name := v1.ResourceName(key)
+		limit := c.Resources.Limits[name]
+		if request, ok := c.Resources.Requests[name]; ok && request.Cmp(limit) > 0 {
+			errs = append(errs, field.Invalid(path.Child("resources", "requests").Key(string(name)), request.String(), fmt.Sprintf("must be less than or equal to %s limit", name)))
+		}
+	}
+	return errs
+}
+
+func validatePolicyRules(rules []rbacv1.PolicyRule, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, rule := range rules {
+		rulePath := path.Index(i)
+		if len(rule.Verbs) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("verbs"), "verbs must contain at least one value"))
+		}
+		if len(rule.NonResourceURLs) > 0 && (len(rule.APIGroups) > 0 || len(rule.Resources) > 0) {
+			errs = append(errs, field.Invalid(rulePath.Child("nonResourceURLs"), rule.NonResourceURLs, "rules cannot apply to both regular resources and non-resource URLs"))
+		}
+		if len(rule.NonResourceURLs) == 0 && len(rule.Resources) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("resources"), "resource rules must supply at least one resource"))
+		}
+	}
+	return errs
+}
+
+func validateRoleBinding(ref rbacv1.RoleRef, subjects []rbacv1.Subject, namespaced bool) field.ErrorList {
+	errs := field.ErrorList{}
+	refPath := field.NewPath("roleRef")
+	if len(ref.Name) == 0 {
+		errs = append(errs, field.Required(refPath.Child("name"), "the role is required"))
+	}
+	kinds := []string{"ClusterRole"}
+	if namespaced {
+		kinds = append(kinds, "Role")
+	}
+	if !sets.NewString(kinds...).Has(ref.Kind) {
+		errs = append(errs, field.NotSupported(refPath.Child("kind"), ref.Kind, kinds))
+	}
+
+	for i, subject := range subjects {
+		subjectPath := field.NewPath("subjects").Index(i)
+		if len(subject.Name) == 0 {
+			errs = append(errs, field.Required(subjectPath.Child("name"), "the subject name is required"))
+		}
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			if len(subject.Name) > 0 {
+				for _, msg := range validation.IsDNS1123Subdomain(subject.Name) {
+					errs = append(errs, field.Invalid(subjectPath.Child("name"), subject.Name, msg))
+				}
+			}
+			if !namespaced && len(subject.Namespace) == 0 {
+				errs = append(errs, field.Required(subjectPath.Child("namespace"), "the namespace of the service account is required"))
+			}
+		case rbacv1.UserKind, rbacv1.GroupKind:
+		default:
+			errs = append(errs, field.NotSupported(subjectPath.Child("kind"), subject.Kind, []string{rbacv1.ServiceAccountKind, rbacv1.UserKind, rbacv1.GroupKind}))
+		}
+	}
+	return errs
+}
+
+// the restart policies, for the files importing another API group as v1
+var (
+	restartAlways    = v1.RestartPolicyAlways
+	restartOnFailure = v1.RestartPolicyOnFailure
+	restartNever     = v1.RestartPolicyNever
+)
+
+// validateRestartPolicy checks the restart policy of a pod template is one its
+// controller supports.  An unset policy defaults to Always
+func validateRestartPolicy(spec *v1.PodSpec, path *field.Path, allowed ...v1.RestartPolicy) field.ErrorList {
+	policy := spec.RestartPolicy
+	if len(policy) == 0 {
+		policy = v1.RestartPolicyAlways
+	}
+	names := []string{}
+	for _, a := range allowed {
+		if policy == a {
+			return nil
+		}
+		names = append(names, string(a))
+	}
+	return field.ErrorList{field.NotSupported(path, policy, names)}
+}
+
+func validateClaimSpec(spec *v1.PersistentVolumeClaimSpec, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(spec.AccessModes) == 0 {
+		errs = append(errs, field.Required(path.Child("accessModes"), "at least one access mode is required"))
+	}
+	if spec.Selector != nil {
+		errs = append(errs, validateLabels(spec.Selector.MatchLabels, path.Child("selector", "matchLabels"))...)
+		if _, err := metav1.LabelSelectorAsSelector(spec.Selector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), spec.Selector, err.Error()))
+		}
+	}
+	storage, ok := spec.Resources.Requests[v1.ResourceStorage]
+	if !ok {
+		errs = append(errs, field.Required(path.Child("resources", "requests").Key(string(v1.ResourceStorage)), "the size of the claim is required"))
+	} else if storage.Sign() <= 0 {
+		errs = append(errs, field.Invalid(path.Child("resources", "requests").Key(string(v1.ResourceStorage)), storage.String(), "must be greater than 0"))
+	}
+	return errs
+}
@@ -0,0 +1,243 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validDeployment(t *testing.T) *Deployment {
+	d := NewDeployment(api.DeploymentConfig{
+		Name:           "web",
+		Namespace:      "shop",
+		Strategy:       api.DeploymentStrategyTypeRollingUpdate,
+		MaxUnavailable: "25%",
+		MaxExtra:       "1",
+	})
+	d.AddMatchLabelsSelectors(map[string]string{"app": "web"})
+
+	pod := NewPod(api.PodConfig{})
+	pod.AddLabels(map[string]string{"app": "web"})
+	volume, err := NewEmptyDirVolume(api.EmptyDirVolumeConfig{VolumeName: "cache"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod.AddVolume(volume)
+	container, err := NewContainer(api.ContainerConfig{Name: "web", Image: "nginx", MinCPU: "100m", MaxCPU: "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	container.AddPort(api.PortConfig{Name: "http", ContainerPort: 80, Protocol: api.ProtocolTCP})
+	container.AddEnv(api.EnvConfig{NameOrPrefix: "MODE", Type: api.EnvVal, KeyOrVal: "production"})
+	container.AddVolumeMount(api.VolumeMountConfig{Name: "cache", MountPath: "/cache"})
+	pod.AddContainer(container)
+	d.AddPod(pod)
+	return d
+}
+
+func TestDeploymentValidate(t *testing.T) {
+	var tests = []struct {
+		description string
+		change      func(d *Deployment)
+		expected    []string
+	}{
+		{
+			description: "valid",
+			change:      func(d *Deployment) {},
+		},
+		{
+			description: "invalid name",
+			change:      func(d *Deployment) { d.Name = "Web_App" },
+			expected:    []string{"metadata.name"},
+		},
+		{
+			description: "invalid labels",
+			change:      func(d *Deployment) { d.AddLabels(map[string]string{"bad key": "value", "app": "bad value"}) },
+			expected:    []string{"metadata.labels", "metadata.labels"},
+		},
+		{
+			description: "negative replicas",
+			change: func(d *Deployment) {
+				replicas := int32(-1)
+				d.Spec.Replicas = &replicas
+			},
+			expected: []string{"spec.replicas"},
+		},
+		{
+			description: "invalid max unavailable",
+			change:      func(d *Deployment) { d.Spec.Strategy.RollingUpdate.MaxUnavailable = createIntOrStr("half") },
+			expected:    []string{"spec.strategy.rollingUpdate.maxUnavailable"},
+		},
+		{
+			description: "zero max unavailable and surge",
+			change: func(d *Deployment) {
+				d.Spec.Strategy.RollingUpdate.MaxUnavailable = createIntOrStr("0%")
+				d.Spec.Strategy.RollingUpdate.MaxSurge = createIntOrStr("0")
+			},
+			expected: []string{"spec.strategy.rollingUpdate.maxUnavailable"},
+		},
+		{
+			description: "selector not matching the template",
+			change:      func(d *Deployment) { d.Spec.Template.Labels = map[string]string{"app": "api"} },
+			expected:    []string{"spec.template.metadata.labels"},
+		},
+		{
+			description: "invalid container",
+			change: func(d *Deployment) {
+				c := &d.Spec.Template.Spec.Containers[0]
+				c.Image = ""
+				c.Ports[0].ContainerPort = 70000
+				c.Env[0].Name = "1MODE"
+				c.VolumeMounts[0].Name = "missing"
+			},
+			expected: []string{
+				"spec.template.spec.containers[0].image",
+				"spec.template.spec.containers[0].ports[0].containerPort",
+				"spec.template.spec.containers[0].env[0].name",
+				"spec.template.spec.containers[0].volumeMounts[0].name",
+			},
+		},
+		{
+			description: "requests above limits",
+			change: func(d *Deployment) {
+				c := &d.Spec.Template.Spec.Containers[0]
+				c.Resources.Requests["cpu"] = c.Resources.Limits["cpu"]
+				c.Resources.Limits["cpu"] = c.Resources.Requests["cpu"].DeepCopy()
+				q := c.Resources.Limits["cpu"]
+				q.Set(0)
+				c.Resources.Limits["cpu"] = q
+			},
+			expected: []string{"spec.template.spec.containers[0].resources.requests[cpu]"},
+		},
+		{
+			description: "no container",
+			change:      func(d *Deployment) { d.Spec.Template.Spec.Containers = nil },
+			expected:    []string{"spec.template.spec.containers"},
+		},
+	}
+
+	for _, test := range tests {
+		d := validDeployment(t)
+		test.change(d)
+		checkFieldErrors(t, test.description, d.Validate(), test.expected)
+	}
+}
+
+func TestComponentValidate(t *testing.T) {
+	emptyName := NewNamespace(api.NamespaceConfig{})
+
+	cm := NewConfigMap(api.ConfigMapConfig{Name: "settings"})
+	cm.AddData(map[string]string{"mode": "a", "bad/key": "b"})
+	cm.AddBinaryData(map[string][]byte{"mode": []byte("c")})
+
+	tls := NewSecret(api.SecretConfig{Name: "tls", Type: api.SecretTypeTLS})
+	tls.AddStringData(map[string]string{"tls.crt": "cert"})
+
+	svc := NewService(api.ServiceConfig{Name: "web", Type: api.ServiceTypeServiceIP})
+	svc.AddPort(api.ServicePortConfig{Name: "HTTP", Port: 80, TargetPort: "http"})
+	svc.AddPort(api.ServicePortConfig{Name: "admin", Port: 70000, NodePort: 30000})
+
+	noPorts := NewService(api.ServiceConfig{Name: "web", Type: api.ServiceTypeServiceIP})
+	headless := NewService(api.ServiceConfig{Name: "web", Type: api.ServiceTypeServiceIP, ClusterIP: "None"})
+
+	binding := NewRoleBinding(api.RoleBindingConfig{Name: "binding"})
+	binding.AddSubject(api.SubjectConfig{Kind: "ServiceAccount", Name: "web"})
+
+	role := NewClusterRole(api.ClusterRoleConfig{Name: "reader"})
+	role.AddPolicyRule(api.PolicyRuleConfig{Resources: []string{"pods"}})
+
+	hpa := NewHorizontalPodAutoscaler(api.HPAConfig{Name: "web", MaxReplicas: 0})
+
+	pdb := NewPodDisruptionBudget(api.PodDisruptionBudgetConfig{Name: "web", MinAvailable: "1", MaxUnavailable: "150%"})
+
+	np := NewNetworkPolicy(api.NetworkPolicyConfig{Name: "web", PodSelector: api.SelectorConfig{Labels: map[string]string{"app": "web"}}})
+	np.AddIngressRule(api.NetworkPolicyRuleConfig{
+		Ports: []api.NetworkPolicyPortConfig{{Port: "http", Protocol: api.ProtocolTCP}, {Port: "70000"}},
+		Peers: []api.NetworkPolicyPeerConfig{{}, {CIDR: "10.0.0.0/8", Except: []string{"192.168.0.0/16"}}},
+	})
+	np.AddEgressRule(api.NetworkPolicyRuleConfig{
+		Peers: []api.NetworkPolicyPeerConfig{{PodSelector: &api.SelectorConfig{Labels: map[string]string{"app": "db"}}, CIDR: "10.0.0.0/8"}},
+	})
+
+	var tests = []struct {
+		description string
+		component   validatable
+		expected    []string
+	}{
+		{description: "namespace", component: emptyName, expected: []string{"metadata.name"}},
+		{description: "config map", component: cm, expected: []string{"data", "binaryData"}},
+		{description: "tls secret", component: tls, expected: []string{"data[tls.key]"}},
+		{description: "service", component: svc, expected: []string{"spec.ports[0].name", "spec.ports[1].port", "spec.ports[1].nodePort"}},
+		{description: "service without ports", component: noPorts, expected: []string{"spec.ports"}},
+		{description: "headless service without ports", component: headless, expected: []string{}},
+		{description: "role binding", component: binding, expected: []string{"roleRef.name", "roleRef.kind"}},
+		{description: "cluster role", component: role, expected: []string{"rules[0].verbs"}},
+		{description: "hpa", component: hpa, expected: []string{"spec.scaleTargetRef.kind", "spec.scaleTargetRef.name", "spec.maxReplicas"}},
+		{description: "pod disruption budget", component: pdb, expected: []string{"spec", "spec.maxUnavailable"}},
+		{description: "network policy", component: np, expected: []string{"spec.ingress[0].ports[1].port", "spec.ingress[0].from[0]", "spec.ingress[0].from[1].ipBlock.except[0]", "spec.egress[0].to[0]"}},
+	}
+
+	for _, test := range tests {
+		checkFieldErrors(t, test.description, test.component.Validate(), test.expected)
+	}
+}
+
+func checkFieldErrors(t *testing.T, description string, errs field.ErrorList, expected []string) {
+	fields := []string{}
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("%s: expected errors on %v, got %v", description, expected, errs)
+	}
+}
+
+func TestStatefulSetValidateClaimMounts(t *testing.T) {
+	s := NewStatefulSet(api.StatefulSetConfig{Name: "db", Service: "db"})
+	s.AddMatchLabelsSelectors(map[string]string{"app": "db"})
+	claim, err := NewPersistentVolumeClaim(api.PVCConfig{Name: "data", Size: "1Gi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claim.AddAccessMode(api.ReadWriteOnce)
+	s.AddVolumeClaimTemplate(*claim)
+
+	pod := NewPod(api.PodConfig{})
+	pod.AddLabels(map[string]string{"app": "db"})
+	container, err := NewContainer(api.ContainerConfig{Name: "db", Image: "postgres"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	container.AddVolumeMount(api.VolumeMountConfig{Name: "data", MountPath: "/var/lib/postgresql"})
+	pod.AddContainer(container)
+	s.AddPod(pod)
+
+	checkFieldErrors(t, "claim template mount", s.Validate(), nil)
+
+	s.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name = "logs"
+	checkFieldErrors(t, "unknown mount", s.Validate(), []string{"spec.template.spec.containers[0].volumeMounts[0].name"})
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Volume defines the volume component
@@ -192,3 +193,8 @@ func NewPVCVolume(config api.PVCVolumeConfig) *Volume {
 
 	return &Volume{&v}
 }
+
+// Validate checks the volume against the rules of the API server
+func (v *Volume) Validate() field.ErrorList {
+	return validateVolume(v.Volume, nil)
+}
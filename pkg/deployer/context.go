@@ -0,0 +1,95 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// InFlightError is the error reported for a component that was being deployed
+// or removed when the context was cancelled or the component timed out
+type InFlightError struct {
+	Kind   api.ComponentType
+	Name   string
+	Action string
+	Err    error
+}
+
+// Error implements the error interface Error function
+func (e *InFlightError) Error() string {
+	return fmt.Sprintf("%s of %s %s was in flight when it was stopped: %v", e.Action, e.Kind, e.Name, e.Err)
+}
+
+// SetTimeoutConfig sets the deadline of a run and the time allowed for each component
+func (d *Deployer) SetTimeoutConfig(config api.TimeoutConfig) {
+	d.timeoutConfig = &config
+}
+
+// withDeadline returns a context bounded by the configured run timeout
+func (d *Deployer) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.timeoutConfig != nil && d.timeoutConfig.Timeout > 0 {
+		return context.WithTimeout(ctx, d.timeoutConfig.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// componentTimeout returns the time allowed for a component of the given type
+func (d *Deployer) componentTimeout(kind api.ComponentType) time.Duration {
+	if d.timeoutConfig == nil {
+		return 0
+	}
+	if t, ok := d.timeoutConfig.KindTimeouts[kind]; ok {
+		return t
+	}
+	return d.timeoutConfig.ComponentTimeout
+}
+
+// runComponent calls f with a context bounded by the component timeout.  Since the
+// kubernetes clients can't be interrupted, f is left running in the background if
+// the context is done first and an InFlightError is returned
+func (d *Deployer) runComponent(ctx context.Context, action string, kind api.ComponentType, c api.DeployableComponentInterface, f func(context.Context) error) error {
+	if timeout := d.componentTimeout(kind); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- f(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		err := &InFlightError{Kind: kind, Name: c.GetName(), Action: action, Err: ctx.Err()}
+		log.Warnf("%v", err)
+		return err
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hungComponent simulates a call to the api server that never returns
+type hungComponent struct {
+	*v1.ConfigMap
+	block chan struct{}
+}
+
+func (h *hungComponent) Deploy(context.Context, api.DeployerResources) error {
+	<-h.block
+	return nil
+}
+
+func (h *hungComponent) Undeploy(context.Context, api.DeployerResources) error {
+	<-h.block
+	return nil
+}
+
+func TestDeployGraphComponentTimeout(t *testing.T) {
+	comps, deployed := newRecordingComponents("after")
+	hung := &hungComponent{ConfigMap: &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "hung"}}, block: make(chan struct{})}
+	defer close(hung.block)
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, hung)
+	d.AddComponent(api.ConfigMapComponent, comps["after"])
+	d.AddDependency(comps["after"], hung)
+	d.SetTimeoutConfig(api.TimeoutConfig{KindTimeouts: map[api.ComponentType]time.Duration{api.ConfigMapComponent: 10 * time.Millisecond}})
+
+	g, err := d.buildGraph()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := d.deployGraph(context.Background(), g, api.DeployerResources{}, nil)[api.ConfigMapComponent]
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	inFlight, ok := errs[0].(*InFlightError)
+	if !ok || inFlight.Name != "hung" || inFlight.Err != context.DeadlineExceeded {
+		t.Errorf("expected the hung component to be reported in flight, got %v", errs[0])
+	}
+	if len(*deployed) != 0 {
+		t.Errorf("expected the dependent component not to be deployed, got %v", *deployed)
+	}
+}
+
+func TestDeployGraphCancelled(t *testing.T) {
+	comps, deployed := newRecordingComponents("a", "b")
+	d := NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, comps["a"])
+	d.AddComponent(api.ConfigMapComponent, comps["b"])
+
+	g, err := d.buildGraph()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := d.deployGraph(ctx, g, api.DeployerResources{}, nil)[api.ConfigMapComponent]
+	if len(errs) != 2 || !strings.Contains(errs[0].Error(), "deploy was stopped") {
+		t.Errorf("expected both components to be reported as not deployed, got %v", errs)
+	}
+	if len(*deployed) != 0 {
+		t.Errorf("expected no component to be deployed, got %v", *deployed)
+	}
+}
@@ -22,18 +22,18 @@ under the License.
 package deployer
 
 import (
-	"bytes"
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/policy"
 	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
 
-	"k8s.io/apimachinery/pkg/runtime/serializer/json"
-
 	extensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 
 	log "github.com/sirupsen/logrus"
@@ -50,6 +50,7 @@ var deployOrder = []api.ComponentType{
 	api.ConfigMapComponent,
 	api.SecretComponent,
 	api.PersistentVolumeClaimComponent,
+	api.NetworkPolicyComponent,
 	api.ReplicationControllerComponent,
 	api.PodComponent,
 	api.DeploymentComponent,
@@ -59,6 +60,7 @@ var deployOrder = []api.ComponentType{
 	api.IngressComponent,
 	api.StatefulSetComponent,
 	api.DaemonSetComponent,
+	api.PodDisruptionBudgetComponent,
 }
 
 // Deployer handles deploying the components to a cluster
@@ -66,8 +68,21 @@ type Deployer struct {
 	components  map[api.ComponentType][]api.DeployableComponentInterface
 	controllers map[string]api.DeployerControllerInterface
 
-	client        *kubernetes.Clientset
-	apiextensions *extensionsclient.Clientset
+	dependencies    []dependency
+	externals       map[string]bool
+	securityPolicy  *policy.Policy
+	policySeverity  policy.Severity
+	hardened        bool
+	parallelism     int
+	transactional   bool
+	waitConfig      *api.WaitConfig
+	releaseConfig   *api.ReleaseConfig
+	inventoryConfig *api.InventoryConfig
+	timeoutConfig   *api.TimeoutConfig
+	observers       *observerList
+
+	client        kubernetes.Interface
+	apiextensions extensionsclient.Interface
 }
 
 // NewDeployer creates a Deployer object
@@ -84,10 +99,16 @@ func NewDeployer(kubeconfig *rest.Config) (*Deployer, error) {
 		return nil, fmt.Errorf("error creating the kubernetes api extensions client: %v", err)
 	}
 
+	return NewDeployerWithClients(client, extensions), nil
+}
+
+// NewDeployerWithClients creates a Deployer object that uses the given clients,
+// which allows deploying with fake or in-memory clients
+func NewDeployerWithClients(client kubernetes.Interface, extensions extensionsclient.Interface) *Deployer {
 	d := createDeployer()
 	d.client = client
 	d.apiextensions = extensions
-	return d, nil
+	return d
 }
 
 // NewDeployerExporter creates a Deployer object that only supports exporting
@@ -99,6 +120,7 @@ func createDeployer() *Deployer {
 	return &Deployer{
 		components:  make(map[api.ComponentType][]api.DeployableComponentInterface),
 		controllers: make(map[string]api.DeployerControllerInterface),
+		observers:   &observerList{},
 	}
 }
 
@@ -124,24 +146,135 @@ func (d *Deployer) exporterOnly() bool {
 	return false
 }
 
-// Run starts the deployer and deploys all components to the cluster
+// Run starts the deployer and deploys all components to the cluster.  Nothing is
+// deployed if a component fails validation or violates the security policy.  If the
+// deployer is hardened, copies of the pods are first rewritten to a restricted profile,
+// leaving the components added to the deployer unchanged.  Components that already
+// exist in the cluster will be updated if they have changed.  Components are deployed
+// after the components they depend on, and independent components are deployed in
+// parallel.  If a component fails, the components depending on it are skipped, and if
+// the deployer is transactional the changes made by the run are rolled back.  If an
+// inventory configuration with pruning was set, objects removed from the deployer are
+// then removed from the cluster.  If a release configuration was set, a successful run
+// is recorded as a new release
 func (d *Deployer) Run() error {
-	if d.exporterOnly() {
-		return fmt.Errorf("deployer has no clients defined and can only be used to export")
+	return d.RunWithContext(context.Background())
+}
+
+// RunWithContext deploys all components like Run, and stops when the context is done
+// or the run timeout expires.  The components that were being deployed at that time
+// are reported with an InFlightError and the remaining components are not deployed
+func (d *Deployer) RunWithContext(ctx context.Context) (err error) {
+	d.observers.start(d.componentCount())
+	defer func() {
+		d.observers.finish(err)
+	}()
+
+	p, err := d.prepare()
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := p.CheckPolicy(); err != nil {
+		return err
+	}
+	if err := p.checkReleaseStorage(); err != nil {
+		return err
 	}
 
-	allErrs := map[api.ComponentType][]error{}
-	resources := d.getResources()
+	ctx, cancel := d.withDeadline(ctx)
+	defer cancel()
+
+	if err := p.deploy(ctx); err != nil {
+		return err
+	}
+
+	if d.inventoryConfig != nil && d.inventoryConfig.Prune {
+		if _, err := p.prune(ctx, false); err != nil {
+			return err
+		}
+	}
+
+	if d.releaseConfig != nil {
+		err := p.recordRelease("")
+		d.observers.phaseError(api.DeployPhaseRelease, err)
+		return err
+	}
+	return nil
+}
+
+// prepare returns a copy of the deployer with deep copies of the components, labeled
+// with the ownership labels and hardened if the deployer is hardened, so that running
+// or planning the deployer leaves the components that were added to it unchanged
+func (d *Deployer) prepare() (*Deployer, error) {
+	copies := map[api.DeployableComponentInterface]api.DeployableComponentInterface{}
+	comps := map[api.ComponentType][]api.DeployableComponentInterface{}
 	for _, ct := range deployOrder {
 		for _, c := range d.components[ct] {
-			log.Infof("creating %s %s", ct, c.GetName())
-			err := c.Deploy(resources)
-			if err != nil {
-				allErrs[ct] = append(allErrs[ct], err)
+			copies[c] = c
+			// components from other packages are used as they are
+			if components.CanApply(c) {
+				if _, copied, err := components.NewComponentFromObject(c.DeepCopyObject()); err == nil {
+					copies[c] = copied
+				}
 			}
+			comps[ct] = append(comps[ct], copies[c])
 		}
 	}
 
+	p := d.withComponents(comps)
+	for _, dep := range d.dependencies {
+		component, dependsOn := dep.component, dep.dependsOn
+		if c, ok := copies[component]; ok {
+			component = c
+		}
+		if c, ok := copies[dependsOn]; ok {
+			dependsOn = c
+		}
+		p.dependencies = append(p.dependencies, dependency{component: component, dependsOn: dependsOn})
+	}
+
+	if err := p.stampOwnership(); err != nil {
+		return nil, err
+	}
+	if d.hardened {
+		if err := p.Harden(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (d *Deployer) deploy(ctx context.Context) error {
+	if d.exporterOnly() {
+		return fmt.Errorf("deployer has no clients defined and can only be used to export")
+	}
+
+	if err := d.stampOwnership(); err != nil {
+		return err
+	}
+
+	g, err := d.buildGraph()
+	if err != nil {
+		return err
+	}
+
+	var tx *transaction
+	if d.transactional {
+		tx = &transaction{observers: d.observers}
+	}
+
+	resources := d.getResources()
+	allErrs := d.deployGraph(ctx, g, resources, tx)
+	d.observers.phaseComplete(api.DeployPhaseDeploy, allErrs)
+	if tx != nil && len(allErrs) > 0 {
+		// the run context may be done, the rollback is only bounded by the component timeouts
+		rollbackErrs := tx.rollback(context.Background(), resources)
+		d.observers.phaseComplete(api.DeployPhaseRollback, rollbackErrs)
+		return utilserror.NewRollbackErrors(allErrs, rollbackErrs)
+	}
 	return utilserror.NewDeployErrors(allErrs)
 }
 
@@ -189,50 +322,80 @@ func (d *Deployer) StartControllers(stopCh chan struct{}) map[string][]error {
 	return errs
 }
 
-// Undeploy will remove all components from the cluster
+// Undeploy will remove all components from the cluster, removing components
+// before the components they depend on
 func (d *Deployer) Undeploy() error {
-	var err error
+	return d.UndeployWithContext(context.Background())
+}
+
+// UndeployWithContext removes all components like Undeploy, and stops when the
+// context is done or the run timeout expires
+func (d *Deployer) UndeployWithContext(ctx context.Context) (err error) {
 	if d.exporterOnly() {
 		return fmt.Errorf("deployer has no clients defined and can only be used to export")
 	}
 
+	d.observers.start(d.componentCount())
+	defer func() {
+		d.observers.finish(err)
+	}()
+
+	ctx, cancel := d.withDeadline(ctx)
+	defer cancel()
+	return d.undeploy(ctx, api.DeployPhaseUndeploy)
+}
+
+// undeploy removes the components in reverse dependency order as part of the given phase
+func (d *Deployer) undeploy(ctx context.Context, phase api.DeployPhaseType) error {
+	g, err := d.buildGraph()
+	if err != nil {
+		return err
+	}
+	order, err := g.order()
+	if err != nil {
+		return err
+	}
+
 	allErrs := map[api.ComponentType][]error{}
 	resources := d.getResources()
-	for cnt := len(deployOrder) - 1; cnt >= 0; cnt-- {
-		cType := deployOrder[cnt]
-		for _, c := range d.components[cType] {
-			log.Infof("deleting %s %s", cType, c.GetName())
-			err = c.Undeploy(resources)
-			if err != nil {
-				allErrs[cType] = append(allErrs[cType], err)
-			}
+	for cnt := len(order) - 1; cnt >= 0; cnt-- {
+		n := g.nodes[order[cnt]]
+		if ctx.Err() != nil {
+			allErrs[n.kind] = append(allErrs[n.kind], fmt.Errorf("%s was not removed because the undeploy was stopped: %v", n.component.GetName(), ctx.Err()))
+			continue
+		}
+
+		log.Infof("deleting %s %s", n.kind, n.component.GetName())
+		start := time.Now()
+		d.observers.componentStart(phase, n.kind, n.component.GetName())
+		err = d.runComponent(ctx, "undeploy", n.kind, n.component, func(ctx context.Context) error {
+			return n.component.Undeploy(ctx, resources)
+		})
+		d.observers.componentDone(phase, n.kind, n.component.GetName(), start, err)
+		if err != nil {
+			allErrs[n.kind] = append(allErrs[n.kind], err)
 		}
 	}
 
+	d.observers.phaseComplete(phase, allErrs)
 	return utilserror.NewDeployErrors(allErrs)
 }
 
-// Export returns api string objects for all types
+// Export returns api string objects for all types, keyed by name.  Components
+// that fail to export are skipped.
+// Deprecated: components of different types with the same name overwrite each
+// other, use ExportObjects instead
 func (d *Deployer) Export() map[string]string {
-	ser := json.NewYAMLSerializer(json.DefaultMetaFactory, scheme.Scheme,
-		scheme.Scheme)
-
-	// append more yaml
 	m := map[string]string{}
-	appender := func(s string, obj api.DeployableComponentInterface) {
-		buf := bytes.NewBufferString("")
-		err := ser.Encode(obj, buf)
-		if err != nil {
-			panic(err)
-		}
-		m[s] = fmt.Sprintf("%v \n---", buf.String())
-	}
-
 	for _, ct := range deployOrder {
 		for _, c := range d.components[ct] {
-			appender(c.GetName(), c)
+			data, err := renderYAML(c)
+			if err != nil {
+				log.Errorf("unable to export %s %s: %v", ct, c.GetName(), err)
+				continue
+			}
+			m[c.GetName()] = fmt.Sprintf("%v \n---", string(data))
 		}
 	}
-
 	return m
 }
@@ -46,10 +46,9 @@ func TestMultipleControllerErrors(t *testing.T) {
 	name2 := "controller2"
 	controller1 := errorController{Name: name1}
 	controller2 := errorController{Name: name2}
-	d := Deployer{}
-	d.controllers = map[string]api.DeployerControllerInterface{name1: &controller1, name2: &controller2}
-	d.client = &kubernetes.Clientset{}
-	d.apiextensions = &extensionsclient.Clientset{}
+	d := NewDeployerWithClients(&kubernetes.Clientset{}, &extensionsclient.Clientset{})
+	d.AddController(name1, &controller1)
+	d.AddController(name2, &controller2)
 	stopCh := make(chan struct{})
 	errCh := make(chan map[string][]error)
 	go func() {
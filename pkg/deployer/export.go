@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterScopedDirectory is the directory used by ExportDirectory for
+// components that don't belong to a namespace
+const clusterScopedDirectory = "_cluster"
+
+// ExportedObject defines a component rendered by ExportObjects
+type ExportedObject struct {
+	Kind      api.ComponentType
+	Namespace string
+	Name      string
+	// Data is the YAML representation of the component
+	Data []byte
+}
+
+// renderYAML renders an object as YAML.  Exports and plans both use it, so
+// that a plan shows the objects as they are exported
+func renderYAML(obj interface{}) ([]byte, error) {
+	return yaml.Marshal(obj)
+}
+
+// ExportObjects renders the components as YAML, in the order they would be deployed
+func (d *Deployer) ExportObjects() ([]ExportedObject, error) {
+	objs := []ExportedObject{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			accessor, err := meta.Accessor(c)
+			if err != nil {
+				return nil, fmt.Errorf("unable to export %s %s: %v", ct, c.GetName(), err)
+			}
+			data, err := renderYAML(c)
+			if err != nil {
+				return nil, fmt.Errorf("unable to export %s %s: %v", ct, c.GetName(), err)
+			}
+			objs = append(objs, ExportedObject{Kind: ct, Namespace: accessor.GetNamespace(), Name: accessor.GetName(), Data: data})
+		}
+	}
+	return objs, nil
+}
+
+// ExportYAML writes the components as a multi-document YAML stream
+func (d *Deployer) ExportYAML(w io.Writer) error {
+	objs, err := d.ExportObjects()
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		if _, err := io.WriteString(w, "---\n"); err != nil {
+			return err
+		}
+		if _, err := w.Write(obj.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportJSONList writes the components as a JSON v1 List
+func (d *Deployer) ExportJSONList(w io.Writer) error {
+	objs, err := d.ExportObjects()
+	if err != nil {
+		return err
+	}
+
+	list := v1.List{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "List",
+			APIVersion: "v1",
+		},
+		Items: []runtime.RawExtension{},
+	}
+	for _, obj := range objs {
+		data, err := yaml.YAMLToJSON(obj.Data)
+		if err != nil {
+			return fmt.Errorf("unable to export %s %s: %v", obj.Kind, obj.Name, err)
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: data})
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// ExportDirectory writes one YAML file per component into a directory per
+// namespace.  Files are prefixed with their position in the deploy order
+func (d *Deployer) ExportDirectory(dir string) error {
+	objs, err := d.ExportObjects()
+	if err != nil {
+		return err
+	}
+
+	for i, obj := range objs {
+		path := filepath.Join(dir, exportDirectory(obj), exportFileName(i, obj))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, obj.Data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportDirectory(obj ExportedObject) string {
+	if len(obj.Namespace) == 0 {
+		return clusterScopedDirectory
+	}
+	return obj.Namespace
+}
+
+func exportFileName(index int, obj ExportedObject) string {
+	return fmt.Sprintf("%03d-%s-%s.yaml", index, strings.ToLower(string(obj.Kind)), obj.Name)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type unencodableComponent struct {
+	*v1.ConfigMap
+	Channel chan struct{}
+}
+
+func (u *unencodableComponent) Deploy(context.Context, api.DeployerResources) error {
+	return nil
+}
+
+func (u *unencodableComponent) Undeploy(context.Context, api.DeployerResources) error {
+	return nil
+}
+
+func newExportDeployer() *Deployer {
+	d := NewDeployerExporter()
+	d.AddComponent(api.DeploymentComponent, components.NewDeployment(api.DeploymentConfig{Name: "web", Namespace: "ns"}))
+	d.AddComponent(api.ServiceComponent, components.NewService(api.ServiceConfig{Name: "web", Namespace: "ns"}))
+	d.AddComponent(api.NamespaceComponent, components.NewNamespace(api.NamespaceConfig{Name: "ns"}))
+	return d
+}
+
+func TestExportObjects(t *testing.T) {
+	objs, err := newExportDeployer().ExportObjects()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []api.ComponentType{api.NamespaceComponent, api.DeploymentComponent, api.ServiceComponent}
+	if len(objs) != len(expected) {
+		t.Fatalf("expected %d objects, got %d", len(expected), len(objs))
+	}
+	for i, obj := range objs {
+		if obj.Kind != expected[i] {
+			t.Errorf("expected object %d to be a %s, got %s", i, expected[i], obj.Kind)
+		}
+		if !strings.Contains(string(obj.Data), "name: "+obj.Name) {
+			t.Errorf("expected the data of %s to contain its name", obj.Name)
+		}
+	}
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, &unencodableComponent{ConfigMap: &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "bad"}}})
+	if _, err := d.ExportObjects(); err == nil {
+		t.Errorf("expected an error exporting a component that can't be encoded")
+	}
+}
+
+func TestExportSkipsFailingComponents(t *testing.T) {
+	d := newExportDeployer()
+	d.AddComponent(api.ConfigMapComponent, &unencodableComponent{ConfigMap: &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "bad"}}})
+
+	exported := d.Export()
+	if _, ok := exported["bad"]; ok {
+		t.Errorf("expected the component that can't be encoded to be skipped")
+	}
+	if len(exported) != 2 || !strings.Contains(exported["ns"], "name: ns") {
+		t.Errorf("expected the other components to be exported, got %v", exported)
+	}
+}
+
+func TestExportYAMLAndJSONList(t *testing.T) {
+	d := newExportDeployer()
+	buf := &bytes.Buffer{}
+	if err := d.ExportYAML(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(buf.String(), "---\n") != 3 {
+		t.Errorf("expected 3 documents, got %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := d.ExportJSONList(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list := v1.List{}
+	if err := json.Unmarshal(buf.Bytes(), &list); err != nil {
+		t.Fatalf("unable to decode the list: %v", err)
+	}
+	if list.Kind != "List" || len(list.Items) != 3 {
+		t.Errorf("expected a list of 3 items, got %s", buf.String())
+	}
+}
+
+func TestExportDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatalf("unable to create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := newExportDeployer().ExportDirectory(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, f := range []string{"_cluster/000-namespace-ns.yaml", "ns/001-deployment-web.yaml", "ns/002-service-web.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("expected %s to be exported: %v", f, err)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultParallelism = 5
+
+type dependency struct {
+	component api.DeployableComponentInterface
+	dependsOn api.DeployableComponentInterface
+}
+
+type graphNode struct {
+	kind       api.ComponentType
+	component  api.DeployableComponentInterface
+	dependsOn  []int
+	dependents []int
+}
+
+// graph defines the dependencies between the components of a deployer.  Nodes
+// are stored in deploy order, which is used to order independent components
+type graph struct {
+	nodes []*graphNode
+}
+
+// AddDependency will make the deployer deploy dependsOn, and wait for it to be ready
+// if a wait configuration was set, before deploying component.  Both components must
+// be added to the deployer
+func (d *Deployer) AddDependency(component api.DeployableComponentInterface, dependsOn api.DeployableComponentInterface) {
+	d.dependencies = append(d.dependencies, dependency{component: component, dependsOn: dependsOn})
+}
+
+// SetParallelism sets the maximum number of components that will be deployed at the same time
+func (d *Deployer) SetParallelism(parallelism int) {
+	d.parallelism = parallelism
+}
+
+func componentKey(kind api.ComponentType, namespace string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// keyOf returns the key identifying the component in the cluster
+func keyOf(kind api.ComponentType, c api.DeployableComponentInterface) string {
+	namespace := ""
+	if accessor, err := meta.Accessor(c); err == nil {
+		namespace = accessor.GetNamespace()
+	}
+	return componentKey(kind, namespace, c.GetName())
+}
+
+// buildGraph creates the dependency graph of the components.  Besides the dependencies
+// that were explicitly added, components depend on their namespace, on the components
+// they reference, except for weak references, and pods depend on the bindings granting
+// roles to their service account.  When waiting, services depend on the workloads whose
+// pods they select
+func (d *Deployer) buildGraph() (*graph, error) {
+	g := &graph{}
+	index := map[api.DeployableComponentInterface]int{}
+	keys := map[string][]int{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			accessor, err := meta.Accessor(c)
+			if err != nil {
+				return nil, err
+			}
+			index[c] = len(g.nodes)
+			key := componentKey(ct, accessor.GetNamespace(), accessor.GetName())
+			keys[key] = append(keys[key], len(g.nodes))
+			g.nodes = append(g.nodes, &graphNode{kind: ct, component: c})
+		}
+	}
+
+	bindings := map[string][]int{}
+	for i, n := range g.nodes {
+		if n.kind != api.RoleBindingComponent && n.kind != api.ClusterRoleBindingComponent {
+			continue
+		}
+		for _, ref := range components.GetReferences(n.component) {
+			if ref.Kind == api.ServiceAccountComponent {
+				key := componentKey(ref.Kind, ref.Namespace, ref.Name)
+				bindings[key] = append(bindings[key], i)
+			}
+		}
+	}
+
+	edges := map[int]map[int]bool{}
+	addEdge := func(from int, to int) {
+		if from == to {
+			return
+		}
+		if edges[from] == nil {
+			edges[from] = map[int]bool{}
+		}
+		edges[from][to] = true
+	}
+
+	for i, n := range g.nodes {
+		accessor, _ := meta.Accessor(n.component)
+		if ns := accessor.GetNamespace(); len(ns) > 0 {
+			for _, j := range keys[componentKey(api.NamespaceComponent, "", ns)] {
+				addEdge(i, j)
+			}
+		}
+
+		hasPod := components.GetPodSpec(n.component) != nil
+		for _, ref := range components.GetReferences(n.component) {
+			if ref.Weak {
+				continue
+			}
+			key := componentKey(ref.Kind, ref.Namespace, ref.Name)
+			for _, j := range keys[key] {
+				addEdge(i, j)
+			}
+			if hasPod && ref.Kind == api.ServiceAccountComponent {
+				for _, j := range bindings[key] {
+					addEdge(i, j)
+				}
+			}
+		}
+	}
+
+	// services are ready once the pods they select are, so when waiting they
+	// are deployed after the workloads creating those pods
+	if d.waitConfig != nil {
+		for i, n := range g.nodes {
+			svc, ok := n.component.(*components.Service)
+			if !ok || len(svc.Spec.Selector) == 0 {
+				continue
+			}
+			selector := labels.SelectorFromSet(svc.Spec.Selector)
+			for j, m := range g.nodes {
+				if t := podTemplateOf(m.kind, m.component); t != nil && t.namespace == svc.Namespace && selector.Matches(t.labels) {
+					addEdge(i, j)
+				}
+			}
+		}
+	}
+
+	for _, dep := range d.dependencies {
+		from, ok := index[dep.component]
+		if !ok {
+			return nil, fmt.Errorf("dependency of %s which was not added to the deployer", dep.component.GetName())
+		}
+		to, ok := index[dep.dependsOn]
+		if !ok {
+			return nil, fmt.Errorf("dependency on %s which was not added to the deployer", dep.dependsOn.GetName())
+		}
+		addEdge(from, to)
+	}
+
+	for from := range g.nodes {
+		for to := range g.nodes {
+			if edges[from][to] {
+				g.nodes[from].dependsOn = append(g.nodes[from].dependsOn, to)
+				g.nodes[to].dependents = append(g.nodes[to].dependents, from)
+			}
+		}
+	}
+
+	if _, err := g.order(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// order returns the indexes of the nodes sorted so that every node comes after
+// the nodes it depends on.  An error is returned if there is a dependency cycle
+func (g *graph) order() ([]int, error) {
+	remaining := make([]int, len(g.nodes))
+	for i, n := range g.nodes {
+		remaining[i] = len(n.dependsOn)
+	}
+
+	order := []int{}
+	done := make([]bool, len(g.nodes))
+	for len(order) < len(g.nodes) {
+		next := -1
+		for i := range g.nodes {
+			if !done[i] && remaining[i] == 0 {
+				next = i
+				break
+			}
+		}
+
+		if next < 0 {
+			cycle := []string{}
+			for i, n := range g.nodes {
+				if !done[i] {
+					cycle = append(cycle, fmt.Sprintf("%s %s", n.kind, n.component.GetName()))
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(cycle, ", "))
+		}
+
+		done[next] = true
+		order = append(order, next)
+		for _, j := range g.nodes[next].dependents {
+			remaining[j]--
+		}
+	}
+
+	return order, nil
+}
+
+type nodeResult struct {
+	index int
+	err   error
+}
+
+// deployGraph deploys the components of the graph, running independent components
+// in parallel.  Components whose dependencies failed are not deployed, and no more
+// components are started once the context is done
+func (d *Deployer) deployGraph(ctx context.Context, g *graph, res api.DeployerResources, tx *transaction) map[api.ComponentType][]error {
+	parallelism := d.parallelism
+	if parallelism <= 0 {
+		parallelism = defaultParallelism
+	}
+
+	allErrs := map[api.ComponentType][]error{}
+	remaining := make([]int, len(g.nodes))
+	queue := []int{}
+	for i, n := range g.nodes {
+		remaining[i] = len(n.dependsOn)
+		if remaining[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	done := make([]bool, len(g.nodes))
+	skipped := make([]bool, len(g.nodes))
+	var skip func(int)
+	skip = func(failed int) {
+		for _, j := range g.nodes[failed].dependents {
+			if skipped[j] {
+				continue
+			}
+			skipped[j] = true
+			n := g.nodes[j]
+			allErrs[n.kind] = append(allErrs[n.kind], fmt.Errorf("%s was not deployed because %s %s failed", n.component.GetName(), g.nodes[failed].kind, g.nodes[failed].component.GetName()))
+			skip(j)
+		}
+	}
+
+	results := make(chan nodeResult)
+	running := 0
+	for running > 0 || (len(queue) > 0 && ctx.Err() == nil) {
+		for running < parallelism && len(queue) > 0 && ctx.Err() == nil {
+			i := queue[0]
+			queue = queue[1:]
+			running++
+			go func(i int) {
+				results <- nodeResult{index: i, err: d.deployNode(ctx, g.nodes[i], res, tx)}
+			}(i)
+		}
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+		done[result.index] = true
+		n := g.nodes[result.index]
+		if result.err != nil {
+			allErrs[n.kind] = append(allErrs[n.kind], result.err)
+			skip(result.index)
+			continue
+		}
+
+		for _, j := range n.dependents {
+			remaining[j]--
+			if remaining[j] == 0 && !skipped[j] {
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		for i, n := range g.nodes {
+			if !done[i] && !skipped[i] {
+				allErrs[n.kind] = append(allErrs[n.kind], fmt.Errorf("%s was not deployed because the deploy was stopped: %v", n.component.GetName(), ctx.Err()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (d *Deployer) deployNode(ctx context.Context, n *graphNode, res api.DeployerResources, tx *transaction) error {
+	log.Infof("deploying %s %s", n.kind, n.component.GetName())
+	start := time.Now()
+	d.observers.componentStart(api.DeployPhaseDeploy, n.kind, n.component.GetName())
+	err := d.deployAndWait(ctx, n, res, tx)
+	d.observers.componentDone(api.DeployPhaseDeploy, n.kind, n.component.GetName(), start, err)
+	return err
+}
+
+// deployAndWait deploys the component and waits for it to be ready if a wait
+// configuration was set
+func (d *Deployer) deployAndWait(ctx context.Context, n *graphNode, res api.DeployerResources, tx *transaction) error {
+	err := d.runComponent(ctx, "deploy", n.kind, n.component, func(ctx context.Context) error {
+		return deployComponent(ctx, n.kind, n.component, res, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	if d.waitConfig != nil {
+		timeout, interval := waitSettings(n.kind, *d.waitConfig)
+		log.Infof("waiting for %s %s to be ready", n.kind, n.component.GetName())
+		return waitForComponent(ctx, n.component, res, timeout, interval)
+	}
+	return nil
+}
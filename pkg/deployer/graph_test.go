@@ -0,0 +1,206 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type recordingComponent struct {
+	*v1.ConfigMap
+	err        error
+	lock       *sync.Mutex
+	deployed   *[]string
+	undeployed *[]string
+}
+
+func (r *recordingComponent) Deploy(context.Context, api.DeployerResources) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	*r.deployed = append(*r.deployed, r.Name)
+	return r.err
+}
+
+func (r *recordingComponent) Undeploy(context.Context, api.DeployerResources) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	*r.undeployed = append(*r.undeployed, r.Name)
+	return nil
+}
+
+func newRecordingComponents(names ...string) (map[string]*recordingComponent, *[]string) {
+	lock := &sync.Mutex{}
+	deployed := []string{}
+	undeployed := []string{}
+	comps := map[string]*recordingComponent{}
+	for _, n := range names {
+		comps[n] = &recordingComponent{
+			ConfigMap:  &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: n}},
+			lock:       lock,
+			deployed:   &deployed,
+			undeployed: &undeployed,
+		}
+	}
+	return comps, &deployed
+}
+
+func nodeNames(g *graph, indexes []int) []string {
+	names := []string{}
+	for _, i := range indexes {
+		names = append(names, g.nodes[i].component.GetName())
+	}
+	return names
+}
+
+func TestBuildGraphInferredDependencies(t *testing.T) {
+	ns := components.NewNamespace(api.NamespaceConfig{Name: "ns"})
+	cm := components.NewConfigMap(api.ConfigMapConfig{Name: "cfg", Namespace: "ns"})
+	sa := components.NewServiceAccount(api.ServiceAccountConfig{Name: "sa", Namespace: "ns"})
+	rb := components.NewRoleBinding(api.RoleBindingConfig{Name: "rb", Namespace: "ns"})
+	rb.AddSubject(api.SubjectConfig{Kind: "ServiceAccount", Name: "sa", Namespace: "ns"})
+	pod := components.NewPod(api.PodConfig{Name: "pod", Namespace: "ns", ServiceAccount: "sa"})
+	pod.AddVolume(components.NewConfigMapVolume(api.ConfigMapOrSecretVolumeConfig{VolumeName: "cfg", MapOrSecretName: "cfg"}))
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.PodComponent, pod)
+	d.AddComponent(api.RoleBindingComponent, rb)
+	d.AddComponent(api.ServiceAccountComponent, sa)
+	d.AddComponent(api.ConfigMapComponent, cm)
+	d.AddComponent(api.NamespaceComponent, ns)
+
+	g, err := d.buildGraph()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var podNode *graphNode
+	for _, n := range g.nodes {
+		if n.component == pod {
+			podNode = n
+		}
+	}
+	deps := strings.Join(nodeNames(g, podNode.dependsOn), ",")
+	for _, expected := range []string{"ns", "cfg", "sa", "rb"} {
+		if !strings.Contains(deps, expected) {
+			t.Errorf("expected pod to depend on %s, got %s", expected, deps)
+		}
+	}
+
+	order, err := g.order()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := nodeNames(g, order)
+	if names[0] != "ns" || names[len(names)-1] != "pod" {
+		t.Errorf("unexpected order %v", names)
+	}
+}
+
+func TestBuildGraphGoverningService(t *testing.T) {
+	svc := components.NewService(api.ServiceConfig{Name: "db", Namespace: "ns", ClusterIP: "None"})
+	ss := components.NewStatefulSet(api.StatefulSetConfig{Name: "db", Namespace: "ns", Service: "db"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ServiceComponent, svc)
+	d.AddComponent(api.StatefulSetComponent, ss)
+
+	g, err := d.buildGraph()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, n := range g.nodes {
+		if len(n.dependsOn) > 0 {
+			t.Errorf("expected %s %s not to depend on %v", n.kind, n.component.GetName(), nodeNames(g, n.dependsOn))
+		}
+	}
+}
+
+func TestBuildGraphCycle(t *testing.T) {
+	comps, _ := newRecordingComponents("a", "b")
+	d := NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, comps["a"])
+	d.AddComponent(api.ConfigMapComponent, comps["b"])
+	d.AddDependency(comps["a"], comps["b"])
+	d.AddDependency(comps["b"], comps["a"])
+
+	_, err := d.buildGraph()
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("expected dependency cycle error, got %v", err)
+	}
+}
+
+func TestDeployGraph(t *testing.T) {
+	var tests = []struct {
+		description  string
+		failing      string
+		expected     []string
+		expectedErrs int
+	}{
+		{
+			description:  "all components deployed in order",
+			expected:     []string{"a", "b", "c"},
+			expectedErrs: 0,
+		},
+		{
+			description:  "dependents of a failed component are skipped",
+			failing:      "b",
+			expected:     []string{"a", "b"},
+			expectedErrs: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		comps, deployed := newRecordingComponents("a", "b", "c")
+		if len(tc.failing) > 0 {
+			comps[tc.failing].err = fmt.Errorf("failed")
+		}
+
+		d := NewDeployerExporter()
+		for _, n := range []string{"c", "b", "a"} {
+			d.AddComponent(api.ConfigMapComponent, comps[n])
+		}
+		d.AddDependency(comps["c"], comps["b"])
+		d.AddDependency(comps["b"], comps["a"])
+
+		g, err := d.buildGraph()
+		if err != nil {
+			t.Fatalf("[%s] unexpected error: %v", tc.description, err)
+		}
+		errs := d.deployGraph(context.Background(), g, api.DeployerResources{}, nil)
+		if len(errs[api.ConfigMapComponent]) != tc.expectedErrs {
+			t.Errorf("[%s] expected %d errors, got %v", tc.description, tc.expectedErrs, errs)
+		}
+		if strings.Join(*deployed, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("[%s] expected %v to be deployed, got %v", tc.description, tc.expected, *deployed)
+		}
+	}
+}
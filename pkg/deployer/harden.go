@@ -0,0 +1,87 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/policy"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SetHardened makes Run harden the components, as done by Harden, before
+// deploying them
+func (d *Deployer) SetHardened(hardened bool) {
+	d.hardened = hardened
+}
+
+// Harden rewrites the pods of all components to the restricted security
+// profile of policy.Harden.  Pods keep the token of their service account only
+// if the service account is one of the components and is bound to a role.
+// Components exempted from hardening are logged with their justification
+func (d *Deployer) Harden() error {
+	bound := d.boundServiceAccounts()
+	allErrs := map[api.ComponentType][]error{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			spec := components.GetPodSpec(c)
+			if spec == nil {
+				continue
+			}
+			account := spec.ServiceAccountName
+			if len(account) == 0 {
+				account = "default"
+			}
+			justification, err := policy.Harden(c, bound[componentKey(api.ServiceAccountComponent, namespaceOf(c), account)])
+			if err != nil {
+				allErrs[ct] = append(allErrs[ct], fmt.Errorf("%s: %v", c.GetName(), err))
+			} else if len(justification) > 0 {
+				log.Infof("%s %s is exempt from hardening: %s", ct, c.GetName(), justification)
+			}
+		}
+	}
+	return utilserror.NewDeployErrors(allErrs)
+}
+
+// boundServiceAccounts returns the keys of the service accounts of the
+// deployer that are subjects of its role bindings
+func (d *Deployer) boundServiceAccounts() map[string]bool {
+	accounts := map[string]bool{}
+	for _, c := range d.components[api.ServiceAccountComponent] {
+		accounts[keyOf(api.ServiceAccountComponent, c)] = false
+	}
+	for _, ct := range []api.ComponentType{api.RoleBindingComponent, api.ClusterRoleBindingComponent} {
+		for _, c := range d.components[ct] {
+			for _, ref := range components.GetReferences(c) {
+				key := componentKey(ref.Kind, ref.Namespace, ref.Name)
+				if _, ok := accounts[key]; ok && ref.Kind == api.ServiceAccountComponent {
+					accounts[key] = true
+				}
+			}
+		}
+	}
+	return accounts
+}
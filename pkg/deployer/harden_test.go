@@ -0,0 +1,115 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/policy"
+)
+
+func newHardeningPod(name string, account string) *components.Pod {
+	c, _ := components.NewContainer(api.ContainerConfig{Name: name, Image: name + ":1.0"})
+	pod := components.NewPod(api.PodConfig{Name: name, Namespace: "shop", ServiceAccount: account})
+	pod.AddContainer(c)
+	return pod
+}
+
+func TestHarden(t *testing.T) {
+	operator := newHardeningPod("operator", "operator")
+	web := newHardeningPod("web", "web")
+	worker := newHardeningPod("worker", "")
+	rb := components.NewRoleBinding(api.RoleBindingConfig{Name: "operator", Namespace: "shop"})
+	rb.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"})
+	rb.AddSubject(api.SubjectConfig{Kind: "ServiceAccount", Name: "operator", Namespace: "shop"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ServiceAccountComponent, components.NewServiceAccount(api.ServiceAccountConfig{Name: "operator", Namespace: "shop"}))
+	d.AddComponent(api.ServiceAccountComponent, components.NewServiceAccount(api.ServiceAccountConfig{Name: "web", Namespace: "shop"}))
+	d.AddComponent(api.RoleBindingComponent, rb)
+	d.AddComponent(api.PodComponent, operator)
+	d.AddComponent(api.PodComponent, web)
+	d.AddComponent(api.PodComponent, worker)
+	if err := d.Harden(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if operator.Spec.AutomountServiceAccountToken != nil {
+		t.Errorf("expected the operator to mount the token of its bound service account")
+	}
+	for _, pod := range []*components.Pod{web, worker} {
+		if pod.Spec.AutomountServiceAccountToken == nil || *pod.Spec.AutomountServiceAccountToken {
+			t.Errorf("expected %s not to mount the token of its service account", pod.Name)
+		}
+	}
+	for _, pod := range []*components.Pod{operator, web, worker} {
+		if sc := pod.Spec.Containers[0].SecurityContext; sc == nil || !*sc.ReadOnlyRootFilesystem {
+			t.Errorf("expected %s to be hardened", pod.Name)
+		}
+	}
+}
+
+func TestRunHardened(t *testing.T) {
+	pod := newHardeningPod("web", "")
+	pod.AddAnnotations(map[string]string{policy.HardeningExemptionAnnotation: ""})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.PodComponent, pod)
+	d.SetHardened(true)
+	if err := d.Run(); err == nil || !strings.Contains(err.Error(), "web: the horizon.blackducksoftware.com/hardening-exemption annotation requires a justification") {
+		t.Errorf("expected Run to fail hardening, got %v", err)
+	}
+}
+
+func TestPrepareCopiesComponents(t *testing.T) {
+	pod := newHardeningPod("web", "")
+	cm := components.NewConfigMap(api.ConfigMapConfig{Name: "web", Namespace: "shop"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, cm)
+	d.AddComponent(api.PodComponent, pod)
+	d.AddDependency(pod, cm)
+	d.SetHardened(true)
+	d.SetInventoryConfig(api.InventoryConfig{AppName: "shop", Instance: "test"})
+
+	p, err := d.prepare()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pod.Spec.Containers[0].SecurityContext != nil || len(pod.Labels) > 0 || len(cm.Labels) > 0 {
+		t.Errorf("expected the components of the deployer to be unchanged")
+	}
+	prepared := p.components[api.PodComponent][0].(*components.Pod)
+	if sc := prepared.Spec.Containers[0].SecurityContext; sc == nil || !*sc.ReadOnlyRootFilesystem {
+		t.Errorf("expected the copy of the pod to be hardened")
+	}
+	if prepared.Labels[AppLabel] != "shop" {
+		t.Errorf("expected the copy of the pod to have the ownership labels, got %v", prepared.Labels)
+	}
+	if len(p.dependencies) != 1 || p.dependencies[0].component != prepared || p.dependencies[0].dependsOn != p.components[api.ConfigMapComponent][0] {
+		t.Errorf("expected the dependencies to be between the copies, got %v", p.dependencies)
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"sigs.k8s.io/yaml"
+)
+
+const defaultChartVersion = "0.1.0"
+
+// helmValueSections defines the values.yaml section holding the lifted
+// fields of each component type
+var helmValueSections = map[api.ComponentType]string{
+	api.DeploymentComponent:            "deployments",
+	api.StatefulSetComponent:           "statefulSets",
+	api.DaemonSetComponent:             "daemonSets",
+	api.ReplicationControllerComponent: "replicationControllers",
+	api.JobComponent:                   "jobs",
+	api.PodComponent:                   "pods",
+	api.ServiceComponent:               "services",
+	api.IngressComponent:               "ingresses",
+}
+
+// helmChartMetadata defines the content of Chart.yaml
+type helmChartMetadata struct {
+	APIVersion  string `json:"apiVersion"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ExportHelmChart writes the components as a Helm chart in the given directory.
+// The fields selected in the config are moved to values.yaml and replaced by
+// references to the values in the templates
+func (d *Deployer) ExportHelmChart(dir string, config api.HelmChartConfig) error {
+	if len(config.Name) == 0 {
+		return fmt.Errorf("a chart name is required")
+	}
+
+	objs, err := d.ExportObjects()
+	if err != nil {
+		return err
+	}
+
+	chart := newHelmChart(config)
+	files := map[string][]byte{}
+	for i, obj := range objs {
+		data, err := chart.template(obj)
+		if err != nil {
+			return fmt.Errorf("unable to export %s %s to the chart: %v", obj.Kind, obj.Name, err)
+		}
+		files[filepath.Join("templates", exportFileName(i, obj))] = data
+	}
+
+	version := config.Version
+	if len(version) == 0 {
+		version = defaultChartVersion
+	}
+	metadata, err := yaml.Marshal(helmChartMetadata{
+		APIVersion:  "v1",
+		Name:        config.Name,
+		Version:     version,
+		AppVersion:  config.AppVersion,
+		Description: config.Description,
+	})
+	if err != nil {
+		return err
+	}
+	files["Chart.yaml"] = metadata
+
+	values, err := yaml.Marshal(chart.values)
+	if err != nil {
+		return err
+	}
+	files["values.yaml"] = values
+
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// helmChart collects the values lifted from the templates
+type helmChart struct {
+	lift   map[api.HelmValueType]bool
+	values map[string]interface{}
+}
+
+func newHelmChart(config api.HelmChartConfig) *helmChart {
+	lift := map[api.HelmValueType]bool{}
+	for _, v := range config.Values {
+		lift[v] = true
+	}
+	return &helmChart{lift: lift, values: map[string]interface{}{}}
+}
+
+// helmTemplate replaces the lifted fields of a single component by placeholders
+// that are turned into template references once the component is rendered
+type helmTemplate struct {
+	chart *helmChart
+	path  []string
+	refs  map[string]string
+}
+
+// template renders a component as a chart template
+func (c *helmChart) template(obj ExportedObject) ([]byte, error) {
+	content := map[string]interface{}{}
+	if err := yaml.Unmarshal(obj.Data, &content); err != nil {
+		return nil, err
+	}
+
+	t := &helmTemplate{chart: c, path: []string{helmValueSections[obj.Kind], obj.Name}, refs: map[string]string{}}
+	switch obj.Kind {
+	case api.DeploymentComponent, api.StatefulSetComponent, api.ReplicationControllerComponent:
+		if c.lift[api.HelmValueReplicas] {
+			if spec, ok := content["spec"].(map[string]interface{}); ok {
+				if replicas, ok := spec["replicas"]; ok {
+					spec["replicas"] = t.ref(replicas, false, "replicas")
+				}
+			}
+		}
+		t.liftPodSpec(nestedMap(content, "spec", "template", "spec"))
+	case api.DaemonSetComponent, api.JobComponent:
+		t.liftPodSpec(nestedMap(content, "spec", "template", "spec"))
+	case api.PodComponent:
+		t.liftPodSpec(nestedMap(content, "spec"))
+	case api.ServiceComponent:
+		if spec := nestedMap(content, "spec"); spec != nil && c.lift[api.HelmValueServiceType] {
+			serviceType, ok := spec["type"]
+			if !ok {
+				serviceType = "ClusterIP"
+			}
+			spec["type"] = t.ref(serviceType, true, "type")
+		}
+	case api.IngressComponent:
+		if c.lift[api.HelmValueIngressHost] {
+			t.liftIngressHosts(nestedMap(content, "spec"))
+		}
+	}
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// escape the existing template delimiters so that Helm renders them as is
+	rendered := strings.Replace(string(data), "{{", `{{ "{{" }}`, -1)
+	for placeholder, ref := range t.refs {
+		rendered = strings.Replace(rendered, placeholder, ref, -1)
+	}
+	return []byte(rendered), nil
+}
+
+// liftPodSpec lifts the images and resources of the containers of a pod spec
+func (t *helmTemplate) liftPodSpec(spec map[string]interface{}) {
+	if spec == nil {
+		return
+	}
+
+	for _, key := range []string{"initContainers", "containers"} {
+		containers, _ := spec[key].([]interface{})
+		for _, item := range containers {
+			container, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := container["name"].(string)
+			if image, ok := container["image"]; ok && t.chart.lift[api.HelmValueImage] {
+				container["image"] = t.ref(image, true, "containers", name, "image")
+			}
+			if resources, ok := container["resources"].(map[string]interface{}); ok && t.chart.lift[api.HelmValueResources] {
+				for _, kind := range sortedKeys(resources) {
+					quantities, ok := resources[kind].(map[string]interface{})
+					if !ok {
+						continue
+					}
+					for _, resource := range sortedKeys(quantities) {
+						quantities[resource] = t.ref(quantities[resource], true, "containers", name, "resources", kind, resource)
+					}
+				}
+			}
+		}
+	}
+}
+
+// liftIngressHosts lifts the hosts of the ingress rules into a list of hosts
+func (t *helmTemplate) liftIngressHosts(spec map[string]interface{}) {
+	if spec == nil {
+		return
+	}
+
+	rules, _ := spec["rules"].([]interface{})
+	hosts := []interface{}{}
+	path := t.valuePath("hosts")
+	for _, item := range rules {
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		host, ok := rule["host"]
+		if !ok {
+			continue
+		}
+		placeholder := t.placeholder()
+		t.refs[placeholder] = fmt.Sprintf("{{ index .Values %s %d | quote }}", quoteAll(path), len(hosts))
+		rule["host"] = placeholder
+		hosts = append(hosts, host)
+	}
+	if len(hosts) > 0 {
+		t.chart.set(path, hosts)
+	}
+}
+
+// ref records the value under the given path and returns the placeholder
+// to use in its place
+func (t *helmTemplate) ref(value interface{}, quote bool, path ...string) string {
+	full := t.valuePath(path...)
+	t.chart.set(full, value)
+
+	ref := fmt.Sprintf("index .Values %s", quoteAll(full))
+	if quote {
+		ref = ref + " | quote"
+	}
+
+	placeholder := t.placeholder()
+	t.refs[placeholder] = fmt.Sprintf("{{ %s }}", ref)
+	return placeholder
+}
+
+// valuePath returns the path of a value of the component
+func (t *helmTemplate) valuePath(path ...string) []string {
+	return append(append([]string{}, t.path...), path...)
+}
+
+func (t *helmTemplate) placeholder() string {
+	return fmt.Sprintf("horizon-helm-value-%d-", len(t.refs))
+}
+
+// set stores a value in the chart values, creating the intermediate sections
+func (c *helmChart) set(path []string, value interface{}) {
+	values := c.values
+	for _, key := range path[:len(path)-1] {
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			values[key] = next
+		}
+		values = next
+	}
+	values[path[len(path)-1]] = value
+}
+
+func nestedMap(content map[string]interface{}, path ...string) map[string]interface{} {
+	for _, key := range path {
+		next, ok := content[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		content = next
+	}
+	return content
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func quoteAll(keys []string) string {
+	quoted := []string{}
+	for _, k := range keys {
+		quoted = append(quoted, strconv.Quote(k))
+	}
+	return strings.Join(quoted, " ")
+}
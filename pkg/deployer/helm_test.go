@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"sigs.k8s.io/yaml"
+)
+
+func newHelmDeployer(t *testing.T) *Deployer {
+	replicas := int32(2)
+	deployment := components.NewDeployment(api.DeploymentConfig{Name: "app", Namespace: "ns", Replicas: &replicas})
+	pod := components.NewPod(api.PodConfig{Name: "web"})
+	container, err := components.NewContainer(api.ContainerConfig{Name: "nginx", Image: "nginx:1.15", MinCPU: "100m", MaxMem: "1Gi"})
+	if err != nil {
+		t.Fatalf("unable to create container: %v", err)
+	}
+	pod.AddContainer(container)
+	deployment.AddPod(pod)
+
+	ingress, err := components.NewIngress(api.IngressConfig{Name: "web", Namespace: "ns", ServiceName: "web", ServicePort: "80"})
+	if err != nil {
+		t.Fatalf("unable to create ingress: %v", err)
+	}
+	ingress.AddHostRule(api.IngressHostRuleConfig{Host: "web.example.com"})
+
+	configMap := components.NewConfigMap(api.ConfigMapConfig{Name: "web", Namespace: "ns"})
+	configMap.AddData(map[string]string{"template": "{{ .Name }}"})
+
+	d := newExportDeployer()
+	d.AddComponent(api.DeploymentComponent, deployment)
+	d.AddComponent(api.IngressComponent, ingress)
+	d.AddComponent(api.ConfigMapComponent, configMap)
+	return d
+}
+
+// renderChart renders the templates of a chart with its default values the way Helm would
+func renderChart(t *testing.T, dir string) []string {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "values.yaml"))
+	if err != nil {
+		t.Fatalf("unable to read the values: %v", err)
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		t.Fatalf("unable to parse the values: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "templates", "*.yaml"))
+	if err != nil {
+		t.Fatalf("unable to list the templates: %v", err)
+	}
+	rendered := []string{}
+	for _, file := range files {
+		funcs := template.FuncMap{"quote": func(v interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(v)) }}
+		tmpl, err := template.New(filepath.Base(file)).Funcs(funcs).ParseFiles(file)
+		if err != nil {
+			t.Fatalf("unable to parse %s: %v", file, err)
+		}
+		buf := &bytes.Buffer{}
+		if err := tmpl.Execute(buf, map[string]interface{}{"Values": values}); err != nil {
+			t.Fatalf("unable to render %s: %v", file, err)
+		}
+		rendered = append(rendered, buf.String())
+	}
+	return rendered
+}
+
+func TestExportHelmChart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chart")
+	if err != nil {
+		t.Fatalf("unable to create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := newHelmDeployer(t)
+	config := api.HelmChartConfig{
+		Name:       "web",
+		AppVersion: "1.15",
+		Values:     []api.HelmValueType{api.HelmValueImage, api.HelmValueReplicas, api.HelmValueResources, api.HelmValueServiceType, api.HelmValueIngressHost},
+	}
+	if err := d.ExportHelmChart(dir, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chart, err := ioutil.ReadFile(filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		t.Fatalf("unable to read the chart: %v", err)
+	}
+	for _, expected := range []string{"apiVersion: v1", "name: web", "version: 0.1.0", "appVersion: \"1.15\""} {
+		if !strings.Contains(string(chart), expected) {
+			t.Errorf("expected Chart.yaml to contain %q, got %s", expected, chart)
+		}
+	}
+
+	values, err := ioutil.ReadFile(filepath.Join(dir, "values.yaml"))
+	if err != nil {
+		t.Fatalf("unable to read the values: %v", err)
+	}
+	for _, expected := range []string{"image: nginx:1.15", "replicas: 2", "cpu: 100m", "memory: 1Gi", "type: ClusterIP", "- web.example.com"} {
+		if !strings.Contains(string(values), expected) {
+			t.Errorf("expected values.yaml to contain %q, got %s", expected, values)
+		}
+	}
+
+	templates, err := filepath.Glob(filepath.Join(dir, "templates", "*-deployment-app.yaml"))
+	if err != nil || len(templates) != 1 {
+		t.Fatalf("expected a template for the deployment, got %v: %v", templates, err)
+	}
+	deployment, err := ioutil.ReadFile(templates[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{`replicas: {{ index .Values "deployments" "app" "replicas" }}`, `image: {{ index .Values "deployments" "app" "containers" "nginx" "image" | quote }}`} {
+		if !strings.Contains(string(deployment), expected) {
+			t.Errorf("expected the deployment template to contain %q, got %s", expected, deployment)
+		}
+	}
+
+	objs, err := d.ExportObjects()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rendered := renderChart(t, dir)
+	if len(rendered) != len(objs) {
+		t.Fatalf("expected %d templates, got %d", len(objs), len(rendered))
+	}
+	for i, obj := range objs {
+		expected, actual := map[string]interface{}{}, map[string]interface{}{}
+		if err := yaml.Unmarshal(obj.Data, &expected); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := yaml.Unmarshal([]byte(rendered[i]), &actual); err != nil {
+			t.Fatalf("unable to parse the rendered %s %s: %v\n%s", obj.Kind, obj.Name, err, rendered[i])
+		}
+		// the service type is added to the template with its default value
+		if obj.Kind == api.ServiceComponent {
+			expected["spec"].(map[string]interface{})["type"] = "ClusterIP"
+		}
+		if fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("expected the rendered %s %s to match the component\nexpected: %v\nactual:   %v", obj.Kind, obj.Name, expected, actual)
+		}
+	}
+
+	if err := d.ExportHelmChart(dir, api.HelmChartConfig{}); err == nil {
+		t.Errorf("expected an error exporting a chart without a name")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/components"
+)
+
+// Import reads a stream of YAML documents or JSON objects and adds every object
+// to the deployer as the component of its type.  Nothing is added if any object
+// can't be decoded
+func (d *Deployer) Import(r io.Reader) error {
+	decoded, err := components.DecodeComponents(r)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range decoded {
+		d.AddComponent(c.Kind, c.Component)
+	}
+	return nil
+}
+
+// ImportPath imports a manifest file, or all the .yaml, .yml and .json files
+// found under a directory in lexical order.  Nothing is added if any file can't
+// be decoded
+func (d *Deployer) ImportPath(path string) error {
+	files := []string{}
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if p == path || isManifest(p) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	decoded := []components.DecodedComponent{}
+	for _, file := range files {
+		comps, err := decodeFile(file)
+		if err != nil {
+			return err
+		}
+		decoded = append(decoded, comps...)
+	}
+
+	for _, c := range decoded {
+		d.AddComponent(c.Kind, c.Component)
+	}
+	return nil
+}
+
+func decodeFile(path string) ([]components.DecodedComponent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decoded, err := components.DecodeComponents(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return decoded, nil
+}
+
+func isManifest(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
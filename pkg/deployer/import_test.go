@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+)
+
+func TestImportExportedList(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := newExportDeployer().ExportJSONList(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := NewDeployerExporter()
+	if err := d.Import(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ct := range []api.ComponentType{api.NamespaceComponent, api.DeploymentComponent, api.ServiceComponent} {
+		if len(d.components[ct]) != 1 {
+			t.Errorf("expected a %s to be imported, got %v", ct, d.components)
+		}
+	}
+
+	deployment, ok := d.components[api.DeploymentComponent][0].(*components.Deployment)
+	if !ok {
+		t.Fatalf("expected a deployment component, got %T", d.components[api.DeploymentComponent][0])
+	}
+	deployment.AddLabels(map[string]string{"app": "web"})
+	if deployment.Labels["app"] != "web" || deployment.Namespace != "ns" {
+		t.Errorf("unexpected deployment %+v", deployment.ObjectMeta)
+	}
+}
+
+func TestImportPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import")
+	if err != nil {
+		t.Fatalf("unable to create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	exported := newExportDeployer()
+	if err := exported.ExportDirectory(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ioutil.WriteFile(dir+"/README.md", []byte("not a manifest"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := NewDeployerExporter()
+	if err := d.ImportPath(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected, _ := exported.ExportObjects()
+	imported, _ := d.ExportObjects()
+	if len(imported) != len(expected) {
+		t.Fatalf("expected %d objects, got %d", len(expected), len(imported))
+	}
+	for i := range expected {
+		if string(imported[i].Data) != string(expected[i].Data) {
+			t.Errorf("expected %s to be unchanged, got %s", expected[i].Data, imported[i].Data)
+		}
+	}
+
+	if err := ioutil.WriteFile(dir+"/zz-bad.yaml", []byte("kind: Unknown\napiVersion: v1\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d = NewDeployerExporter()
+	if err := d.ImportPath(dir); err == nil {
+		t.Errorf("expected an error importing an unknown kind")
+	}
+	if d.componentCount() != 0 {
+		t.Errorf("expected nothing to be imported, got %v", d.components)
+	}
+}
@@ -0,0 +1,296 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kustomizationFile       = "kustomization.yaml"
+	kustomizationAPIVersion = "kustomize.config.k8s.io/v1beta1"
+)
+
+// kustomization defines the content of kustomization.yaml
+type kustomization struct {
+	APIVersion            string               `json:"apiVersion"`
+	Kind                  string               `json:"kind"`
+	Resources             []string             `json:"resources,omitempty"`
+	PatchesStrategicMerge []string             `json:"patchesStrategicMerge,omitempty"`
+	PatchesJSON6902       []kustomizationPatch `json:"patchesJson6902,omitempty"`
+}
+
+// kustomizationPatch defines a JSON patch applied to a resource of the base
+type kustomizationPatch struct {
+	Target kustomizationTarget `json:"target"`
+	Path   string              `json:"path"`
+}
+
+type kustomizationTarget struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// jsonPatchOperation defines a RFC 6902 JSON patch operation.  The value is kept
+// encoded so that add and replace operations always have one, even when it is
+// null, zero or empty, while remove operations have none
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func newJSONPatchOperation(op string, path string, value interface{}) (jsonPatchOperation, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return jsonPatchOperation{}, fmt.Errorf("unable to encode the value of %s: %v", path, err)
+	}
+	return jsonPatchOperation{Op: op, Path: path, Value: data}, nil
+}
+
+// kustomizeObject is an exported component along with its decoded content
+type kustomizeObject struct {
+	ExportedObject
+	content map[string]interface{}
+}
+
+// ExportKustomizeBase writes the components as a kustomize base: one file
+// per component and a kustomization.yaml listing them in deploy order
+func (d *Deployer) ExportKustomizeBase(dir string) error {
+	objs, err := d.ExportObjects()
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{}
+	k := newKustomization()
+	for i, obj := range objs {
+		name := exportFileName(i, obj)
+		k.Resources = append(k.Resources, name)
+		files[name] = obj.Data
+	}
+
+	return writeKustomization(dir, k, files)
+}
+
+// ExportKustomizeOverlay writes a kustomize overlay that turns the components
+// of this deployer, exported as a base in the base directory, into the
+// components of the overlay deployer.  The base path is written as is in the
+// overlay, and should be relative to dir.  Changed components become JSON
+// patches, new components become resources of the overlay and removed
+// components are deleted with a strategic merge patch
+func (d *Deployer) ExportKustomizeOverlay(dir string, base string, overlay *Deployer) error {
+	baseObjs, err := d.kustomizeObjects()
+	if err != nil {
+		return err
+	}
+	overlayObjs, err := overlay.kustomizeObjects()
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{}
+	k := newKustomization()
+	k.Resources = append(k.Resources, base)
+
+	baseKeys := map[string]kustomizeObject{}
+	for _, obj := range baseObjs {
+		baseKeys[componentKey(obj.Kind, obj.Namespace, obj.Name)] = obj
+	}
+	overlayKeys := map[string]bool{}
+	for i, obj := range overlayObjs {
+		key := componentKey(obj.Kind, obj.Namespace, obj.Name)
+		overlayKeys[key] = true
+
+		previous, ok := baseKeys[key]
+		if !ok {
+			name := exportFileName(i, obj.ExportedObject)
+			k.Resources = append(k.Resources, name)
+			files[name] = obj.Data
+			continue
+		}
+
+		ops, err := jsonPatch("", previous.content, obj.content)
+		if err != nil {
+			return fmt.Errorf("unable to export the patch of %s %s: %v", obj.Kind, obj.Name, err)
+		}
+		if len(ops) == 0 {
+			continue
+		}
+		data, err := yaml.Marshal(ops)
+		if err != nil {
+			return fmt.Errorf("unable to export the patch of %s %s: %v", obj.Kind, obj.Name, err)
+		}
+		name := "patch-" + kustomizeFileName(obj.ExportedObject)
+		k.PatchesJSON6902 = append(k.PatchesJSON6902, kustomizationPatch{Target: obj.target(), Path: name})
+		files[name] = data
+	}
+
+	for _, obj := range baseObjs {
+		if overlayKeys[componentKey(obj.Kind, obj.Namespace, obj.Name)] {
+			continue
+		}
+		metadata := map[string]interface{}{"name": obj.Name}
+		if len(obj.Namespace) > 0 {
+			metadata["namespace"] = obj.Namespace
+		}
+		data, err := yaml.Marshal(map[string]interface{}{
+			"apiVersion": obj.content["apiVersion"],
+			"kind":       obj.content["kind"],
+			"metadata":   metadata,
+			"$patch":     "delete",
+		})
+		if err != nil {
+			return fmt.Errorf("unable to export the removal of %s %s: %v", obj.Kind, obj.Name, err)
+		}
+		name := "delete-" + kustomizeFileName(obj.ExportedObject)
+		k.PatchesStrategicMerge = append(k.PatchesStrategicMerge, name)
+		files[name] = data
+	}
+
+	return writeKustomization(dir, k, files)
+}
+
+func newKustomization() *kustomization {
+	return &kustomization{APIVersion: kustomizationAPIVersion, Kind: "Kustomization"}
+}
+
+// kustomizeObjects exports the components and decodes them so they can be compared
+func (d *Deployer) kustomizeObjects() ([]kustomizeObject, error) {
+	objs, err := d.ExportObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := []kustomizeObject{}
+	for _, obj := range objs {
+		content := map[string]interface{}{}
+		if err := yaml.Unmarshal(obj.Data, &content); err != nil {
+			return nil, fmt.Errorf("unable to decode %s %s: %v", obj.Kind, obj.Name, err)
+		}
+		decoded = append(decoded, kustomizeObject{ExportedObject: obj, content: content})
+	}
+	return decoded, nil
+}
+
+// target returns the kustomize patch target matching the object
+func (obj kustomizeObject) target() kustomizationTarget {
+	apiVersion, _ := obj.content["apiVersion"].(string)
+	kind, _ := obj.content["kind"].(string)
+	target := kustomizationTarget{Version: apiVersion, Kind: kind, Name: obj.Name, Namespace: obj.Namespace}
+	if i := strings.Index(apiVersion, "/"); i >= 0 {
+		target.Group = apiVersion[:i]
+		target.Version = apiVersion[i+1:]
+	}
+	return target
+}
+
+func kustomizeFileName(obj ExportedObject) string {
+	if len(obj.Namespace) == 0 {
+		return fmt.Sprintf("%s-%s.yaml", strings.ToLower(string(obj.Kind)), obj.Name)
+	}
+	return fmt.Sprintf("%s-%s-%s.yaml", strings.ToLower(string(obj.Kind)), obj.Namespace, obj.Name)
+}
+
+// jsonPatch returns the operations turning from into to.  Maps are compared key
+// by key, any other value, including lists, is replaced when it changed
+func jsonPatch(path string, from interface{}, to interface{}) ([]jsonPatchOperation, error) {
+	fromMap, fromOk := from.(map[string]interface{})
+	toMap, toOk := to.(map[string]interface{})
+	if !fromOk || !toOk {
+		if reflect.DeepEqual(from, to) {
+			return nil, nil
+		}
+		op, err := newJSONPatchOperation("replace", path, to)
+		if err != nil {
+			return nil, err
+		}
+		return []jsonPatchOperation{op}, nil
+	}
+
+	keys := map[string]bool{}
+	for k := range fromMap {
+		keys[k] = true
+	}
+	for k := range toMap {
+		keys[k] = true
+	}
+	sorted := []string{}
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	ops := []jsonPatchOperation{}
+	for _, k := range sorted {
+		childPath := path + "/" + strings.Replace(strings.Replace(k, "~", "~0", -1), "/", "~1", -1)
+		fromValue, inFrom := fromMap[k]
+		toValue, inTo := toMap[k]
+		switch {
+		case !inTo:
+			ops = append(ops, jsonPatchOperation{Op: "remove", Path: childPath})
+		case !inFrom:
+			op, err := newJSONPatchOperation("add", childPath, toValue)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, op)
+		default:
+			childOps, err := jsonPatch(childPath, fromValue, toValue)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, childOps...)
+		}
+	}
+	return ops, nil
+}
+
+func writeKustomization(dir string, k *kustomization, files map[string][]byte) error {
+	data, err := yaml.Marshal(k)
+	if err != nil {
+		return err
+	}
+	files[kustomizationFile] = data
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"sigs.k8s.io/yaml"
+)
+
+func readKustomization(t *testing.T, dir string) kustomization {
+	data, err := ioutil.ReadFile(filepath.Join(dir, kustomizationFile))
+	if err != nil {
+		t.Fatalf("unable to read the kustomization: %v", err)
+	}
+	k := kustomization{}
+	if err := yaml.Unmarshal(data, &k); err != nil {
+		t.Fatalf("unable to parse the kustomization: %v", err)
+	}
+	return k
+}
+
+// applyJSONPatch applies the add, remove and replace operations to a decoded object
+func applyJSONPatch(t *testing.T, content map[string]interface{}, ops []jsonPatchOperation) {
+	for _, op := range ops {
+		keys := strings.Split(op.Path, "/")[1:]
+		parent := content
+		for _, k := range keys[:len(keys)-1] {
+			parent = parent[k].(map[string]interface{})
+		}
+		last := strings.Replace(strings.Replace(keys[len(keys)-1], "~1", "/", -1), "~0", "~", -1)
+		switch op.Op {
+		case "add", "replace":
+			var value interface{}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				t.Fatalf("unable to decode the value of %s: %v", op.Path, err)
+			}
+			parent[last] = value
+		case "remove":
+			delete(parent, last)
+		default:
+			t.Fatalf("unexpected operation %s", op.Op)
+		}
+	}
+}
+
+func TestExportKustomizeBase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kustomize")
+	if err != nil {
+		t.Fatalf("unable to create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := newExportDeployer().ExportKustomizeBase(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	k := readKustomization(t, dir)
+	expected := []string{"000-namespace-ns.yaml", "001-deployment-web.yaml", "002-service-web.yaml"}
+	if !reflect.DeepEqual(k.Resources, expected) {
+		t.Errorf("expected resources %v, got %v", expected, k.Resources)
+	}
+	for _, name := range expected {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+}
+
+func TestExportKustomizeOverlay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kustomize")
+	if err != nil {
+		t.Fatalf("unable to create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	base := newExportDeployer()
+	replicas := int32(3)
+	overlay := NewDeployerExporter()
+	overlay.AddComponent(api.NamespaceComponent, components.NewNamespace(api.NamespaceConfig{Name: "ns"}))
+	overlay.AddComponent(api.DeploymentComponent, components.NewDeployment(api.DeploymentConfig{Name: "web", Namespace: "ns", Replicas: &replicas}))
+	overlay.AddComponent(api.ConfigMapComponent, components.NewConfigMap(api.ConfigMapConfig{Name: "config", Namespace: "ns"}))
+
+	if err := base.ExportKustomizeOverlay(dir, "../base", overlay); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	k := readKustomization(t, dir)
+	if !reflect.DeepEqual(k.Resources, []string{"../base", "001-configmap-config.yaml"}) {
+		t.Errorf("expected the base and the new config map as resources, got %v", k.Resources)
+	}
+	if !reflect.DeepEqual(k.PatchesStrategicMerge, []string{"delete-service-ns-web.yaml"}) {
+		t.Errorf("expected the service to be deleted, got %v", k.PatchesStrategicMerge)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "delete-service-ns-web.yaml"))
+	if err != nil || !strings.Contains(string(data), "$patch: delete") || !strings.Contains(string(data), "kind: Service") {
+		t.Errorf("expected a delete patch for the service, got %s: %v", data, err)
+	}
+
+	if len(k.PatchesJSON6902) != 1 {
+		t.Fatalf("expected a single JSON patch, got %v", k.PatchesJSON6902)
+	}
+	patch := k.PatchesJSON6902[0]
+	expectedTarget := kustomizationTarget{Group: "apps", Version: "v1", Kind: "Deployment", Name: "web", Namespace: "ns"}
+	if patch.Target != expectedTarget {
+		t.Errorf("expected target %+v, got %+v", expectedTarget, patch.Target)
+	}
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, patch.Path))
+	if err != nil {
+		t.Fatalf("unable to read the patch: %v", err)
+	}
+	ops := []jsonPatchOperation{}
+	if err := yaml.Unmarshal(data, &ops); err != nil {
+		t.Fatalf("unable to parse the patch: %v", err)
+	}
+
+	baseObjs, _ := base.kustomizeObjects()
+	overlayObjs, _ := overlay.kustomizeObjects()
+	patched := baseObjs[1].content
+	applyJSONPatch(t, patched, ops)
+	if !reflect.DeepEqual(patched, overlayObjs[2].content) {
+		t.Errorf("expected the patched deployment to match the overlay\nexpected: %v\nactual:   %v", overlayObjs[2].content, patched)
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	from := map[string]interface{}{"a": "1", "b": map[string]interface{}{"c/d": "2"}, "e": []interface{}{"x"}}
+	to := map[string]interface{}{"b": map[string]interface{}{"c/d": "3"}, "e": []interface{}{"x", "y"}, "f": "4"}
+
+	expected := []jsonPatchOperation{
+		{Op: "remove", Path: "/a"},
+		{Op: "replace", Path: "/b/c~1d", Value: json.RawMessage(`"3"`)},
+		{Op: "replace", Path: "/e", Value: json.RawMessage(`["x","y"]`)},
+		{Op: "add", Path: "/f", Value: json.RawMessage(`"4"`)},
+	}
+	if ops, err := jsonPatch("", from, to); err != nil || !reflect.DeepEqual(ops, expected) {
+		t.Errorf("expected %v, got %v %v", expected, ops, err)
+	}
+	if ops, err := jsonPatch("", from, from); err != nil || len(ops) != 0 {
+		t.Errorf("expected no operations for identical objects, got %v %v", ops, err)
+	}
+
+	from = map[string]interface{}{"a": "1", "b": int64(2), "c": []interface{}{"x"}}
+	to = map[string]interface{}{"a": nil, "b": int64(0), "c": []interface{}{}, "d": ""}
+	ops, err := jsonPatch("", from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedJSON := `[{"op":"replace","path":"/a","value":null},{"op":"replace","path":"/b","value":0},{"op":"replace","path":"/c","value":[]},{"op":"add","path":"/d","value":""}]`
+	if string(data) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, data)
+	}
+}
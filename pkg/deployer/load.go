@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/blackducksoftware/horizon/pkg/loader"
+)
+
+// LoadConfig reads a declarative Horizon config file and adds its components to
+// the deployer.  Nothing is added if the file is invalid
+func (d *Deployer) LoadConfig(r io.Reader) error {
+	loaded, err := loader.Load(r)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range loaded {
+		d.AddComponent(c.Kind, c.Component)
+	}
+	return nil
+}
+
+// LoadConfigFile loads a config file.  The errors are prefixed by the path of
+// the file and the line of the invalid definition
+func (d *Deployer) LoadConfigFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = d.LoadConfig(f)
+	if e, ok := err.(*loader.Error); ok && e.Line > 0 {
+		return fmt.Errorf("%s:%d: %s", path, e.Line, e.Message)
+	} else if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
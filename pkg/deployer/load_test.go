@@ -0,0 +1,69 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+)
+
+func TestLoadConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "load")
+	if err != nil {
+		t.Fatalf("unable to create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := ioutil.WriteFile(valid, []byte("components:\n- kind: Namespace\n  name: shop\n- kind: ServiceAccount\n  name: web\n  namespace: shop\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := ioutil.WriteFile(invalid, []byte("components:\n- kind: Namespace\n  name: other\n- kind: ServiceAccount\n  nmae: web\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := NewDeployerExporter()
+	if err := d.LoadConfigFile(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.components[api.NamespaceComponent]) != 1 || len(d.components[api.ServiceAccountComponent]) != 1 {
+		t.Errorf("unexpected components %v", d.components)
+	}
+
+	err = d.LoadConfigFile(invalid)
+	expected := invalid + `:5: unknown field "nmae" in serviceAccount`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+	if len(d.components[api.NamespaceComponent]) != 1 {
+		t.Errorf("expected nothing to be added from an invalid file, got %v", d.components)
+	}
+
+	if err := d.LoadConfigFile(filepath.Join(dir, "missing.yaml")); !os.IsNotExist(err) {
+		t.Errorf("expected the open error, got %v", err)
+	}
+}
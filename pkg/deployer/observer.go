@@ -0,0 +1,93 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+)
+
+// observerList notifies the registered observers one call at a time
+type observerList struct {
+	lock      sync.Mutex
+	observers []api.DeployObserver
+}
+
+// AddObserver registers an observer that will be notified of the progress of the deployer
+func (d *Deployer) AddObserver(o api.DeployObserver) {
+	d.observers.lock.Lock()
+	defer d.observers.lock.Unlock()
+	d.observers.observers = append(d.observers.observers, o)
+}
+
+func (l *observerList) notify(f func(api.DeployObserver)) {
+	if l == nil {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, o := range l.observers {
+		f(o)
+	}
+}
+
+func (l *observerList) start(total int) {
+	l.notify(func(o api.DeployObserver) { o.OnStart(total) })
+}
+
+func (l *observerList) componentStart(phase api.DeployPhaseType, kind api.ComponentType, name string) {
+	l.notify(func(o api.DeployObserver) { o.OnComponentStart(phase, kind, name) })
+}
+
+func (l *observerList) componentDone(phase api.DeployPhaseType, kind api.ComponentType, name string, start time.Time, err error) {
+	duration := time.Since(start)
+	l.notify(func(o api.DeployObserver) { o.OnComponentDone(phase, kind, name, duration, err) })
+}
+
+func (l *observerList) phaseComplete(phase api.DeployPhaseType, errs map[api.ComponentType][]error) {
+	var err error
+	if deployErrs := utilserror.NewDeployErrors(errs); deployErrs != nil {
+		err = deployErrs
+	}
+	l.notify(func(o api.DeployObserver) { o.OnPhaseComplete(phase, err) })
+}
+
+func (l *observerList) phaseError(phase api.DeployPhaseType, err error) {
+	l.notify(func(o api.DeployObserver) { o.OnPhaseComplete(phase, err) })
+}
+
+func (l *observerList) finish(err error) {
+	l.notify(func(o api.DeployObserver) { o.OnFinish(err) })
+}
+
+// componentCount returns the number of components in the deployer
+func (d *Deployer) componentCount() int {
+	count := 0
+	for _, comps := range d.components {
+		count += len(comps)
+	}
+	return count
+}
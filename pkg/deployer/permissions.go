@@ -0,0 +1,337 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const rbacGroup = "rbac.authorization.k8s.io"
+
+// apiResource defines the resource the API serves a component type as
+type apiResource struct {
+	group      string
+	resource   string
+	namespaced bool
+}
+
+var apiResources = map[api.ComponentType]apiResource{
+	api.NamespaceComponent:               {"", "namespaces", false},
+	api.CRDComponent:                     {"apiextensions.k8s.io", "customresourcedefinitions", false},
+	api.ServiceAccountComponent:          {"", "serviceaccounts", true},
+	api.ClusterRoleComponent:             {rbacGroup, "clusterroles", false},
+	api.ClusterRoleBindingComponent:      {rbacGroup, "clusterrolebindings", false},
+	api.RoleComponent:                    {rbacGroup, "roles", true},
+	api.RoleBindingComponent:             {rbacGroup, "rolebindings", true},
+	api.ConfigMapComponent:               {"", "configmaps", true},
+	api.SecretComponent:                  {"", "secrets", true},
+	api.PersistentVolumeClaimComponent:   {"", "persistentvolumeclaims", true},
+	api.ServiceComponent:                 {"", "services", true},
+	api.PodComponent:                     {"", "pods", true},
+	api.ReplicationControllerComponent:   {"", "replicationcontrollers", true},
+	api.DeploymentComponent:              {"apps", "deployments", true},
+	api.StatefulSetComponent:             {"apps", "statefulsets", true},
+	api.DaemonSetComponent:               {"apps", "daemonsets", true},
+	api.JobComponent:                     {"batch", "jobs", true},
+	api.HorizontalPodAutoscalerComponent: {"autoscaling", "horizontalpodautoscalers", true},
+	api.IngressComponent:                 {"extensions", "ingresses", true},
+	api.NetworkPolicyComponent:           {"networking.k8s.io", "networkpolicies", true},
+	api.PodDisruptionBudgetComponent:     {"policy", "poddisruptionbudgets", true},
+}
+
+// RequiredRBAC defines the roles and bindings granting a service account the
+// permissions a deployer needs
+type RequiredRBAC struct {
+	ClusterRole        *components.ClusterRole
+	ClusterRoleBinding *components.ClusterRoleBinding
+	Roles              []*components.Role
+	RoleBindings       []*components.RoleBinding
+}
+
+// Components returns the roles and bindings, so that they can be added to the
+// deployer installing the service account
+func (r *RequiredRBAC) Components() map[api.ComponentType][]api.DeployableComponentInterface {
+	comps := map[api.ComponentType][]api.DeployableComponentInterface{}
+	if r.ClusterRole != nil {
+		comps[api.ClusterRoleComponent] = append(comps[api.ClusterRoleComponent], r.ClusterRole)
+		comps[api.ClusterRoleBindingComponent] = append(comps[api.ClusterRoleBindingComponent], r.ClusterRoleBinding)
+	}
+	for i := range r.Roles {
+		comps[api.RoleComponent] = append(comps[api.RoleComponent], r.Roles[i])
+		comps[api.RoleBindingComponent] = append(comps[api.RoleBindingComponent], r.RoleBindings[i])
+	}
+	return comps
+}
+
+// grant defines a verb allowed on a resource, either on all objects or on the
+// named ones
+type grant struct {
+	group    string
+	resource string
+	verb     string
+}
+
+// scopeRules defines the permissions needed in a namespace, or cluster-wide
+type scopeRules struct {
+	grants map[grant]map[string]bool
+	// all marks the grants that apply to all objects
+	all   map[grant]bool
+	rules []rbacv1.PolicyRule
+}
+
+type permissionSet map[string]*scopeRules
+
+func (p permissionSet) scope(namespace string) *scopeRules {
+	s, ok := p[namespace]
+	if !ok {
+		s = &scopeRules{grants: map[grant]map[string]bool{}, all: map[grant]bool{}}
+		p[namespace] = s
+	}
+	return s
+}
+
+// allow grants the verbs on the named object, or on all objects if the name
+// is empty
+func (p permissionSet) allow(namespace string, group string, resource string, name string, verbs ...string) {
+	s := p.scope(namespace)
+	for _, verb := range verbs {
+		g := grant{group, resource, verb}
+		if len(name) == 0 {
+			s.all[g] = true
+			continue
+		}
+		if s.grants[g] == nil {
+			s.grants[g] = map[string]bool{}
+		}
+		s.grants[g][name] = true
+	}
+}
+
+// addRules adds rules held by the app's roles, which the deployer must hold to
+// create them
+func (p permissionSet) addRules(namespace string, rules []rbacv1.PolicyRule) {
+	s := p.scope(namespace)
+	for _, r := range rules {
+		// non-resource rules have no effect in a namespace
+		if len(namespace) > 0 && len(r.NonResourceURLs) > 0 {
+			continue
+		}
+		found := false
+		for _, existing := range s.rules {
+			if reflect.DeepEqual(existing, r) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s.rules = append(s.rules, r)
+		}
+	}
+}
+
+// policyRules returns the rules of the scope.  Grants on all objects combine
+// the verbs of a resource, and grants on named objects combine the verbs
+// allowed on the same objects
+func (s *scopeRules) policyRules() []rbacv1.PolicyRule {
+	type resourceKey struct{ group, resource, names string }
+	verbs := map[resourceKey][]string{}
+	for g := range s.all {
+		key := resourceKey{g.group, g.resource, ""}
+		verbs[key] = append(verbs[key], g.verb)
+	}
+	for g, names := range s.grants {
+		if s.all[g] {
+			continue
+		}
+		list := []string{}
+		for n := range names {
+			list = append(list, n)
+		}
+		sort.Strings(list)
+		key := resourceKey{g.group, g.resource, strings.Join(list, ",")}
+		verbs[key] = append(verbs[key], g.verb)
+	}
+
+	keys := []resourceKey{}
+	for k := range verbs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].group != keys[j].group {
+			return keys[i].group < keys[j].group
+		}
+		if keys[i].resource != keys[j].resource {
+			return keys[i].resource < keys[j].resource
+		}
+		return keys[i].names < keys[j].names
+	})
+
+	rules := []rbacv1.PolicyRule{}
+	for _, k := range keys {
+		v := verbs[k]
+		sort.Strings(v)
+		rule := rbacv1.PolicyRule{Verbs: v, APIGroups: []string{k.group}, Resources: []string{k.resource}}
+		if len(k.names) > 0 {
+			rule.ResourceNames = strings.Split(k.names, ",")
+		}
+		rules = append(rules, rule)
+	}
+	return append(rules, s.rules...)
+}
+
+// RequiredRBAC returns the roles and bindings granting a service account the
+// permissions needed to deploy the components: creating them and getting and
+// updating them by name, and deleting them if they are undeployed or the
+// deployer is transactional.  The permissions of the operations enabled by the
+// config and by the wait, inventory and release configurations of the deployer
+// are included.  Permissions on namespaced objects are granted by roles in
+// their namespaces, and other permissions by a cluster role.
+//
+// The rules of the roles of the app are included, since the API server only
+// lets the deployer create roles and bindings granting permissions it holds.
+// Bindings to roles that aren't part of the app need the bind verb on them,
+// and cluster roles with aggregation rules need the escalate verb
+func (d *Deployer) RequiredRBAC(config api.DeployerRBACConfig) *RequiredRBAC {
+	wait := config.Wait || d.waitConfig != nil
+	prune := config.Prune || (d.inventoryConfig != nil && d.inventoryConfig.Prune)
+	verbs := []string{"get", "update"}
+	if config.Undeploy || d.transactional {
+		verbs = append(verbs, "delete")
+	}
+
+	perms := permissionSet{}
+	clusterRoles := map[string]*components.ClusterRole{}
+	roles := map[string]*components.Role{}
+	for _, c := range d.components[api.ClusterRoleComponent] {
+		if cr, ok := c.(*components.ClusterRole); ok {
+			clusterRoles[cr.Name] = cr
+		}
+	}
+	for _, c := range d.components[api.RoleComponent] {
+		if r, ok := c.(*components.Role); ok {
+			roles[r.Namespace+"/"+r.Name] = r
+		}
+	}
+
+	for _, ct := range deployOrder {
+		res, ok := apiResources[ct]
+		if !ok {
+			continue
+		}
+		for _, c := range d.components[ct] {
+			namespace := ""
+			if res.namespaced {
+				namespace = namespaceOf(c)
+			}
+			perms.allow(namespace, res.group, res.resource, "", "create")
+			perms.allow(namespace, res.group, res.resource, c.GetName(), verbs...)
+
+			switch obj := c.(type) {
+			case *components.Service:
+				if wait {
+					perms.allow(namespace, "", "endpoints", obj.Name, "get")
+				}
+			case *components.PersistentVolumeClaim:
+				if wait && obj.Spec.StorageClassName != nil {
+					perms.allow("", "storage.k8s.io", "storageclasses", *obj.Spec.StorageClassName, "get")
+				}
+			case *components.ClusterRole:
+				perms.addRules("", obj.Rules)
+				if obj.AggregationRule != nil {
+					perms.allow("", rbacGroup, "clusterroles", obj.Name, "escalate")
+				}
+			case *components.Role:
+				perms.addRules(namespace, obj.Rules)
+			case *components.ClusterRoleBinding:
+				if _, ok := clusterRoles[obj.RoleRef.Name]; !ok {
+					perms.allow("", rbacGroup, "clusterroles", obj.RoleRef.Name, "bind")
+				}
+			case *components.RoleBinding:
+				if obj.RoleRef.Kind == "ClusterRole" {
+					if cr, ok := clusterRoles[obj.RoleRef.Name]; ok {
+						perms.addRules(namespace, cr.Rules)
+					} else {
+						perms.allow(namespace, rbacGroup, "clusterroles", obj.RoleRef.Name, "bind")
+					}
+				} else if _, ok := roles[namespace+"/"+obj.RoleRef.Name]; !ok {
+					perms.allow(namespace, rbacGroup, "roles", obj.RoleRef.Name, "bind")
+				}
+			}
+		}
+	}
+
+	if prune {
+		for _, ct := range deployOrder {
+			if res, ok := apiResources[ct]; ok {
+				perms.allow("", res.group, res.resource, "", "list", "delete")
+			}
+		}
+	}
+
+	if d.releaseConfig != nil {
+		resource := "secrets"
+		if d.releaseConfig.Storage == api.ReleaseStorageConfigMap {
+			resource = "configmaps"
+		}
+		perms.allow(d.releaseConfig.Namespace, "", resource, "", "create", "list", "delete")
+	}
+
+	return perms.build(config)
+}
+
+// build creates the roles and bindings granting the permissions
+func (p permissionSet) build(config api.DeployerRBACConfig) *RequiredRBAC {
+	subject := api.SubjectConfig{Kind: "ServiceAccount", Name: config.ServiceAccount, Namespace: config.ServiceAccountNamespace}
+	r := &RequiredRBAC{}
+
+	namespaces := []string{}
+	for ns := range p {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		rules := p[ns].policyRules()
+		if len(ns) == 0 {
+			r.ClusterRole = components.NewClusterRole(api.ClusterRoleConfig{Name: config.Name})
+			r.ClusterRole.Rules = rules
+			r.ClusterRoleBinding = components.NewClusterRoleBinding(api.ClusterRoleBindingConfig{Name: config.Name})
+			r.ClusterRoleBinding.AddRoleRef(api.RoleRefConfig{APIGroup: rbacGroup, Kind: "ClusterRole", Name: config.Name})
+			r.ClusterRoleBinding.AddSubject(subject)
+			continue
+		}
+		role := components.NewRole(api.RoleConfig{Name: config.Name, Namespace: ns})
+		role.Rules = rules
+		binding := components.NewRoleBinding(api.RoleBindingConfig{Name: config.Name, Namespace: ns})
+		binding.AddRoleRef(api.RoleRefConfig{APIGroup: rbacGroup, Kind: "Role", Name: config.Name})
+		binding.AddSubject(subject)
+		r.Roles = append(r.Roles, role)
+		r.RoleBindings = append(r.RoleBindings, binding)
+	}
+	return r
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/rbac"
+)
+
+func TestRequiredRBAC(t *testing.T) {
+	role := components.NewRole(api.RoleConfig{Name: "web", Namespace: "shop"})
+	role.AddPolicyRule(api.PolicyRuleConfig{Verbs: []string{"watch"}, APIGroups: []string{""}, Resources: []string{"pods"}})
+	rb := components.NewRoleBinding(api.RoleBindingConfig{Name: "web", Namespace: "shop"})
+	rb.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "web"})
+	rb.AddSubject(api.SubjectConfig{Kind: "ServiceAccount", Name: "web", Namespace: "shop"})
+	view := components.NewRoleBinding(api.RoleBindingConfig{Name: "view", Namespace: "shop"})
+	view.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"})
+	view.AddSubject(api.SubjectConfig{Kind: "ServiceAccount", Name: "web", Namespace: "shop"})
+	monitoring := components.NewClusterRole(api.ClusterRoleConfig{Name: "monitoring"})
+	monitoring.AddAggregationRule(api.SelectorConfig{Labels: map[string]string{"monitoring": "true"}})
+	nodes := components.NewClusterRole(api.ClusterRoleConfig{Name: "nodes"})
+	nodes.AddPolicyRule(api.PolicyRuleConfig{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"nodes"}})
+	svc := components.NewService(api.ServiceConfig{Name: "web", Namespace: "shop"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.NamespaceComponent, components.NewNamespace(api.NamespaceConfig{Name: "shop"}))
+	d.AddComponent(api.ConfigMapComponent, components.NewConfigMap(api.ConfigMapConfig{Name: "settings", Namespace: "shop"}))
+	d.AddComponent(api.ServiceComponent, svc)
+	d.AddComponent(api.RoleComponent, role)
+	d.AddComponent(api.RoleBindingComponent, rb)
+	d.AddComponent(api.RoleBindingComponent, view)
+	d.AddComponent(api.ClusterRoleComponent, monitoring)
+	d.AddComponent(api.ClusterRoleComponent, nodes)
+	d.SetWaitConfig(api.WaitConfig{})
+	d.SetReleaseConfig(api.ReleaseConfig{Name: "shop", Namespace: "releases"})
+
+	required := d.RequiredRBAC(api.DeployerRBACConfig{Name: "deployer", ServiceAccount: "deployer", ServiceAccountNamespace: "horizon"})
+	if len(required.Roles) != 2 || required.Roles[0].Namespace != "releases" || required.Roles[1].Namespace != "shop" || required.ClusterRole == nil {
+		t.Fatalf("unexpected roles %+v", required)
+	}
+	comps := required.Components()
+	if len(comps[api.RoleComponent]) != 2 || len(comps[api.RoleBindingComponent]) != 2 || len(comps[api.ClusterRoleComponent]) != 1 || len(comps[api.ClusterRoleBindingComponent]) != 1 {
+		t.Errorf("unexpected components %v", comps)
+	}
+
+	objs := []interface{}{}
+	for _, list := range comps {
+		for _, c := range list {
+			objs = append(objs, c)
+		}
+	}
+	a := rbac.NewAnalyzer(objs...)
+	var cases = []struct {
+		request  rbac.Request
+		expected bool
+	}{
+		{rbac.Request{Verb: "create", Resource: "configmaps", Namespace: "shop"}, true},
+		{rbac.Request{Verb: "update", Resource: "configmaps", Name: "settings", Namespace: "shop"}, true},
+		{rbac.Request{Verb: "update", Resource: "configmaps", Name: "other", Namespace: "shop"}, false},
+		{rbac.Request{Verb: "delete", Resource: "configmaps", Name: "settings", Namespace: "shop"}, false},
+		{rbac.Request{Verb: "create", Resource: "configmaps", Namespace: "default"}, false},
+		{rbac.Request{Verb: "get", Resource: "endpoints", Name: "web", Namespace: "shop"}, true},
+		{rbac.Request{Verb: "get", Resource: "namespaces", Name: "shop"}, true},
+		{rbac.Request{Verb: "watch", Resource: "pods", Namespace: "shop"}, true},
+		{rbac.Request{Verb: "list", Resource: "nodes"}, true},
+		{rbac.Request{Verb: "bind", APIGroup: "rbac.authorization.k8s.io", Resource: "clusterroles", Name: "view", Namespace: "shop"}, true},
+		{rbac.Request{Verb: "bind", APIGroup: "rbac.authorization.k8s.io", Resource: "clusterroles", Name: "admin", Namespace: "shop"}, false},
+		{rbac.Request{Verb: "escalate", APIGroup: "rbac.authorization.k8s.io", Resource: "clusterroles", Name: "monitoring"}, true},
+		{rbac.Request{Verb: "list", Resource: "secrets", Namespace: "releases"}, true},
+		{rbac.Request{Verb: "list", Resource: "secrets", Namespace: "shop"}, false},
+		{rbac.Request{Verb: "list", Resource: "configmaps"}, false},
+	}
+	for _, tc := range cases {
+		if actual := a.Can("horizon", "deployer", tc.request); actual != tc.expected {
+			t.Errorf("%+v: expected %t, got %t", tc.request, tc.expected, actual)
+		}
+	}
+
+	d.SetInventoryConfig(api.InventoryConfig{AppName: "shop", Prune: true})
+	required = d.RequiredRBAC(api.DeployerRBACConfig{Name: "deployer", ServiceAccount: "deployer", ServiceAccountNamespace: "horizon", Undeploy: true})
+	a = rbac.NewAnalyzer(required.ClusterRole, required.ClusterRoleBinding, required.Roles[1], required.RoleBindings[1])
+	if !a.Can("horizon", "deployer", rbac.Request{Verb: "list", Resource: "configmaps"}) || !a.Can("horizon", "deployer", rbac.Request{Verb: "delete", Resource: "configmaps", Name: "settings", Namespace: "shop"}) {
+		t.Errorf("expected the deployer to prune and undeploy config maps")
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/util/diff"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ComponentPlan defines the change deploying a component would make to the cluster
+type ComponentPlan struct {
+	Kind      api.ComponentType
+	Name      string
+	Namespace string
+	Action    api.ApplyActionType
+
+	// Diff is a unified diff between the live object and the object that
+	// would be deployed.  Fields that are only set by the cluster are omitted
+	Diff string
+}
+
+// Plan compares all components against the cluster and returns the change deploying
+// each of them would make, in deploy order, without modifying the cluster.  The
+// components are labeled and hardened as Run would before they are compared
+func (d *Deployer) Plan() ([]ComponentPlan, error) {
+	if d.exporterOnly() {
+		return nil, fmt.Errorf("deployer has no clients defined and can only be used to export")
+	}
+
+	p, err := d.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	plans := []ComponentPlan{}
+	allErrs := map[api.ComponentType][]error{}
+	resources := d.getResources()
+	for _, ct := range deployOrder {
+		for _, c := range p.components[ct] {
+			plan, err := p.planComponent(ct, c, resources)
+			if err != nil {
+				allErrs[ct] = append(allErrs[ct], fmt.Errorf("%s: %v", c.GetName(), err))
+				continue
+			}
+			plans = append(plans, *plan)
+		}
+	}
+
+	return plans, utilserror.NewDeployErrors(allErrs)
+}
+
+func (d *Deployer) planComponent(ct api.ComponentType, c api.DeployableComponentInterface, res api.DeployerResources) (*ComponentPlan, error) {
+	accessor, err := meta.Accessor(c)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := components.PlanDeploy(c, res)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ComponentPlan{
+		Kind:      ct,
+		Name:      accessor.GetName(),
+		Namespace: accessor.GetNamespace(),
+		Action:    p.Action,
+	}
+
+	if p.Action != api.ApplyActionUnchanged {
+		plan.Diff, err = planDiff(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// planDiff returns a diff of the YAML of the live and desired objects
+func planDiff(p *components.Plan) (string, error) {
+	desired, err := cleanFields(p.Desired)
+	if err != nil {
+		return "", err
+	}
+	to, err := encodeFields(desired)
+	if err != nil {
+		return "", err
+	}
+
+	from := ""
+	if p.Live != nil {
+		live, err := cleanFields(p.Live)
+		if err != nil {
+			return "", err
+		}
+		from, err = encodeFields(pruneFields(live, desired).(map[string]interface{}))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return diff.Unified(from, to, "live", "desired"), nil
+}
+
+// cleanFields returns the fields of the object without the status and the
+// metadata managed by the cluster or the deployer
+func cleanFields(obj runtime.Object) (map[string]interface{}, error) {
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+		for _, k := range []string{"resourceVersion", "uid", "selfLink", "creationTimestamp", "generation"} {
+			delete(metadata, k)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, components.AppliedHashAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+// pruneFields removes the fields from live that aren't set in desired so that
+// defaults added by the cluster don't show up as differences
+func pruneFields(live interface{}, desired interface{}) interface{} {
+	switch l := live.(type) {
+	case map[string]interface{}:
+		d, ok := desired.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		pruned := make(map[string]interface{})
+		for k, v := range l {
+			if dv, exists := d[k]; exists {
+				pruned[k] = pruneFields(v, dv)
+			}
+		}
+		return pruned
+	case []interface{}:
+		d, ok := desired.([]interface{})
+		if !ok {
+			return live
+		}
+		pruned := make([]interface{}, len(l))
+		for i := range l {
+			if i < len(d) {
+				pruned[i] = pruneFields(l[i], d[i])
+			} else {
+				pruned[i] = l[i]
+			}
+		}
+		return pruned
+	default:
+		return live
+	}
+}
+
+// encodeFields renders the fields like ExportObjects renders the components
+func encodeFields(fields map[string]interface{}) (string, error) {
+	data, err := renderYAML(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+)
+
+func TestPlanDiff(t *testing.T) {
+	desired := components.NewConfigMap(api.ConfigMapConfig{Name: "config", Namespace: "ns"})
+	desired.AddData(map[string]string{"key": "new"})
+
+	live := desired.ConfigMap.DeepCopy()
+	live.ResourceVersion = "10"
+	live.UID = "1234"
+	live.Annotations = map[string]string{components.AppliedHashAnnotation: "hash"}
+	live.Data = map[string]string{"key": "old"}
+
+	diff, err := planDiff(&components.Plan{
+		Action:  api.ApplyActionUpdate,
+		Live:    live,
+		Desired: desired.ConfigMap,
+	})
+	if err != nil {
+		t.Fatalf("failed to create the diff: %v", err)
+	}
+
+	for _, expected := range []string{"-  key: old", "+  key: new"} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("expected %q in diff:\n%s", expected, diff)
+		}
+	}
+	for _, unexpected := range []string{"resourceVersion", "uid", components.AppliedHashAnnotation} {
+		if strings.Contains(diff, unexpected) {
+			t.Errorf("unexpected %q in diff:\n%s", unexpected, diff)
+		}
+	}
+}
+
+func TestPlanDiffCreate(t *testing.T) {
+	desired := components.NewConfigMap(api.ConfigMapConfig{Name: "config", Namespace: "ns"})
+
+	diff, err := planDiff(&components.Plan{
+		Action:  api.ApplyActionCreate,
+		Desired: desired.ConfigMap,
+	})
+	if err != nil {
+		t.Fatalf("failed to create the diff: %v", err)
+	}
+
+	if !strings.Contains(diff, "+  name: config") {
+		t.Errorf("expected the whole object to be added:\n%s", diff)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/policy"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+)
+
+// SetPolicy sets the security policy the components are checked against before
+// they are deployed.  Run fails without deploying anything if a component has a
+// finding with at least the given severity
+func (d *Deployer) SetPolicy(p *policy.Policy, failOn policy.Severity) {
+	d.securityPolicy = p
+	d.policySeverity = failOn
+}
+
+// CheckPolicy checks the components against the security policy, if one was
+// set, and returns the findings with at least the severity given to SetPolicy
+func (d *Deployer) CheckPolicy() error {
+	if d.securityPolicy == nil {
+		return nil
+	}
+
+	allErrs := map[api.ComponentType][]error{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			for _, f := range d.securityPolicy.Check(c) {
+				if f.Severity >= d.policySeverity {
+					finding := f
+					allErrs[ct] = append(allErrs[ct], &finding)
+				}
+			}
+		}
+	}
+	return utilserror.NewDeployErrors(allErrs)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/policy"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	yes := true
+	c, _ := components.NewContainer(api.ContainerConfig{Name: "web", Image: "shop/web:1.0", Privileged: &yes})
+	pod := components.NewPod(api.PodConfig{Name: "web", Namespace: "shop"})
+	pod.AddContainer(c)
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.PodComponent, pod)
+	if err := d.CheckPolicy(); err != nil {
+		t.Errorf("expected no findings without a policy, got %v", err)
+	}
+
+	d.SetPolicy(policy.NewDefaultPolicy(), policy.SeverityHigh)
+	err := d.CheckPolicy()
+	deployErrs, ok := err.(utilserror.DeployErrors)
+	if !ok {
+		t.Fatalf("expected DeployErrors, got %v", err)
+	}
+	errs := deployErrs.Errors()[api.PodComponent]
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "(privileged, high)") {
+		t.Errorf("expected the privileged finding, got %v", errs)
+	}
+	if err := d.Run(); err == nil || err.Error() != deployErrs.Error() {
+		t.Errorf("expected Run to fail the policy, got %v", err)
+	}
+
+	pod.AddAnnotations(map[string]string{policy.IgnoreRulesAnnotation: "privileged"})
+	if err := d.CheckPolicy(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// AppLabel is the label identifying the app that owns a component
+	AppLabel = "horizon.blackducksoftware.com/app"
+	// InstanceLabel is the label identifying the instance of the app that owns a component
+	InstanceLabel = "horizon.blackducksoftware.com/instance"
+)
+
+// PruneResult defines an object that was, or would be, removed by Prune
+type PruneResult struct {
+	Kind      api.ComponentType
+	Namespace string
+	Name      string
+}
+
+// SetInventoryConfig configures the deployer to label every component with the
+// app name and instance, so that objects removed from the deployer can be pruned
+func (d *Deployer) SetInventoryConfig(config api.InventoryConfig) {
+	d.inventoryConfig = &config
+}
+
+// ownershipLabels returns the labels identifying the components of this deployer
+func (d *Deployer) ownershipLabels() map[string]string {
+	return map[string]string{
+		AppLabel:      d.inventoryConfig.AppName,
+		InstanceLabel: d.inventoryConfig.Instance,
+	}
+}
+
+// stampOwnership adds the ownership labels to all components
+func (d *Deployer) stampOwnership() error {
+	if d.inventoryConfig == nil {
+		return nil
+	}
+
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			accessor, err := meta.Accessor(c)
+			if err != nil {
+				return err
+			}
+			l := accessor.GetLabels()
+			if l == nil {
+				l = map[string]string{}
+			}
+			for k, v := range d.ownershipLabels() {
+				l[k] = v
+			}
+			accessor.SetLabels(l)
+		}
+	}
+	return nil
+}
+
+// Prune finds the objects in the cluster that have the ownership labels of this
+// deployer but are no longer part of it, and removes them unless dryRun is set
+func (d *Deployer) Prune(dryRun bool) ([]PruneResult, error) {
+	return d.prune(context.Background(), dryRun)
+}
+
+func (d *Deployer) prune(ctx context.Context, dryRun bool) ([]PruneResult, error) {
+	if d.exporterOnly() {
+		return nil, fmt.Errorf("deployer has no clients defined and can only be used to export")
+	}
+	if d.inventoryConfig == nil {
+		return nil, fmt.Errorf("deployer has no inventory configuration")
+	}
+
+	keep := map[string]bool{}
+	for ct, comps := range d.components {
+		for _, c := range comps {
+			keep[keyOf(ct, c)] = true
+		}
+	}
+
+	selector := labels.SelectorFromSet(d.ownershipLabels()).String()
+	resources := d.getResources()
+	results := []PruneResult{}
+	remove := map[api.ComponentType][]api.DeployableComponentInterface{}
+	for _, ct := range deployOrder {
+		live, err := components.ListComponents(ct, resources, selector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list %s objects: %v", ct, err)
+		}
+		for _, c := range live {
+			if keep[keyOf(ct, c)] {
+				continue
+			}
+			accessor, err := meta.Accessor(c)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, PruneResult{Kind: ct, Namespace: accessor.GetNamespace(), Name: accessor.GetName()})
+			remove[ct] = append(remove[ct], c)
+		}
+	}
+
+	if dryRun {
+		for _, r := range results {
+			log.Infof("would prune %s %s", r.Kind, r.Name)
+		}
+		return results, nil
+	}
+
+	return results, d.withComponents(remove).undeploy(ctx, api.DeployPhasePrune)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+)
+
+func TestStampOwnership(t *testing.T) {
+	cm := components.NewConfigMap(api.ConfigMapConfig{Name: "cfg", Namespace: "ns"})
+	cm.AddLabels(map[string]string{"app": "test"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, cm)
+	if err := d.stampOwnership(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cm.Labels) != 1 {
+		t.Errorf("expected no ownership labels without an inventory configuration, got %v", cm.Labels)
+	}
+
+	d.SetInventoryConfig(api.InventoryConfig{AppName: "app", Instance: "one"})
+	if err := d.stampOwnership(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{"app": "test", AppLabel: "app", InstanceLabel: "one"}
+	for k, v := range expected {
+		if cm.Labels[k] != v {
+			t.Errorf("expected label %s=%s, got %v", k, v, cm.Labels)
+		}
+	}
+}
+
+func TestPruneRequiresInventory(t *testing.T) {
+	d := NewDeployerExporter()
+	if _, err := d.Prune(true); err == nil {
+		t.Errorf("expected an error when pruning without clients")
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/rbac"
+)
+
+// RBAC returns an analyzer of the permissions granted to service accounts by
+// the roles, cluster roles and bindings of the deployer
+func (d *Deployer) RBAC() *rbac.Analyzer {
+	objs := []interface{}{}
+	for _, ct := range []api.ComponentType{api.ClusterRoleComponent, api.ClusterRoleBindingComponent, api.RoleComponent, api.RoleBindingComponent} {
+		for _, c := range d.components[ct] {
+			objs = append(objs, c)
+		}
+	}
+	return rbac.NewAnalyzer(objs...)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/rbac"
+)
+
+func TestRBAC(t *testing.T) {
+	role := components.NewRole(api.RoleConfig{Name: "web", Namespace: "shop"})
+	role.AddPolicyRule(api.PolicyRuleConfig{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"configmaps"}})
+	rb := components.NewRoleBinding(api.RoleBindingConfig{Name: "web", Namespace: "shop"})
+	rb.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "web"})
+	rb.AddSubject(api.SubjectConfig{Kind: "ServiceAccount", Name: "web", Namespace: "shop"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.RoleComponent, role)
+	d.AddComponent(api.RoleBindingComponent, rb)
+	d.AddComponent(api.ServiceAccountComponent, components.NewServiceAccount(api.ServiceAccountConfig{Name: "web", Namespace: "shop"}))
+
+	a := d.RBAC()
+	if !a.Can("shop", "web", rbac.Request{Verb: "get", Resource: "configmaps", Namespace: "shop"}) {
+		t.Errorf("expected web to get config maps")
+	}
+	if a.Can("shop", "web", rbac.Request{Verb: "get", Resource: "secrets", Namespace: "shop"}) {
+		t.Errorf("expected web not to get secrets")
+	}
+	if grants := a.DangerousGrants(); len(grants) != 0 {
+		t.Errorf("unexpected dangerous grants %v", grants)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// builtinClusterRoles are the cluster roles every cluster provides, besides the
+// system: roles
+var builtinClusterRoles = map[string]bool{
+	"cluster-admin": true,
+	"admin":         true,
+	"edit":          true,
+	"view":          true,
+}
+
+// ReferenceError defines a reference from a component to an object that isn't
+// part of the deployer
+type ReferenceError struct {
+	Kind      api.ComponentType
+	Name      string
+	Reference components.Reference
+	Reason    string
+}
+
+func (e *ReferenceError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Name, e.Reference.Field, e.Reason)
+}
+
+// MarkExternal marks an object as existing outside of the deployer, so that the
+// references to it aren't reported by CheckReferences
+func (d *Deployer) MarkExternal(kind api.ComponentType, namespace string, name string) {
+	if d.externals == nil {
+		d.externals = map[string]bool{}
+	}
+	d.externals[componentKey(kind, namespace, name)] = true
+}
+
+// CheckReferences reports the references between components that would only be
+// resolved by the cluster at runtime: the config maps, secrets, claims and
+// service accounts used by the pods, the keys of their environment, the volumes
+// mounted by their containers, the roles of the bindings, the services and
+// ports of the ingresses and the targets of the autoscalers.  The references to
+// objects missing from the deployer are returned as ReferenceErrors, unless the
+// objects were added with MarkExternal or are provided by every cluster, like the
+// default service account.  Run doesn't check the references, as the objects
+// may be created by other means, so call CheckReferences before it
+func (d *Deployer) CheckReferences() error {
+	objects := map[string]api.DeployableComponentInterface{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			objects[keyOf(ct, c)] = c
+		}
+	}
+
+	allErrs := map[api.ComponentType][]error{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			for _, err := range d.checkReferences(ct, c, objects) {
+				allErrs[ct] = append(allErrs[ct], err)
+			}
+		}
+	}
+	return utilserror.NewDeployErrors(allErrs)
+}
+
+func (d *Deployer) checkReferences(kind api.ComponentType, c api.DeployableComponentInterface, objects map[string]api.DeployableComponentInterface) []error {
+	errs := []error{}
+	report := func(ref components.Reference, format string, args ...interface{}) {
+		errs = append(errs, &ReferenceError{Kind: kind, Name: c.GetName(), Reference: ref, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	for _, ref := range components.GetReferences(c) {
+		if isBuiltin(ref) || d.externals[componentKey(ref.Kind, ref.Namespace, ref.Name)] {
+			continue
+		}
+		obj, ok := objects[componentKey(ref.Kind, ref.Namespace, ref.Name)]
+		if !ok {
+			if !ref.Optional {
+				report(ref, "%s not found", ref)
+			}
+			continue
+		}
+		if len(ref.Key) == 0 || ref.Optional {
+			continue
+		}
+		switch o := obj.(type) {
+		case *components.ConfigMap:
+			if !hasKey(ref.Key, o.Data) && !hasKey(ref.Key, o.BinaryData) {
+				report(ref, "key %s not found in %s", ref.Key, ref)
+			}
+		case *components.Secret:
+			if !hasKey(ref.Key, o.Data) && !hasKey(ref.Key, o.StringData) {
+				report(ref, "key %s not found in %s", ref.Key, ref)
+			}
+		case *components.Service:
+			if !hasServicePort(o, ref.Key) {
+				report(ref, "port %s not found in %s", ref.Key, ref)
+			}
+		}
+	}
+
+	for _, ref := range volumeMountReferences(c) {
+		report(ref, "volume %s not declared by the pod", ref.Name)
+	}
+	return errs
+}
+
+// isBuiltin returns true if the object of a reference exists in every cluster
+func isBuiltin(ref components.Reference) bool {
+	switch ref.Kind {
+	case api.ServiceAccountComponent:
+		return ref.Name == "default"
+	case api.ClusterRoleComponent:
+		return builtinClusterRoles[ref.Name] || strings.HasPrefix(ref.Name, "system:")
+	}
+	return false
+}
+
+func hasKey(key string, data interface{}) bool {
+	switch m := data.(type) {
+	case map[string]string:
+		_, ok := m[key]
+		return ok
+	case map[string][]byte:
+		_, ok := m[key]
+		return ok
+	}
+	return false
+}
+
+// hasServicePort returns true if the port, given by number or name, is exposed
+// by the service
+func hasServicePort(s *components.Service, port string) bool {
+	for _, p := range s.Spec.Ports {
+		if p.Name == port || strconv.Itoa(int(p.Port)) == port {
+			return true
+		}
+	}
+	return false
+}
+
+// volumeMountReferences returns the volume mounts of the containers of a
+// component naming volumes its pods don't declare.  The volumes of the pods of
+// a stateful set include its volume claim templates
+func volumeMountReferences(c api.DeployableComponentInterface) []components.Reference {
+	spec := components.GetPodSpec(c)
+	if spec == nil {
+		return nil
+	}
+	namespace := ""
+	if accessor, err := meta.Accessor(c); err == nil {
+		namespace = accessor.GetNamespace()
+	}
+	field := "spec.template.spec"
+	if _, ok := c.(*components.Pod); ok {
+		field = "spec"
+	}
+
+	volumes := map[string]bool{}
+	for _, v := range spec.Volumes {
+		volumes[v.Name] = true
+	}
+	if s, ok := c.(*components.StatefulSet); ok {
+		for _, claim := range s.Spec.VolumeClaimTemplates {
+			volumes[claim.Name] = true
+		}
+	}
+
+	refs := []components.Reference{}
+	check := func(containers string, list []v1.Container) {
+		for i, container := range list {
+			for j, mount := range container.VolumeMounts {
+				if !volumes[mount.Name] {
+					refs = append(refs, components.Reference{Namespace: namespace, Name: mount.Name, Field: fmt.Sprintf("%s.%s[%d].volumeMounts[%d]", field, containers, i, j)})
+				}
+			}
+			for j, device := range container.VolumeDevices {
+				if !volumes[device.Name] {
+					refs = append(refs, components.Reference{Namespace: namespace, Name: device.Name, Field: fmt.Sprintf("%s.%s[%d].volumeDevices[%d]", field, containers, i, j)})
+				}
+			}
+		}
+	}
+	check("initContainers", spec.InitContainers)
+	check("containers", spec.Containers)
+	return refs
+}
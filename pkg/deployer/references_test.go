@@ -0,0 +1,137 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+)
+
+func referenceErrors(t *testing.T, err error) []string {
+	if err == nil {
+		return nil
+	}
+	deployErrs, ok := err.(utilserror.DeployErrors)
+	if !ok {
+		t.Fatalf("expected DeployErrors, got %v", err)
+	}
+	msgs := []string{}
+	for _, errs := range deployErrs.Errors() {
+		for _, err := range errs {
+			if _, ok := err.(*ReferenceError); !ok {
+				t.Errorf("expected a ReferenceError, got %T", err)
+			}
+			msgs = append(msgs, err.Error())
+		}
+	}
+	sort.Strings(msgs)
+	return msgs
+}
+
+func TestCheckReferences(t *testing.T) {
+	cm := components.NewConfigMap(api.ConfigMapConfig{Name: "settings", Namespace: "shop"})
+	cm.AddData(map[string]string{"mode": "fast"})
+
+	c, _ := components.NewContainer(api.ContainerConfig{Name: "web", Image: "web"})
+	c.AddEnv(api.EnvConfig{NameOrPrefix: "MODE", Type: api.EnvFromConfigMap, KeyOrVal: "mode", FromName: "settings"})
+	c.AddEnv(api.EnvConfig{NameOrPrefix: "LEVEL", Type: api.EnvFromConfigMap, KeyOrVal: "level", FromName: "settings"})
+	c.AddEnv(api.EnvConfig{NameOrPrefix: "TOKEN", Type: api.EnvFromSecret, KeyOrVal: "token", FromName: "vault"})
+	c.AddVolumeMount(api.VolumeMountConfig{Name: "config", MountPath: "/config"})
+	c.AddVolumeMount(api.VolumeMountConfig{Name: "data", MountPath: "/data"})
+	pod := components.NewPod(api.PodConfig{Name: "web", Namespace: "shop", ServiceAccount: "web"})
+	pod.AddContainer(c)
+	pod.AddVolume(components.NewConfigMapVolume(api.ConfigMapOrSecretVolumeConfig{VolumeName: "config", MapOrSecretName: "settings"}))
+	pod.AddVolume(components.NewPVCVolume(api.PVCVolumeConfig{VolumeName: "cache", PVCName: "cache"}))
+
+	rb := components.NewRoleBinding(api.RoleBindingConfig{Name: "web", Namespace: "shop"})
+	rb.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "web"})
+	viewer := components.NewRoleBinding(api.RoleBindingConfig{Name: "viewer", Namespace: "shop"})
+	viewer.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"})
+
+	svc := components.NewService(api.ServiceConfig{Name: "web", Namespace: "shop"})
+	svc.AddPort(api.ServicePortConfig{Name: "http", Port: 80})
+	ing, _ := components.NewIngress(api.IngressConfig{Name: "web", Namespace: "shop", ServiceName: "web", ServicePort: "http"})
+	ing.AddHostRule(api.IngressHostRuleConfig{Host: "shop.example.com", Paths: []api.HTTPIngressPathConfig{
+		{Path: "/", ServiceName: "web", ServicePort: "80"},
+		{Path: "/admin", ServiceName: "web", ServicePort: "8443"},
+		{Path: "/api", ServiceName: "api", ServicePort: "80"},
+	}})
+
+	hpa := components.NewHorizontalPodAutoscaler(api.HPAConfig{Name: "web", Namespace: "shop", MaxReplicas: 3, ScaleTargetKind: "Deployment", ScaleTargetName: "web", ScaleTargetAPIVersion: "apps/v1"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, cm)
+	d.AddComponent(api.PodComponent, pod)
+	d.AddComponent(api.RoleBindingComponent, rb)
+	d.AddComponent(api.RoleBindingComponent, viewer)
+	d.AddComponent(api.ServiceComponent, svc)
+	d.AddComponent(api.IngressComponent, ing)
+	d.AddComponent(api.HorizontalPodAutoscalerComponent, hpa)
+	d.MarkExternal(api.PersistentVolumeClaimComponent, "shop", "cache")
+
+	expected := []string{
+		"web: roleRef: Role shop/web not found",
+		"web: spec.containers[0].env[1].valueFrom.configMapKeyRef: key level not found in ConfigMap shop/settings",
+		"web: spec.containers[0].env[2].valueFrom.secretKeyRef: Secret shop/vault not found",
+		"web: spec.containers[0].volumeMounts[1]: volume data not declared by the pod",
+		"web: spec.rules[0].http.paths[1].backend: port 8443 not found in Service shop/web",
+		"web: spec.rules[0].http.paths[2].backend: Service shop/api not found",
+		"web: spec.scaleTargetRef: Deployment shop/web not found",
+		"web: spec.serviceAccountName: ServiceAccount shop/web not found",
+	}
+	actual := referenceErrors(t, d.CheckReferences())
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected errors:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestCheckReferencesResolved(t *testing.T) {
+	claim, err := components.NewPersistentVolumeClaim(api.PVCConfig{Name: "data", Namespace: "shop", Size: "1Gi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template, err := components.NewPersistentVolumeClaim(api.PVCConfig{Name: "state", Size: "1Gi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, _ := components.NewContainer(api.ContainerConfig{Name: "db", Image: "db"})
+	c.AddVolumeMount(api.VolumeMountConfig{Name: "data", MountPath: "/data"})
+	c.AddVolumeMount(api.VolumeMountConfig{Name: "state", MountPath: "/state"})
+	pod := components.NewPod(api.PodConfig{Name: "db", Namespace: "shop"})
+	pod.AddContainer(c)
+	pod.AddVolume(components.NewPVCVolume(api.PVCVolumeConfig{VolumeName: "data", PVCName: "data"}))
+	ss := components.NewStatefulSet(api.StatefulSetConfig{Name: "db", Namespace: "shop"})
+	ss.AddPod(pod)
+	ss.AddVolumeClaimTemplate(*template)
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.PersistentVolumeClaimComponent, claim)
+	d.AddComponent(api.StatefulSetComponent, ss)
+	if err := d.CheckReferences(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
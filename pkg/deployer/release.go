@@ -0,0 +1,348 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	releaseOwnerLabel    = "owner"
+	releaseNameLabel     = "release"
+	releaseRevisionLabel = "revision"
+	releaseOwner         = "horizon"
+	releaseDataKey       = "release"
+)
+
+// Release defines a release that was recorded in the cluster
+type Release struct {
+	Name        string    `json:"name"`
+	Revision    int       `json:"revision"`
+	Timestamp   time.Time `json:"timestamp"`
+	AppVersion  string    `json:"appVersion,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Manifest    string    `json:"manifest"`
+}
+
+// SetReleaseConfig configures Run to record every successful deploy as
+// a new release, which can later be returned to with Rollback
+func (d *Deployer) SetReleaseConfig(config api.ReleaseConfig) {
+	d.releaseConfig = &config
+}
+
+// History returns the releases recorded in the cluster, oldest first
+func (d *Deployer) History() ([]Release, error) {
+	if d.exporterOnly() {
+		return nil, fmt.Errorf("deployer has no clients defined and can only be used to export")
+	}
+	if d.releaseConfig == nil {
+		return nil, fmt.Errorf("deployer has no release configuration")
+	}
+
+	return d.loadReleases()
+}
+
+// Rollback deploys the manifest of a previous release, removes the components
+// that are not part of it and records the result as a new release
+func (d *Deployer) Rollback(revision int) (err error) {
+	releases, err := d.History()
+	if err != nil {
+		return err
+	}
+
+	var target *Release
+	for i := range releases {
+		if releases[i].Revision == revision {
+			target = &releases[i]
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("release %s has no revision %d", d.releaseConfig.Name, revision)
+	}
+
+	rollback := d.withComponents(map[api.ComponentType][]api.DeployableComponentInterface{})
+	if err := rollback.addManifest(target.Manifest); err != nil {
+		return fmt.Errorf("unable to load revision %d: %v", revision, err)
+	}
+
+	d.observers.start(rollback.componentCount())
+	defer func() {
+		d.observers.finish(err)
+	}()
+
+	if err := rollback.deploy(context.Background()); err != nil {
+		return err
+	}
+
+	if len(releases) > 0 {
+		current := d.withComponents(map[api.ComponentType][]api.DeployableComponentInterface{})
+		if err := current.addManifest(releases[len(releases)-1].Manifest); err != nil {
+			return fmt.Errorf("unable to load the current revision: %v", err)
+		}
+		if err := current.removeMissing(rollback); err != nil {
+			return err
+		}
+	}
+
+	err = rollback.recordRelease(fmt.Sprintf("Rollback to %d", revision))
+	d.observers.phaseError(api.DeployPhaseRelease, err)
+	return err
+}
+
+// checkReleaseStorage returns an error if the releases would be stored in config
+// maps while the deployer has secrets, which would leave the secret data readable
+// by anyone allowed to read config maps
+func (d *Deployer) checkReleaseStorage() error {
+	if d.releaseConfig == nil || d.releaseConfig.Storage != api.ReleaseStorageConfigMap {
+		return nil
+	}
+	if secrets := d.components[api.SecretComponent]; len(secrets) > 0 {
+		return fmt.Errorf("release %s can't be stored in config maps since it has secrets, such as %s", d.releaseConfig.Name, secrets[0].GetName())
+	}
+	return nil
+}
+
+// withComponents returns a deployer with the same configuration and the given components
+func (d *Deployer) withComponents(comps map[api.ComponentType][]api.DeployableComponentInterface) *Deployer {
+	copy := *d
+	copy.components = comps
+	copy.dependencies = nil
+	return &copy
+}
+
+// addManifest adds the components of a YAML manifest to the deployer
+func (d *Deployer) addManifest(manifest string) error {
+	return d.Import(strings.NewReader(manifest))
+}
+
+// removeMissing undeploys the components that are not part of the other deployer
+func (d *Deployer) removeMissing(other *Deployer) error {
+	keep := map[string]bool{}
+	for ct, comps := range other.components {
+		for _, c := range comps {
+			keep[keyOf(ct, c)] = true
+		}
+	}
+
+	remove := map[api.ComponentType][]api.DeployableComponentInterface{}
+	for ct, comps := range d.components {
+		for _, c := range comps {
+			if !keep[keyOf(ct, c)] {
+				remove[ct] = append(remove[ct], c)
+			}
+		}
+	}
+
+	return d.withComponents(remove).undeploy(context.Background(), api.DeployPhasePrune)
+}
+
+// manifest renders the components as a YAML stream in deploy order
+func (d *Deployer) manifest() (string, error) {
+	buf := &bytes.Buffer{}
+	if err := d.ExportYAML(buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// recordRelease stores the components of the deployer as a new release
+func (d *Deployer) recordRelease(description string) error {
+	if err := d.checkReleaseStorage(); err != nil {
+		return err
+	}
+
+	manifest, err := d.manifest()
+	if err != nil {
+		return err
+	}
+
+	releases, err := d.loadReleases()
+	if err != nil {
+		return err
+	}
+
+	release := Release{
+		Name:        d.releaseConfig.Name,
+		Revision:    1,
+		Timestamp:   time.Now().UTC(),
+		AppVersion:  d.releaseConfig.AppVersion,
+		Description: description,
+		Manifest:    manifest,
+	}
+	if len(releases) > 0 {
+		release.Revision = releases[len(releases)-1].Revision + 1
+	}
+	if len(release.Description) == 0 {
+		release.Description = "Install"
+		if release.Revision > 1 {
+			release.Description = "Upgrade"
+		}
+	}
+
+	data, err := encodeRelease(release)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("recording revision %d of release %s", release.Revision, release.Name)
+	objectMeta := metav1.ObjectMeta{
+		Name:      fmt.Sprintf("%s.v%d", release.Name, release.Revision),
+		Namespace: d.releaseConfig.Namespace,
+		Labels: map[string]string{
+			releaseOwnerLabel:    releaseOwner,
+			releaseNameLabel:     release.Name,
+			releaseRevisionLabel: strconv.Itoa(release.Revision),
+		},
+	}
+	if d.releaseConfig.Storage == api.ReleaseStorageConfigMap {
+		cm := &v1.ConfigMap{ObjectMeta: objectMeta, Data: map[string]string{releaseDataKey: data}}
+		_, err = d.client.CoreV1().ConfigMaps(objectMeta.Namespace).Create(cm)
+	} else {
+		secret := &v1.Secret{ObjectMeta: objectMeta, Data: map[string][]byte{releaseDataKey: []byte(data)}}
+		_, err = d.client.CoreV1().Secrets(objectMeta.Namespace).Create(secret)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to record release %s: %v", release.Name, err)
+	}
+
+	return d.pruneReleases(append(releases, release))
+}
+
+// pruneReleases removes the oldest releases that exceed the maximum history
+func (d *Deployer) pruneReleases(releases []Release) error {
+	max := d.releaseConfig.MaxHistory
+	if max <= 0 || len(releases) <= max {
+		return nil
+	}
+
+	for _, r := range releases[:len(releases)-max] {
+		name := fmt.Sprintf("%s.v%d", r.Name, r.Revision)
+		var err error
+		if d.releaseConfig.Storage == api.ReleaseStorageConfigMap {
+			err = d.client.CoreV1().ConfigMaps(d.releaseConfig.Namespace).Delete(name, &metav1.DeleteOptions{})
+		} else {
+			err = d.client.CoreV1().Secrets(d.releaseConfig.Namespace).Delete(name, &metav1.DeleteOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("unable to remove revision %d of release %s: %v", r.Revision, r.Name, err)
+		}
+	}
+	return nil
+}
+
+// loadReleases reads the releases stored in the cluster, sorted by revision
+func (d *Deployer) loadReleases() ([]Release, error) {
+	selector := fmt.Sprintf("%s=%s,%s=%s", releaseOwnerLabel, releaseOwner, releaseNameLabel, d.releaseConfig.Name)
+	opts := metav1.ListOptions{LabelSelector: selector}
+
+	data := [][]byte{}
+	if d.releaseConfig.Storage == api.ReleaseStorageConfigMap {
+		list, err := d.client.CoreV1().ConfigMaps(d.releaseConfig.Namespace).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, cm := range list.Items {
+			data = append(data, []byte(cm.Data[releaseDataKey]))
+		}
+	} else {
+		list, err := d.client.CoreV1().Secrets(d.releaseConfig.Namespace).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range list.Items {
+			data = append(data, secret.Data[releaseDataKey])
+		}
+	}
+
+	releases := []Release{}
+	for _, b := range data {
+		release, err := decodeRelease(b)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read release %s: %v", d.releaseConfig.Name, err)
+		}
+		releases = append(releases, *release)
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Revision < releases[j].Revision
+	})
+	return releases, nil
+}
+
+// encodeRelease encodes the release like helm does, as base64 encoded gzipped JSON
+func encodeRelease(release Release) (string, error) {
+	data, err := json.Marshal(release)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeRelease decodes a release encoded by encodeRelease.  Releases stored as
+// plain JSON by earlier versions are also accepted
+func decodeRelease(data []byte) (*Release, error) {
+	release := &Release{}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return release, json.Unmarshal(data, release)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err = ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return release, json.Unmarshal(data, release)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	d := NewDeployerExporter()
+	d.AddComponent(api.NamespaceComponent, components.NewNamespace(api.NamespaceConfig{Name: "ns"}))
+	cm := components.NewConfigMap(api.ConfigMapConfig{Name: "cfg", Namespace: "ns"})
+	cm.AddData(map[string]string{"key": "value"})
+	d.AddComponent(api.ConfigMapComponent, cm)
+
+	manifest, err := d.manifest()
+	if err != nil {
+		t.Fatalf("unable to render the manifest: %v", err)
+	}
+
+	loaded := d.withComponents(map[api.ComponentType][]api.DeployableComponentInterface{})
+	if err := loaded.addManifest(manifest); err != nil {
+		t.Fatalf("unable to load the manifest: %v", err)
+	}
+
+	if len(loaded.components[api.NamespaceComponent]) != 1 || len(loaded.components[api.ConfigMapComponent]) != 1 {
+		t.Fatalf("expected a namespace and a config map, got %v", loaded.components)
+	}
+	loadedCM := loaded.components[api.ConfigMapComponent][0].(*components.ConfigMap)
+	if loadedCM.Namespace != "ns" || loadedCM.Data["key"] != "value" {
+		t.Errorf("unexpected config map %+v", loadedCM.ConfigMap)
+	}
+
+	reloaded, err := loaded.manifest()
+	if err != nil || reloaded != manifest {
+		t.Errorf("expected the manifest to be unchanged, got %s %v", reloaded, err)
+	}
+}
+
+func TestReleaseEncoding(t *testing.T) {
+	release := Release{Name: "app", Revision: 2, Manifest: "kind: ConfigMap\n"}
+	data, err := encodeRelease(release)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(data, "ConfigMap") {
+		t.Errorf("expected the release to be compressed, got %s", data)
+	}
+
+	for _, encoded := range []string{data, `{"name":"app","revision":2,"manifest":"kind: ConfigMap\n"}`} {
+		decoded, err := decodeRelease([]byte(encoded))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if decoded.Name != release.Name || decoded.Revision != release.Revision || decoded.Manifest != release.Manifest {
+			t.Errorf("expected %+v, got %+v", release, decoded)
+		}
+	}
+}
+
+func TestReleaseStorageWithSecrets(t *testing.T) {
+	d := NewDeployerExporter()
+	d.AddComponent(api.SecretComponent, components.NewSecret(api.SecretConfig{Name: "creds", Namespace: "ns"}))
+	d.SetReleaseConfig(api.ReleaseConfig{Name: "app", Namespace: "ns", Storage: api.ReleaseStorageConfigMap})
+	if err := d.checkReleaseStorage(); err == nil || !strings.Contains(err.Error(), "creds") {
+		t.Errorf("expected config map storage to be rejected, got %v", err)
+	}
+
+	d.SetReleaseConfig(api.ReleaseConfig{Name: "app", Namespace: "ns", Storage: api.ReleaseStorageSecret})
+	if err := d.checkReleaseStorage(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SetTransactional configures Run to roll back the changes it made to the cluster
+// if any component fails.  Components created by the run are removed and components
+// updated by the run are restored to their previous version, in reverse order
+func (d *Deployer) SetTransactional(transactional bool) {
+	d.transactional = transactional
+}
+
+type transactionEntry struct {
+	kind      api.ComponentType
+	component api.DeployableComponentInterface
+	action    api.ApplyActionType
+	previous  runtime.Object
+}
+
+// transaction records the changes a run made to the cluster.  Once it has been
+// rolled back it is closed, and changes made by components that were still in
+// flight are reverted as soon as they finish instead of being recorded
+type transaction struct {
+	lock      sync.Mutex
+	entries   []transactionEntry
+	closed    bool
+	observers *observerList
+}
+
+// apply deploys the component and records the change that was made
+func (t *transaction) apply(ctx context.Context, kind api.ComponentType, c api.DeployableComponentInterface, res api.DeployerResources) error {
+	change, err := components.Apply(ctx, c, res)
+	if err != nil {
+		return err
+	}
+
+	if change.Action != api.ApplyActionUnchanged {
+		return t.record(transactionEntry{kind: kind, component: c, action: change.Action, previous: change.Previous}, res)
+	}
+	return nil
+}
+
+// record adds the change to the transaction.  If the transaction was already
+// rolled back the change is reverted and an error is returned
+func (t *transaction) record(e transactionEntry, res api.DeployerResources) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.closed {
+		t.entries = append(t.entries, e)
+		return nil
+	}
+
+	log.Warnf("%s %s was deployed after the run was rolled back, reverting it", e.kind, e.component.GetName())
+	if err := e.revert(context.Background(), res); err != nil {
+		return fmt.Errorf("%s was deployed after the run was rolled back and couldn't be reverted: %v", e.component.GetName(), err)
+	}
+	return fmt.Errorf("%s was deployed after the run was rolled back and was reverted", e.component.GetName())
+}
+
+// revert undoes the change recorded by the entry
+func (e transactionEntry) revert(ctx context.Context, res api.DeployerResources) error {
+	switch e.action {
+	case api.ApplyActionCreate:
+		log.Infof("rolling back %s %s by deleting it", e.kind, e.component.GetName())
+		return e.component.Undeploy(ctx, res)
+	case api.ApplyActionUpdate:
+		log.Infof("rolling back %s %s to its previous version", e.kind, e.component.GetName())
+		return components.Restore(ctx, e.component, res, e.previous)
+	}
+	return nil
+}
+
+// rollback reverts the recorded changes in reverse order and closes the transaction
+func (t *transaction) rollback(ctx context.Context, res api.DeployerResources) map[api.ComponentType][]error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.closed = true
+
+	allErrs := map[api.ComponentType][]error{}
+	for cnt := len(t.entries) - 1; cnt >= 0; cnt-- {
+		e := t.entries[cnt]
+		start := time.Now()
+		t.observers.componentStart(api.DeployPhaseRollback, e.kind, e.component.GetName())
+		err := e.revert(ctx, res)
+		t.observers.componentDone(api.DeployPhaseRollback, e.kind, e.component.GetName(), start, err)
+		if err != nil {
+			allErrs[e.kind] = append(allErrs[e.kind], fmt.Errorf("unable to roll back %s: %v", e.component.GetName(), err))
+		}
+	}
+	t.entries = nil
+	return allErrs
+}
+
+// deployComponent deploys the component, recording the change in the transaction
+// if there is one.  Components that can't be compared against the cluster are
+// deployed without being recorded since the change they make is unknown
+func deployComponent(ctx context.Context, kind api.ComponentType, c api.DeployableComponentInterface, res api.DeployerResources, tx *transaction) error {
+	if tx == nil {
+		return c.Deploy(ctx, res)
+	}
+
+	if !components.CanApply(c) {
+		log.Warnf("%s %s can't be rolled back", kind, c.GetName())
+		return c.Deploy(ctx, res)
+	}
+	return tx.apply(ctx, kind, c, res)
+}
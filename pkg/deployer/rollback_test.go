@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+)
+
+func TestTransactionRollback(t *testing.T) {
+	comps, _ := newRecordingComponents("a", "b", "c")
+	tx := &transaction{}
+	for _, n := range []string{"a", "b", "c"} {
+		tx.entries = append(tx.entries, transactionEntry{kind: api.ConfigMapComponent, component: comps[n], action: api.ApplyActionCreate})
+	}
+
+	errs := tx.rollback(context.Background(), api.DeployerResources{})
+	if len(errs) > 0 {
+		t.Errorf("unexpected rollback errors: %v", errs)
+	}
+
+	undeployed := strings.Join(*comps["a"].undeployed, ",")
+	if undeployed != "c,b,a" {
+		t.Errorf("expected components to be removed in reverse order, got %s", undeployed)
+	}
+}
+
+func TestTransactionRecordAfterRollback(t *testing.T) {
+	comps, _ := newRecordingComponents("a", "late")
+	tx := &transaction{}
+	tx.entries = append(tx.entries, transactionEntry{kind: api.ConfigMapComponent, component: comps["a"], action: api.ApplyActionCreate})
+	if errs := tx.rollback(context.Background(), api.DeployerResources{}); len(errs) > 0 {
+		t.Fatalf("unexpected rollback errors: %v", errs)
+	}
+
+	err := tx.record(transactionEntry{kind: api.ConfigMapComponent, component: comps["late"], action: api.ApplyActionCreate}, api.DeployerResources{})
+	if err == nil || !strings.Contains(err.Error(), "after the run was rolled back") {
+		t.Errorf("expected the late change to be refused, got %v", err)
+	}
+	if len(tx.entries) != 0 {
+		t.Errorf("expected the late change not to be recorded, got %v", tx.entries)
+	}
+
+	undeployed := strings.Join(*comps["a"].undeployed, ",")
+	if undeployed != "a,late" {
+		t.Errorf("expected the late change to be reverted, got %s", undeployed)
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// SelectorError defines a problem with the pods selected by a component
+type SelectorError struct {
+	Kind   api.ComponentType
+	Name   string
+	Field  string
+	Reason string
+}
+
+func (e *SelectorError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Name, e.Field, e.Reason)
+}
+
+// podTemplate defines the pods created by a component
+type podTemplate struct {
+	kind      api.ComponentType
+	component api.DeployableComponentInterface
+	namespace string
+	labels    labels.Set
+	spec      *v1.PodSpec
+}
+
+func (t *podTemplate) String() string {
+	return fmt.Sprintf("%s %s/%s", t.kind, t.namespace, t.component.GetName())
+}
+
+// CheckSelectors evaluates the selectors of the services, controllers, pod
+// disruption budgets and network policies against the pods of the deployer.  A
+// controller must select the pods of its template and only them, and a service
+// must select the pods of exactly one component, which must have a container port
+// named after each named target port of the service.  A pod disruption budget must
+// select some pods, none of which may be selected by another budget, and a budget
+// given as a percentage or a maximum of unavailable pods can't select bare pods.
+// The pod selectors of a network policy and of the peers of its rules in its own
+// namespace must select some pods.  The problems are returned as SelectorErrors
+func (d *Deployer) CheckSelectors() error {
+	templates := []*podTemplate{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			if t := podTemplateOf(ct, c); t != nil {
+				templates = append(templates, t)
+			}
+		}
+	}
+
+	allErrs := map[api.ComponentType][]error{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			var errs []error
+			if s, ok := c.(*components.Service); ok {
+				errs = checkServiceSelector(s, templates)
+			} else if pdb, ok := c.(*components.PodDisruptionBudget); ok {
+				errs = checkDisruptionBudgetSelector(pdb, d.components[api.PodDisruptionBudgetComponent], templates)
+			} else if np, ok := c.(*components.NetworkPolicy); ok {
+				errs = checkNetworkPolicySelectors(np, templates)
+			} else if selector, ok := controllerSelector(c); ok {
+				errs = checkControllerSelector(ct, c, selector, templates)
+			}
+			if len(errs) > 0 {
+				allErrs[ct] = append(allErrs[ct], errs...)
+			}
+		}
+	}
+	return utilserror.NewDeployErrors(allErrs)
+}
+
+// podTemplateOf returns the pods created by a component, or nil if it doesn't
+// create any
+func podTemplateOf(kind api.ComponentType, c api.DeployableComponentInterface) *podTemplate {
+	spec := components.GetPodSpec(c)
+	if spec == nil {
+		return nil
+	}
+	t := &podTemplate{kind: kind, component: c, namespace: namespaceOf(c), spec: spec}
+	switch obj := c.(type) {
+	case *components.Pod:
+		t.labels = obj.Labels
+	case *components.Deployment:
+		t.labels = obj.Spec.Template.Labels
+	case *components.StatefulSet:
+		t.labels = obj.Spec.Template.Labels
+	case *components.DaemonSet:
+		t.labels = obj.Spec.Template.Labels
+	case *components.Job:
+		t.labels = obj.Spec.Template.Labels
+	case *components.ReplicationController:
+		if obj.Spec.Template != nil {
+			t.labels = obj.Spec.Template.Labels
+		}
+	default:
+		return nil
+	}
+	return t
+}
+
+// controllerSelector returns the selector of the controllers that keep a
+// number of pods running.  A replication controller without a selector uses
+// the labels of its template
+func controllerSelector(c api.DeployableComponentInterface) (*metav1.LabelSelector, bool) {
+	switch obj := c.(type) {
+	case *components.Deployment:
+		return obj.Spec.Selector, true
+	case *components.StatefulSet:
+		return obj.Spec.Selector, true
+	case *components.DaemonSet:
+		return obj.Spec.Selector, true
+	case *components.ReplicationController:
+		selector := obj.Spec.Selector
+		if len(selector) == 0 && obj.Spec.Template != nil {
+			selector = obj.Spec.Template.Labels
+		}
+		return &metav1.LabelSelector{MatchLabels: selector}, true
+	}
+	return nil, false
+}
+
+func checkControllerSelector(kind api.ComponentType, c api.DeployableComponentInterface, selector *metav1.LabelSelector, templates []*podTemplate) []error {
+	errs := []error{}
+	report := func(format string, args ...interface{}) {
+		errs = append(errs, &SelectorError{Kind: kind, Name: c.GetName(), Field: "spec.selector", Reason: fmt.Sprintf(format, args...)})
+	}
+
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		report("selector is empty")
+		return errs
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		report("invalid selector: %v", err)
+		return errs
+	}
+
+	for _, t := range templates {
+		if t.component == c {
+			if !s.Matches(t.labels) {
+				report("selector doesn't match the labels of the pod template")
+			}
+		} else if t.namespace == namespaceOf(c) && s.Matches(t.labels) {
+			report("selector also matches the pods of %s", t)
+		}
+	}
+	return errs
+}
+
+func checkServiceSelector(s *components.Service, templates []*podTemplate) []error {
+	errs := []error{}
+	report := func(field string, format string, args ...interface{}) {
+		errs = append(errs, &SelectorError{Kind: api.ServiceComponent, Name: s.GetName(), Field: field, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	// services without selectors have their endpoints managed by other means
+	if len(s.Spec.Selector) == 0 {
+		return errs
+	}
+	selector := labels.SelectorFromSet(s.Spec.Selector)
+	selected := []*podTemplate{}
+	for _, t := range templates {
+		if t.namespace == s.Namespace && selector.Matches(t.labels) {
+			selected = append(selected, t)
+		}
+	}
+
+	switch len(selected) {
+	case 0:
+		report("spec.selector", "selector doesn't match any pods")
+		return errs
+	case 1:
+	default:
+		names := []string{}
+		for _, t := range selected {
+			names = append(names, t.String())
+		}
+		report("spec.selector", "selector matches the pods of %s", strings.Join(names, ", "))
+	}
+
+	for i, port := range s.Spec.Ports {
+		if port.TargetPort.Type != intstr.String || len(port.TargetPort.StrVal) == 0 {
+			continue
+		}
+		for _, t := range selected {
+			if !hasContainerPort(t.spec, port.TargetPort.StrVal) {
+				report(fmt.Sprintf("spec.ports[%d].targetPort", i), "port %s not found in the containers of %s", port.TargetPort.StrVal, t)
+			}
+		}
+	}
+	return errs
+}
+
+func checkDisruptionBudgetSelector(pdb *components.PodDisruptionBudget, budgets []api.DeployableComponentInterface, templates []*podTemplate) []error {
+	errs := []error{}
+	report := func(format string, args ...interface{}) {
+		errs = append(errs, &SelectorError{Kind: api.PodDisruptionBudgetComponent, Name: pdb.GetName(), Field: "spec.selector", Reason: fmt.Sprintf(format, args...)})
+	}
+
+	// the disruption controller ignores budgets with empty selectors
+	selector := pdb.Spec.Selector
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		report("selector is empty")
+		return errs
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		report("invalid selector: %v", err)
+		return errs
+	}
+
+	// the budget needs the scale of the controllers unless it is a number of available pods
+	needsScale := pdb.Spec.MaxUnavailable != nil || (pdb.Spec.MinAvailable != nil && pdb.Spec.MinAvailable.Type == intstr.String)
+	selected := 0
+	for _, t := range templates {
+		if t.namespace != pdb.Namespace || !s.Matches(t.labels) {
+			continue
+		}
+		selected++
+		if needsScale && t.kind == api.PodComponent {
+			report("selector matches the pods of %s, which have no controller to scale the budget with", t)
+		}
+		for _, b := range budgets {
+			other, ok := b.(*components.PodDisruptionBudget)
+			if !ok || other == pdb || other.Namespace != pdb.Namespace || other.Spec.Selector == nil {
+				continue
+			}
+			if o, err := metav1.LabelSelectorAsSelector(other.Spec.Selector); err == nil && !o.Empty() && o.Matches(t.labels) {
+				report("pods of %s are also selected by PodDisruptionBudget %s, which prevents evicting them", t, other.Name)
+			}
+		}
+	}
+	if selected == 0 {
+		report("selector doesn't match any pods")
+	}
+	return errs
+}
+
+func checkNetworkPolicySelectors(np *components.NetworkPolicy, templates []*podTemplate) []error {
+	errs := []error{}
+	check := func(field string, selector *metav1.LabelSelector) {
+		// an empty selector selects all the pods of the namespace
+		if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+			return
+		}
+		s, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			errs = append(errs, &SelectorError{Kind: api.NetworkPolicyComponent, Name: np.GetName(), Field: field, Reason: fmt.Sprintf("invalid selector: %v", err)})
+			return
+		}
+		for _, t := range templates {
+			if t.namespace == np.Namespace && s.Matches(t.labels) {
+				return
+			}
+		}
+		errs = append(errs, &SelectorError{Kind: api.NetworkPolicyComponent, Name: np.GetName(), Field: field, Reason: "selector doesn't match any pods"})
+	}
+	checkPeers := func(field string, peers []networkingv1.NetworkPolicyPeer) {
+		for i, peer := range peers {
+			// peers with a namespace selector select pods of other namespaces
+			if peer.PodSelector != nil && peer.NamespaceSelector == nil {
+				check(fmt.Sprintf("%s[%d].podSelector", field, i), peer.PodSelector)
+			}
+		}
+	}
+
+	check("spec.podSelector", &np.Spec.PodSelector)
+	for i, rule := range np.Spec.Ingress {
+		checkPeers(fmt.Sprintf("spec.ingress[%d].from", i), rule.From)
+	}
+	for i, rule := range np.Spec.Egress {
+		checkPeers(fmt.Sprintf("spec.egress[%d].to", i), rule.To)
+	}
+	return errs
+}
+
+// hasContainerPort returns true if a container of the pod declares a port
+// with the name
+func hasContainerPort(spec *v1.PodSpec, name string) bool {
+	for _, c := range spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func namespaceOf(c api.DeployableComponentInterface) string {
+	accessor, err := meta.Accessor(c)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetNamespace()
+}
@@ -0,0 +1,215 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+)
+
+func newSelectorPod(name string, labels map[string]string, ports ...string) *components.Pod {
+	c, _ := components.NewContainer(api.ContainerConfig{Name: name, Image: name})
+	for i, p := range ports {
+		c.AddPort(api.PortConfig{Name: p, ContainerPort: int32(8080 + i)})
+	}
+	pod := components.NewPod(api.PodConfig{Name: name, Namespace: "shop"})
+	pod.AddLabels(labels)
+	pod.AddContainer(c)
+	return pod
+}
+
+func selectorErrors(t *testing.T, err error) []string {
+	if err == nil {
+		return nil
+	}
+	deployErrs, ok := err.(utilserror.DeployErrors)
+	if !ok {
+		t.Fatalf("expected DeployErrors, got %v", err)
+	}
+	msgs := []string{}
+	for _, errs := range deployErrs.Errors() {
+		for _, err := range errs {
+			if _, ok := err.(*SelectorError); !ok {
+				t.Errorf("expected a SelectorError, got %T", err)
+			}
+			msgs = append(msgs, err.Error())
+		}
+	}
+	sort.Strings(msgs)
+	return msgs
+}
+
+func TestCheckSelectors(t *testing.T) {
+	web := components.NewDeployment(api.DeploymentConfig{Name: "web", Namespace: "shop"})
+	web.AddMatchLabelsSelectors(map[string]string{"app": "shop"})
+	web.AddPod(newSelectorPod("web", map[string]string{"app": "shop", "tier": "web"}, "http"))
+
+	worker := components.NewDeployment(api.DeploymentConfig{Name: "worker", Namespace: "shop"})
+	worker.AddMatchLabelsSelectors(map[string]string{"app": "worker"})
+	worker.AddPod(newSelectorPod("worker", map[string]string{"app": "shop", "tier": "worker"}))
+
+	db := components.NewStatefulSet(api.StatefulSetConfig{Name: "db", Namespace: "shop"})
+	db.AddMatchLabelsSelectors(map[string]string{"app": "db"})
+	db.AddPod(newSelectorPod("db", map[string]string{"app": "db"}, "postgres"))
+
+	agent := components.NewDaemonSet(api.DaemonSetConfig{Name: "agent", Namespace: "shop"})
+	agent.AddPod(newSelectorPod("agent", map[string]string{"app": "agent"}))
+
+	webSvc := components.NewService(api.ServiceConfig{Name: "web", Namespace: "shop"})
+	webSvc.AddSelectors(map[string]string{"app": "shop", "tier": "web"})
+	webSvc.AddPort(api.ServicePortConfig{Name: "http", Port: 80, TargetPort: "http"})
+	webSvc.AddPort(api.ServicePortConfig{Name: "https", Port: 443, TargetPort: "https"})
+
+	shopSvc := components.NewService(api.ServiceConfig{Name: "shop", Namespace: "shop"})
+	shopSvc.AddSelectors(map[string]string{"app": "shop"})
+
+	cacheSvc := components.NewService(api.ServiceConfig{Name: "cache", Namespace: "shop"})
+	cacheSvc.AddSelectors(map[string]string{"app": "cache"})
+
+	dbSvc := components.NewService(api.ServiceConfig{Name: "db", Namespace: "shop"})
+	dbSvc.AddSelectors(map[string]string{"app": "db"})
+	dbSvc.AddPort(api.ServicePortConfig{Name: "postgres", Port: 5432, TargetPort: "postgres"})
+
+	external := components.NewService(api.ServiceConfig{Name: "external", Namespace: "shop"})
+
+	d := NewDeployerExporter()
+	for _, c := range []api.DeployableComponentInterface{webSvc, shopSvc, cacheSvc, dbSvc, external} {
+		d.AddComponent(api.ServiceComponent, c)
+	}
+	d.AddComponent(api.DeploymentComponent, web)
+	d.AddComponent(api.DeploymentComponent, worker)
+	d.AddComponent(api.StatefulSetComponent, db)
+	d.AddComponent(api.DaemonSetComponent, agent)
+	d.AddComponent(api.PodComponent, newSelectorPod("debug", map[string]string{"app": "shop", "tier": "debug"}))
+
+	expected := []string{
+		"agent: spec.selector: selector is empty",
+		"cache: spec.selector: selector doesn't match any pods",
+		"shop: spec.selector: selector matches the pods of Pod shop/debug, Deployment shop/web, Deployment shop/worker",
+		"web: spec.ports[1].targetPort: port https not found in the containers of Deployment shop/web",
+		"web: spec.selector: selector also matches the pods of Deployment shop/worker",
+		"web: spec.selector: selector also matches the pods of Pod shop/debug",
+		"worker: spec.selector: selector doesn't match the labels of the pod template",
+	}
+	actual := selectorErrors(t, d.CheckSelectors())
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected errors:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestCheckSelectorsReplicationController(t *testing.T) {
+	rc := components.NewReplicationController(api.ReplicationControllerConfig{Name: "web", Namespace: "shop"})
+	rc.AddPod(newSelectorPod("web", map[string]string{"app": "web"}, "http"))
+	svc := components.NewService(api.ServiceConfig{Name: "web", Namespace: "shop"})
+	svc.AddSelectors(map[string]string{"app": "web"})
+	svc.AddPort(api.ServicePortConfig{Port: 80, TargetPort: "http"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.ReplicationControllerComponent, rc)
+	d.AddComponent(api.ServiceComponent, svc)
+	if err := d.CheckSelectors(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	rc.AddSelectors(map[string]string{"app": "api"})
+	expected := []string{
+		"web: spec.selector: selector doesn't match the labels of the pod template",
+	}
+	actual := selectorErrors(t, d.CheckSelectors())
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected errors:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestCheckSelectorsDisruptionBudgets(t *testing.T) {
+	web := components.NewDeployment(api.DeploymentConfig{Name: "web", Namespace: "shop"})
+	web.AddMatchLabelsSelectors(map[string]string{"app": "web"})
+	web.AddPod(newSelectorPod("web", map[string]string{"app": "web", "tier": "front"}))
+
+	webPDB := components.NewPodDisruptionBudget(api.PodDisruptionBudgetConfig{Name: "web", Namespace: "shop", MinAvailable: "1"})
+	webPDB.AddMatchLabelsSelectors(map[string]string{"app": "web"})
+	frontPDB := components.NewPodDisruptionBudget(api.PodDisruptionBudgetConfig{Name: "front", Namespace: "shop", MaxUnavailable: "1"})
+	frontPDB.AddMatchLabelsSelectors(map[string]string{"tier": "front"})
+	debugPDB := components.NewPodDisruptionBudget(api.PodDisruptionBudgetConfig{Name: "debug", Namespace: "shop", MinAvailable: "50%"})
+	debugPDB.AddMatchLabelsSelectors(map[string]string{"app": "debug"})
+	cachePDB := components.NewPodDisruptionBudget(api.PodDisruptionBudgetConfig{Name: "cache", Namespace: "shop", MinAvailable: "1"})
+	cachePDB.AddMatchLabelsSelectors(map[string]string{"app": "cache"})
+	emptyPDB := components.NewPodDisruptionBudget(api.PodDisruptionBudgetConfig{Name: "empty", Namespace: "shop", MinAvailable: "1"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.DeploymentComponent, web)
+	d.AddComponent(api.PodComponent, newSelectorPod("debug", map[string]string{"app": "debug"}))
+	for _, c := range []api.DeployableComponentInterface{webPDB, frontPDB, debugPDB, cachePDB, emptyPDB} {
+		d.AddComponent(api.PodDisruptionBudgetComponent, c)
+	}
+
+	expected := []string{
+		"cache: spec.selector: selector doesn't match any pods",
+		"debug: spec.selector: selector matches the pods of Pod shop/debug, which have no controller to scale the budget with",
+		"empty: spec.selector: selector is empty",
+		"front: spec.selector: pods of Deployment shop/web are also selected by PodDisruptionBudget web, which prevents evicting them",
+		"web: spec.selector: pods of Deployment shop/web are also selected by PodDisruptionBudget front, which prevents evicting them",
+	}
+	actual := selectorErrors(t, d.CheckSelectors())
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected errors:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestCheckSelectorsNetworkPolicies(t *testing.T) {
+	web := components.NewDeployment(api.DeploymentConfig{Name: "web", Namespace: "shop"})
+	web.AddMatchLabelsSelectors(map[string]string{"app": "web"})
+	web.AddPod(newSelectorPod("web", map[string]string{"app": "web"}))
+
+	denyAll := components.NewNetworkPolicy(api.NetworkPolicyConfig{Name: "deny-all", Namespace: "shop"})
+
+	np := components.NewNetworkPolicy(api.NetworkPolicyConfig{Name: "web", Namespace: "shop", PodSelector: api.SelectorConfig{Labels: map[string]string{"app": "web"}}})
+	np.AddIngressRule(api.NetworkPolicyRuleConfig{Peers: []api.NetworkPolicyPeerConfig{
+		{PodSelector: &api.SelectorConfig{Labels: map[string]string{"app": "proxy"}}},
+		{PodSelector: &api.SelectorConfig{Labels: map[string]string{"app": "monitor"}}, NamespaceSelector: &api.SelectorConfig{}},
+	}})
+	np.AddEgressRule(api.NetworkPolicyRuleConfig{Peers: []api.NetworkPolicyPeerConfig{
+		{PodSelector: &api.SelectorConfig{Labels: map[string]string{"app": "web"}}},
+	}})
+
+	db := components.NewNetworkPolicy(api.NetworkPolicyConfig{Name: "db", Namespace: "shop", PodSelector: api.SelectorConfig{Labels: map[string]string{"app": "db"}}})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.DeploymentComponent, web)
+	d.AddComponent(api.NetworkPolicyComponent, denyAll)
+	d.AddComponent(api.NetworkPolicyComponent, np)
+	d.AddComponent(api.NetworkPolicyComponent, db)
+
+	expected := []string{
+		"db: spec.podSelector: selector doesn't match any pods",
+		"web: spec.ingress[0].from[0].podSelector: selector doesn't match any pods",
+	}
+	actual := selectorErrors(t, d.CheckSelectors())
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected errors:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
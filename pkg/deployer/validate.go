@@ -0,0 +1,45 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+)
+
+// Validate checks every component against the rules the API server applies to
+// its object, and returns the errors of all the invalid components.  Run
+// validates the components before deploying any of them
+func (d *Deployer) Validate() error {
+	allErrs := map[api.ComponentType][]error{}
+	for _, ct := range deployOrder {
+		for _, c := range d.components[ct] {
+			if errs := components.Validate(c); len(errs) > 0 {
+				allErrs[ct] = append(allErrs[ct], fmt.Errorf("%s: %v", c.GetName(), errs.ToAggregate()))
+			}
+		}
+	}
+	return utilserror.NewDeployErrors(allErrs)
+}
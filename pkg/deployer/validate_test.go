@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+)
+
+func TestValidate(t *testing.T) {
+	comps, deployed := newRecordingComponents("other")
+	valid := components.NewConfigMap(api.ConfigMapConfig{Name: "settings", Namespace: "shop"})
+	invalid := components.NewConfigMap(api.ConfigMapConfig{Name: "Settings", Namespace: "shop"})
+	invalid.AddData(map[string]string{"bad/key": "value"})
+
+	d := NewDeployerExporter()
+	d.AddComponent(api.NamespaceComponent, components.NewNamespace(api.NamespaceConfig{Name: "shop"}))
+	d.AddComponent(api.ConfigMapComponent, valid)
+	d.AddComponent(api.ConfigMapComponent, invalid)
+	d.AddComponent(api.ConfigMapComponent, comps["other"])
+
+	err := d.Validate()
+	deployErrs, ok := err.(utilserror.DeployErrors)
+	if !ok {
+		t.Fatalf("expected DeployErrors, got %v", err)
+	}
+	errs := deployErrs.Errors()
+	if len(errs) != 1 || len(errs[api.ConfigMapComponent]) != 1 {
+		t.Fatalf("expected an error for the invalid config map, got %v", errs)
+	}
+	msg := errs[api.ConfigMapComponent][0].Error()
+	if !strings.HasPrefix(msg, "Settings: ") || !strings.Contains(msg, "metadata.name") || !strings.Contains(msg, "data: Invalid value: \"bad/key\"") {
+		t.Errorf("unexpected error %s", msg)
+	}
+
+	if err := d.Run(); err == nil || err.Error() != deployErrs.Error() {
+		t.Errorf("expected Run to fail validation, got %v", err)
+	}
+	if len(*deployed) != 0 {
+		t.Errorf("expected nothing to be deployed, got %v", *deployed)
+	}
+
+	d = NewDeployerExporter()
+	d.AddComponent(api.ConfigMapComponent, valid)
+	if err := d.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
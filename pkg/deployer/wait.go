@@ -0,0 +1,132 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultWaitTimeout  = 5 * time.Minute
+	defaultWaitInterval = 2 * time.Second
+)
+
+// SetWaitConfig configures Run to wait until a component is ready before
+// deploying the components that depend on it
+func (d *Deployer) SetWaitConfig(config api.WaitConfig) {
+	d.waitConfig = &config
+}
+
+// Wait blocks until all components are ready, or until one of them
+// fails or times out
+func (d *Deployer) Wait() error {
+	return d.WaitWithContext(context.Background())
+}
+
+// WaitWithContext waits for the components like Wait, and stops waiting when
+// the context is done or the run timeout expires
+func (d *Deployer) WaitWithContext(ctx context.Context) error {
+	if d.exporterOnly() {
+		return fmt.Errorf("deployer has no clients defined and can only be used to export")
+	}
+
+	config := api.WaitConfig{}
+	if d.waitConfig != nil {
+		config = *d.waitConfig
+	}
+
+	ctx, cancel := d.withDeadline(ctx)
+	defer cancel()
+
+	allErrs := map[api.ComponentType][]error{}
+	resources := d.getResources()
+	for _, ct := range deployOrder {
+		if errs := d.waitForType(ctx, ct, config, resources); len(errs) > 0 {
+			allErrs[ct] = errs
+		}
+	}
+
+	return utilserror.NewDeployErrors(allErrs)
+}
+
+// waitForType waits for all components of the given type to be ready
+func (d *Deployer) waitForType(ctx context.Context, ct api.ComponentType, config api.WaitConfig, res api.DeployerResources) []error {
+	timeout, interval := waitSettings(ct, config)
+	errs := []error{}
+	for _, c := range d.components[ct] {
+		log.Infof("waiting for %s %s to be ready", ct, c.GetName())
+		if err := waitForComponent(ctx, c, res, timeout, interval); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// waitSettings returns the timeout and interval to use for the given type
+func waitSettings(ct api.ComponentType, config api.WaitConfig) (time.Duration, time.Duration) {
+	timeout := config.Timeout
+	if t, ok := config.KindTimeouts[ct]; ok {
+		timeout = t
+	}
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	return timeout, interval
+}
+
+// waitForComponent polls the component until it is ready, the timeout
+// expires or the context is done
+func waitForComponent(ctx context.Context, c api.DeployableComponentInterface, res api.DeployerResources, timeout time.Duration, interval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ready, err := components.IsReady(c, res)
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for %s to be ready", timeout, c.GetName())
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for %s to be ready: %v", c.GetName(), ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,1312 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package harness
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	extensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiextensionsv1beta1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	autoscalingv1client "k8s.io/client-go/kubernetes/typed/autoscaling/v1"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	extensionsv1beta1client "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	networkingv1client "k8s.io/client-go/kubernetes/typed/networking/v1"
+	policyv1beta1client "k8s.io/client-go/kubernetes/typed/policy/v1beta1"
+	rbacv1client "k8s.io/client-go/kubernetes/typed/rbac/v1"
+	storagev1client "k8s.io/client-go/kubernetes/typed/storage/v1"
+)
+
+// Clientset is a kubernetes client backed by a Store.  Only the resources
+// used by the components are implemented, calling anything else will panic
+type Clientset struct {
+	kubernetes.Interface
+	store *Store
+}
+
+// AppsV1 returns the AppsV1 client
+func (c *Clientset) AppsV1() appsv1client.AppsV1Interface {
+	return &appsV1{store: c.store}
+}
+
+// AutoscalingV1 returns the AutoscalingV1 client
+func (c *Clientset) AutoscalingV1() autoscalingv1client.AutoscalingV1Interface {
+	return &autoscalingV1{store: c.store}
+}
+
+// BatchV1 returns the BatchV1 client
+func (c *Clientset) BatchV1() batchv1client.BatchV1Interface {
+	return &batchV1{store: c.store}
+}
+
+// CoreV1 returns the CoreV1 client
+func (c *Clientset) CoreV1() corev1client.CoreV1Interface {
+	return &coreV1{store: c.store}
+}
+
+// ExtensionsV1beta1 returns the ExtensionsV1beta1 client
+func (c *Clientset) ExtensionsV1beta1() extensionsv1beta1client.ExtensionsV1beta1Interface {
+	return &extensionsV1beta1{store: c.store}
+}
+
+// NetworkingV1 returns the NetworkingV1 client
+func (c *Clientset) NetworkingV1() networkingv1client.NetworkingV1Interface {
+	return &networkingV1{store: c.store}
+}
+
+// PolicyV1beta1 returns the PolicyV1beta1 client
+func (c *Clientset) PolicyV1beta1() policyv1beta1client.PolicyV1beta1Interface {
+	return &policyV1beta1{store: c.store}
+}
+
+// RbacV1 returns the RbacV1 client
+func (c *Clientset) RbacV1() rbacv1client.RbacV1Interface {
+	return &rbacV1{store: c.store}
+}
+
+// StorageV1 returns the StorageV1 client
+func (c *Clientset) StorageV1() storagev1client.StorageV1Interface {
+	return &storageV1{store: c.store}
+}
+
+// ExtensionsClientset is an api extensions client backed by a Store.  Only
+// custom resource definitions are implemented, calling anything else will panic
+type ExtensionsClientset struct {
+	extensionsclient.Interface
+	store *Store
+}
+
+// ApiextensionsV1beta1 returns the ApiextensionsV1beta1 client
+func (c *ExtensionsClientset) ApiextensionsV1beta1() apiextensionsv1beta1client.ApiextensionsV1beta1Interface {
+	return &apiextensionsV1beta1{store: c.store}
+}
+
+type appsV1 struct {
+	appsv1client.AppsV1Interface
+	store *Store
+}
+
+func (c *appsV1) DaemonSets(namespace string) appsv1client.DaemonSetInterface {
+	return &daemonSets{store: c.store, ns: namespace}
+}
+
+func (c *appsV1) Deployments(namespace string) appsv1client.DeploymentInterface {
+	return &deployments{store: c.store, ns: namespace}
+}
+
+func (c *appsV1) StatefulSets(namespace string) appsv1client.StatefulSetInterface {
+	return &statefulSets{store: c.store, ns: namespace}
+}
+
+type daemonSets struct {
+	appsv1client.DaemonSetInterface
+	store *Store
+	ns    string
+}
+
+func (c *daemonSets) Create(obj *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+	out, err := c.store.create("daemonsets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.DaemonSet), nil
+}
+
+func (c *daemonSets) Update(obj *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+	out, err := c.store.update("daemonsets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.DaemonSet), nil
+}
+
+func (c *daemonSets) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("daemonsets", c.ns, name)
+}
+
+func (c *daemonSets) Get(name string, options metav1.GetOptions) (*appsv1.DaemonSet, error) {
+	out, err := c.store.get("daemonsets", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.DaemonSet), nil
+}
+
+func (c *daemonSets) List(opts metav1.ListOptions) (*appsv1.DaemonSetList, error) {
+	objs, err := c.store.list("daemonsets", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &appsv1.DaemonSetList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*appsv1.DaemonSet))
+	}
+	return list, nil
+}
+
+type deployments struct {
+	appsv1client.DeploymentInterface
+	store *Store
+	ns    string
+}
+
+func (c *deployments) Create(obj *appsv1.Deployment) (*appsv1.Deployment, error) {
+	out, err := c.store.create("deployments", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.Deployment), nil
+}
+
+func (c *deployments) Update(obj *appsv1.Deployment) (*appsv1.Deployment, error) {
+	out, err := c.store.update("deployments", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.Deployment), nil
+}
+
+func (c *deployments) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("deployments", c.ns, name)
+}
+
+func (c *deployments) Get(name string, options metav1.GetOptions) (*appsv1.Deployment, error) {
+	out, err := c.store.get("deployments", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.Deployment), nil
+}
+
+func (c *deployments) List(opts metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	objs, err := c.store.list("deployments", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &appsv1.DeploymentList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*appsv1.Deployment))
+	}
+	return list, nil
+}
+
+type statefulSets struct {
+	appsv1client.StatefulSetInterface
+	store *Store
+	ns    string
+}
+
+func (c *statefulSets) Create(obj *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	out, err := c.store.create("statefulsets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.StatefulSet), nil
+}
+
+func (c *statefulSets) Update(obj *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	out, err := c.store.update("statefulsets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.StatefulSet), nil
+}
+
+func (c *statefulSets) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("statefulsets", c.ns, name)
+}
+
+func (c *statefulSets) Get(name string, options metav1.GetOptions) (*appsv1.StatefulSet, error) {
+	out, err := c.store.get("statefulsets", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*appsv1.StatefulSet), nil
+}
+
+func (c *statefulSets) List(opts metav1.ListOptions) (*appsv1.StatefulSetList, error) {
+	objs, err := c.store.list("statefulsets", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &appsv1.StatefulSetList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*appsv1.StatefulSet))
+	}
+	return list, nil
+}
+
+type autoscalingV1 struct {
+	autoscalingv1client.AutoscalingV1Interface
+	store *Store
+}
+
+func (c *autoscalingV1) HorizontalPodAutoscalers(namespace string) autoscalingv1client.HorizontalPodAutoscalerInterface {
+	return &horizontalPodAutoscalers{store: c.store, ns: namespace}
+}
+
+type horizontalPodAutoscalers struct {
+	autoscalingv1client.HorizontalPodAutoscalerInterface
+	store *Store
+	ns    string
+}
+
+func (c *horizontalPodAutoscalers) Create(obj *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	out, err := c.store.create("horizontalpodautoscalers", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*autoscalingv1.HorizontalPodAutoscaler), nil
+}
+
+func (c *horizontalPodAutoscalers) Update(obj *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	out, err := c.store.update("horizontalpodautoscalers", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*autoscalingv1.HorizontalPodAutoscaler), nil
+}
+
+func (c *horizontalPodAutoscalers) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("horizontalpodautoscalers", c.ns, name)
+}
+
+func (c *horizontalPodAutoscalers) Get(name string, options metav1.GetOptions) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	out, err := c.store.get("horizontalpodautoscalers", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*autoscalingv1.HorizontalPodAutoscaler), nil
+}
+
+func (c *horizontalPodAutoscalers) List(opts metav1.ListOptions) (*autoscalingv1.HorizontalPodAutoscalerList, error) {
+	objs, err := c.store.list("horizontalpodautoscalers", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &autoscalingv1.HorizontalPodAutoscalerList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*autoscalingv1.HorizontalPodAutoscaler))
+	}
+	return list, nil
+}
+
+type batchV1 struct {
+	batchv1client.BatchV1Interface
+	store *Store
+}
+
+func (c *batchV1) Jobs(namespace string) batchv1client.JobInterface {
+	return &jobs{store: c.store, ns: namespace}
+}
+
+type jobs struct {
+	batchv1client.JobInterface
+	store *Store
+	ns    string
+}
+
+func (c *jobs) Create(obj *batchv1.Job) (*batchv1.Job, error) {
+	out, err := c.store.create("jobs", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*batchv1.Job), nil
+}
+
+func (c *jobs) Update(obj *batchv1.Job) (*batchv1.Job, error) {
+	out, err := c.store.update("jobs", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*batchv1.Job), nil
+}
+
+func (c *jobs) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("jobs", c.ns, name)
+}
+
+func (c *jobs) Get(name string, options metav1.GetOptions) (*batchv1.Job, error) {
+	out, err := c.store.get("jobs", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*batchv1.Job), nil
+}
+
+func (c *jobs) List(opts metav1.ListOptions) (*batchv1.JobList, error) {
+	objs, err := c.store.list("jobs", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &batchv1.JobList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*batchv1.Job))
+	}
+	return list, nil
+}
+
+type coreV1 struct {
+	corev1client.CoreV1Interface
+	store *Store
+}
+
+func (c *coreV1) ConfigMaps(namespace string) corev1client.ConfigMapInterface {
+	return &configMaps{store: c.store, ns: namespace}
+}
+
+func (c *coreV1) Endpoints(namespace string) corev1client.EndpointsInterface {
+	return &endpoints{store: c.store, ns: namespace}
+}
+
+func (c *coreV1) Namespaces() corev1client.NamespaceInterface {
+	return &namespaces{store: c.store}
+}
+
+func (c *coreV1) PersistentVolumeClaims(namespace string) corev1client.PersistentVolumeClaimInterface {
+	return &persistentVolumeClaims{store: c.store, ns: namespace}
+}
+
+func (c *coreV1) Pods(namespace string) corev1client.PodInterface {
+	return &pods{store: c.store, ns: namespace}
+}
+
+func (c *coreV1) ReplicationControllers(namespace string) corev1client.ReplicationControllerInterface {
+	return &replicationControllers{store: c.store, ns: namespace}
+}
+
+func (c *coreV1) Secrets(namespace string) corev1client.SecretInterface {
+	return &secrets{store: c.store, ns: namespace}
+}
+
+func (c *coreV1) Services(namespace string) corev1client.ServiceInterface {
+	return &services{store: c.store, ns: namespace}
+}
+
+func (c *coreV1) ServiceAccounts(namespace string) corev1client.ServiceAccountInterface {
+	return &serviceAccounts{store: c.store, ns: namespace}
+}
+
+type configMaps struct {
+	corev1client.ConfigMapInterface
+	store *Store
+	ns    string
+}
+
+func (c *configMaps) Create(obj *v1.ConfigMap) (*v1.ConfigMap, error) {
+	out, err := c.store.create("configmaps", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ConfigMap), nil
+}
+
+func (c *configMaps) Update(obj *v1.ConfigMap) (*v1.ConfigMap, error) {
+	out, err := c.store.update("configmaps", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ConfigMap), nil
+}
+
+func (c *configMaps) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("configmaps", c.ns, name)
+}
+
+func (c *configMaps) Get(name string, options metav1.GetOptions) (*v1.ConfigMap, error) {
+	out, err := c.store.get("configmaps", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ConfigMap), nil
+}
+
+func (c *configMaps) List(opts metav1.ListOptions) (*v1.ConfigMapList, error) {
+	objs, err := c.store.list("configmaps", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.ConfigMapList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.ConfigMap))
+	}
+	return list, nil
+}
+
+type endpoints struct {
+	corev1client.EndpointsInterface
+	store *Store
+	ns    string
+}
+
+func (c *endpoints) Create(obj *v1.Endpoints) (*v1.Endpoints, error) {
+	out, err := c.store.create("endpoints", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Endpoints), nil
+}
+
+func (c *endpoints) Update(obj *v1.Endpoints) (*v1.Endpoints, error) {
+	out, err := c.store.update("endpoints", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Endpoints), nil
+}
+
+func (c *endpoints) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("endpoints", c.ns, name)
+}
+
+func (c *endpoints) Get(name string, options metav1.GetOptions) (*v1.Endpoints, error) {
+	out, err := c.store.get("endpoints", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Endpoints), nil
+}
+
+func (c *endpoints) List(opts metav1.ListOptions) (*v1.EndpointsList, error) {
+	objs, err := c.store.list("endpoints", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.EndpointsList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.Endpoints))
+	}
+	return list, nil
+}
+
+type namespaces struct {
+	corev1client.NamespaceInterface
+	store *Store
+	ns    string
+}
+
+func (c *namespaces) Create(obj *v1.Namespace) (*v1.Namespace, error) {
+	out, err := c.store.create("namespaces", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Namespace), nil
+}
+
+func (c *namespaces) Update(obj *v1.Namespace) (*v1.Namespace, error) {
+	out, err := c.store.update("namespaces", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Namespace), nil
+}
+
+func (c *namespaces) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("namespaces", c.ns, name)
+}
+
+func (c *namespaces) Get(name string, options metav1.GetOptions) (*v1.Namespace, error) {
+	out, err := c.store.get("namespaces", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Namespace), nil
+}
+
+func (c *namespaces) List(opts metav1.ListOptions) (*v1.NamespaceList, error) {
+	objs, err := c.store.list("namespaces", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.NamespaceList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.Namespace))
+	}
+	return list, nil
+}
+
+type persistentVolumeClaims struct {
+	corev1client.PersistentVolumeClaimInterface
+	store *Store
+	ns    string
+}
+
+func (c *persistentVolumeClaims) Create(obj *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error) {
+	out, err := c.store.create("persistentvolumeclaims", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.PersistentVolumeClaim), nil
+}
+
+func (c *persistentVolumeClaims) Update(obj *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error) {
+	out, err := c.store.update("persistentvolumeclaims", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.PersistentVolumeClaim), nil
+}
+
+func (c *persistentVolumeClaims) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("persistentvolumeclaims", c.ns, name)
+}
+
+func (c *persistentVolumeClaims) Get(name string, options metav1.GetOptions) (*v1.PersistentVolumeClaim, error) {
+	out, err := c.store.get("persistentvolumeclaims", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.PersistentVolumeClaim), nil
+}
+
+func (c *persistentVolumeClaims) List(opts metav1.ListOptions) (*v1.PersistentVolumeClaimList, error) {
+	objs, err := c.store.list("persistentvolumeclaims", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.PersistentVolumeClaimList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.PersistentVolumeClaim))
+	}
+	return list, nil
+}
+
+type pods struct {
+	corev1client.PodInterface
+	store *Store
+	ns    string
+}
+
+func (c *pods) Create(obj *v1.Pod) (*v1.Pod, error) {
+	out, err := c.store.create("pods", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Pod), nil
+}
+
+func (c *pods) Update(obj *v1.Pod) (*v1.Pod, error) {
+	out, err := c.store.update("pods", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Pod), nil
+}
+
+func (c *pods) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("pods", c.ns, name)
+}
+
+func (c *pods) Get(name string, options metav1.GetOptions) (*v1.Pod, error) {
+	out, err := c.store.get("pods", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Pod), nil
+}
+
+func (c *pods) List(opts metav1.ListOptions) (*v1.PodList, error) {
+	objs, err := c.store.list("pods", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.PodList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.Pod))
+	}
+	return list, nil
+}
+
+type replicationControllers struct {
+	corev1client.ReplicationControllerInterface
+	store *Store
+	ns    string
+}
+
+func (c *replicationControllers) Create(obj *v1.ReplicationController) (*v1.ReplicationController, error) {
+	out, err := c.store.create("replicationcontrollers", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ReplicationController), nil
+}
+
+func (c *replicationControllers) Update(obj *v1.ReplicationController) (*v1.ReplicationController, error) {
+	out, err := c.store.update("replicationcontrollers", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ReplicationController), nil
+}
+
+func (c *replicationControllers) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("replicationcontrollers", c.ns, name)
+}
+
+func (c *replicationControllers) Get(name string, options metav1.GetOptions) (*v1.ReplicationController, error) {
+	out, err := c.store.get("replicationcontrollers", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ReplicationController), nil
+}
+
+func (c *replicationControllers) List(opts metav1.ListOptions) (*v1.ReplicationControllerList, error) {
+	objs, err := c.store.list("replicationcontrollers", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.ReplicationControllerList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.ReplicationController))
+	}
+	return list, nil
+}
+
+type secrets struct {
+	corev1client.SecretInterface
+	store *Store
+	ns    string
+}
+
+func (c *secrets) Create(obj *v1.Secret) (*v1.Secret, error) {
+	out, err := c.store.create("secrets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Secret), nil
+}
+
+func (c *secrets) Update(obj *v1.Secret) (*v1.Secret, error) {
+	out, err := c.store.update("secrets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Secret), nil
+}
+
+func (c *secrets) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("secrets", c.ns, name)
+}
+
+func (c *secrets) Get(name string, options metav1.GetOptions) (*v1.Secret, error) {
+	out, err := c.store.get("secrets", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Secret), nil
+}
+
+func (c *secrets) List(opts metav1.ListOptions) (*v1.SecretList, error) {
+	objs, err := c.store.list("secrets", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.SecretList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.Secret))
+	}
+	return list, nil
+}
+
+type services struct {
+	corev1client.ServiceInterface
+	store *Store
+	ns    string
+}
+
+func (c *services) Create(obj *v1.Service) (*v1.Service, error) {
+	out, err := c.store.create("services", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Service), nil
+}
+
+func (c *services) Update(obj *v1.Service) (*v1.Service, error) {
+	out, err := c.store.update("services", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Service), nil
+}
+
+func (c *services) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("services", c.ns, name)
+}
+
+func (c *services) Get(name string, options metav1.GetOptions) (*v1.Service, error) {
+	out, err := c.store.get("services", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.Service), nil
+}
+
+func (c *services) List(opts metav1.ListOptions) (*v1.ServiceList, error) {
+	objs, err := c.store.list("services", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.ServiceList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.Service))
+	}
+	return list, nil
+}
+
+type serviceAccounts struct {
+	corev1client.ServiceAccountInterface
+	store *Store
+	ns    string
+}
+
+func (c *serviceAccounts) Create(obj *v1.ServiceAccount) (*v1.ServiceAccount, error) {
+	out, err := c.store.create("serviceaccounts", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ServiceAccount), nil
+}
+
+func (c *serviceAccounts) Update(obj *v1.ServiceAccount) (*v1.ServiceAccount, error) {
+	out, err := c.store.update("serviceaccounts", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ServiceAccount), nil
+}
+
+func (c *serviceAccounts) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("serviceaccounts", c.ns, name)
+}
+
+func (c *serviceAccounts) Get(name string, options metav1.GetOptions) (*v1.ServiceAccount, error) {
+	out, err := c.store.get("serviceaccounts", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*v1.ServiceAccount), nil
+}
+
+func (c *serviceAccounts) List(opts metav1.ListOptions) (*v1.ServiceAccountList, error) {
+	objs, err := c.store.list("serviceaccounts", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.ServiceAccountList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*v1.ServiceAccount))
+	}
+	return list, nil
+}
+
+type extensionsV1beta1 struct {
+	extensionsv1beta1client.ExtensionsV1beta1Interface
+	store *Store
+}
+
+func (c *extensionsV1beta1) Ingresses(namespace string) extensionsv1beta1client.IngressInterface {
+	return &ingresses{store: c.store, ns: namespace}
+}
+
+type ingresses struct {
+	extensionsv1beta1client.IngressInterface
+	store *Store
+	ns    string
+}
+
+func (c *ingresses) Create(obj *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error) {
+	out, err := c.store.create("ingresses", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*extensionsv1beta1.Ingress), nil
+}
+
+func (c *ingresses) Update(obj *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error) {
+	out, err := c.store.update("ingresses", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*extensionsv1beta1.Ingress), nil
+}
+
+func (c *ingresses) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("ingresses", c.ns, name)
+}
+
+func (c *ingresses) Get(name string, options metav1.GetOptions) (*extensionsv1beta1.Ingress, error) {
+	out, err := c.store.get("ingresses", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*extensionsv1beta1.Ingress), nil
+}
+
+func (c *ingresses) List(opts metav1.ListOptions) (*extensionsv1beta1.IngressList, error) {
+	objs, err := c.store.list("ingresses", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &extensionsv1beta1.IngressList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*extensionsv1beta1.Ingress))
+	}
+	return list, nil
+}
+
+type networkingV1 struct {
+	networkingv1client.NetworkingV1Interface
+	store *Store
+}
+
+func (c *networkingV1) NetworkPolicies(namespace string) networkingv1client.NetworkPolicyInterface {
+	return &networkPolicies{store: c.store, ns: namespace}
+}
+
+type networkPolicies struct {
+	networkingv1client.NetworkPolicyInterface
+	store *Store
+	ns    string
+}
+
+func (c *networkPolicies) Create(obj *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	out, err := c.store.create("networkpolicies", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*networkingv1.NetworkPolicy), nil
+}
+
+func (c *networkPolicies) Update(obj *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	out, err := c.store.update("networkpolicies", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*networkingv1.NetworkPolicy), nil
+}
+
+func (c *networkPolicies) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("networkpolicies", c.ns, name)
+}
+
+func (c *networkPolicies) Get(name string, options metav1.GetOptions) (*networkingv1.NetworkPolicy, error) {
+	out, err := c.store.get("networkpolicies", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*networkingv1.NetworkPolicy), nil
+}
+
+func (c *networkPolicies) List(opts metav1.ListOptions) (*networkingv1.NetworkPolicyList, error) {
+	objs, err := c.store.list("networkpolicies", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &networkingv1.NetworkPolicyList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*networkingv1.NetworkPolicy))
+	}
+	return list, nil
+}
+
+type policyV1beta1 struct {
+	policyv1beta1client.PolicyV1beta1Interface
+	store *Store
+}
+
+func (c *policyV1beta1) PodDisruptionBudgets(namespace string) policyv1beta1client.PodDisruptionBudgetInterface {
+	return &podDisruptionBudgets{store: c.store, ns: namespace}
+}
+
+type podDisruptionBudgets struct {
+	policyv1beta1client.PodDisruptionBudgetInterface
+	store *Store
+	ns    string
+}
+
+func (c *podDisruptionBudgets) Create(obj *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+	out, err := c.store.create("poddisruptionbudgets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*policyv1beta1.PodDisruptionBudget), nil
+}
+
+func (c *podDisruptionBudgets) Update(obj *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+	out, err := c.store.update("poddisruptionbudgets", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*policyv1beta1.PodDisruptionBudget), nil
+}
+
+func (c *podDisruptionBudgets) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("poddisruptionbudgets", c.ns, name)
+}
+
+func (c *podDisruptionBudgets) Get(name string, options metav1.GetOptions) (*policyv1beta1.PodDisruptionBudget, error) {
+	out, err := c.store.get("poddisruptionbudgets", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*policyv1beta1.PodDisruptionBudget), nil
+}
+
+func (c *podDisruptionBudgets) List(opts metav1.ListOptions) (*policyv1beta1.PodDisruptionBudgetList, error) {
+	objs, err := c.store.list("poddisruptionbudgets", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &policyv1beta1.PodDisruptionBudgetList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*policyv1beta1.PodDisruptionBudget))
+	}
+	return list, nil
+}
+
+type rbacV1 struct {
+	rbacv1client.RbacV1Interface
+	store *Store
+}
+
+func (c *rbacV1) ClusterRoles() rbacv1client.ClusterRoleInterface {
+	return &clusterRoles{store: c.store}
+}
+
+func (c *rbacV1) ClusterRoleBindings() rbacv1client.ClusterRoleBindingInterface {
+	return &clusterRoleBindings{store: c.store}
+}
+
+func (c *rbacV1) Roles(namespace string) rbacv1client.RoleInterface {
+	return &roles{store: c.store, ns: namespace}
+}
+
+func (c *rbacV1) RoleBindings(namespace string) rbacv1client.RoleBindingInterface {
+	return &roleBindings{store: c.store, ns: namespace}
+}
+
+type clusterRoles struct {
+	rbacv1client.ClusterRoleInterface
+	store *Store
+	ns    string
+}
+
+func (c *clusterRoles) Create(obj *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+	out, err := c.store.create("clusterroles", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.ClusterRole), nil
+}
+
+func (c *clusterRoles) Update(obj *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+	out, err := c.store.update("clusterroles", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.ClusterRole), nil
+}
+
+func (c *clusterRoles) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("clusterroles", c.ns, name)
+}
+
+func (c *clusterRoles) Get(name string, options metav1.GetOptions) (*rbacv1.ClusterRole, error) {
+	out, err := c.store.get("clusterroles", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.ClusterRole), nil
+}
+
+func (c *clusterRoles) List(opts metav1.ListOptions) (*rbacv1.ClusterRoleList, error) {
+	objs, err := c.store.list("clusterroles", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &rbacv1.ClusterRoleList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*rbacv1.ClusterRole))
+	}
+	return list, nil
+}
+
+type clusterRoleBindings struct {
+	rbacv1client.ClusterRoleBindingInterface
+	store *Store
+	ns    string
+}
+
+func (c *clusterRoleBindings) Create(obj *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+	out, err := c.store.create("clusterrolebindings", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.ClusterRoleBinding), nil
+}
+
+func (c *clusterRoleBindings) Update(obj *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+	out, err := c.store.update("clusterrolebindings", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.ClusterRoleBinding), nil
+}
+
+func (c *clusterRoleBindings) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("clusterrolebindings", c.ns, name)
+}
+
+func (c *clusterRoleBindings) Get(name string, options metav1.GetOptions) (*rbacv1.ClusterRoleBinding, error) {
+	out, err := c.store.get("clusterrolebindings", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.ClusterRoleBinding), nil
+}
+
+func (c *clusterRoleBindings) List(opts metav1.ListOptions) (*rbacv1.ClusterRoleBindingList, error) {
+	objs, err := c.store.list("clusterrolebindings", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &rbacv1.ClusterRoleBindingList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*rbacv1.ClusterRoleBinding))
+	}
+	return list, nil
+}
+
+type roles struct {
+	rbacv1client.RoleInterface
+	store *Store
+	ns    string
+}
+
+func (c *roles) Create(obj *rbacv1.Role) (*rbacv1.Role, error) {
+	out, err := c.store.create("roles", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.Role), nil
+}
+
+func (c *roles) Update(obj *rbacv1.Role) (*rbacv1.Role, error) {
+	out, err := c.store.update("roles", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.Role), nil
+}
+
+func (c *roles) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("roles", c.ns, name)
+}
+
+func (c *roles) Get(name string, options metav1.GetOptions) (*rbacv1.Role, error) {
+	out, err := c.store.get("roles", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.Role), nil
+}
+
+func (c *roles) List(opts metav1.ListOptions) (*rbacv1.RoleList, error) {
+	objs, err := c.store.list("roles", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &rbacv1.RoleList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*rbacv1.Role))
+	}
+	return list, nil
+}
+
+type roleBindings struct {
+	rbacv1client.RoleBindingInterface
+	store *Store
+	ns    string
+}
+
+func (c *roleBindings) Create(obj *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	out, err := c.store.create("rolebindings", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.RoleBinding), nil
+}
+
+func (c *roleBindings) Update(obj *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	out, err := c.store.update("rolebindings", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.RoleBinding), nil
+}
+
+func (c *roleBindings) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("rolebindings", c.ns, name)
+}
+
+func (c *roleBindings) Get(name string, options metav1.GetOptions) (*rbacv1.RoleBinding, error) {
+	out, err := c.store.get("rolebindings", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*rbacv1.RoleBinding), nil
+}
+
+func (c *roleBindings) List(opts metav1.ListOptions) (*rbacv1.RoleBindingList, error) {
+	objs, err := c.store.list("rolebindings", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &rbacv1.RoleBindingList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*rbacv1.RoleBinding))
+	}
+	return list, nil
+}
+
+type storageV1 struct {
+	storagev1client.StorageV1Interface
+	store *Store
+}
+
+func (c *storageV1) StorageClasses() storagev1client.StorageClassInterface {
+	return &storageClasses{store: c.store}
+}
+
+type storageClasses struct {
+	storagev1client.StorageClassInterface
+	store *Store
+	ns    string
+}
+
+func (c *storageClasses) Create(obj *storagev1.StorageClass) (*storagev1.StorageClass, error) {
+	out, err := c.store.create("storageclasses", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*storagev1.StorageClass), nil
+}
+
+func (c *storageClasses) Update(obj *storagev1.StorageClass) (*storagev1.StorageClass, error) {
+	out, err := c.store.update("storageclasses", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*storagev1.StorageClass), nil
+}
+
+func (c *storageClasses) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("storageclasses", c.ns, name)
+}
+
+func (c *storageClasses) Get(name string, options metav1.GetOptions) (*storagev1.StorageClass, error) {
+	out, err := c.store.get("storageclasses", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*storagev1.StorageClass), nil
+}
+
+func (c *storageClasses) List(opts metav1.ListOptions) (*storagev1.StorageClassList, error) {
+	objs, err := c.store.list("storageclasses", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &storagev1.StorageClassList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*storagev1.StorageClass))
+	}
+	return list, nil
+}
+
+type apiextensionsV1beta1 struct {
+	apiextensionsv1beta1client.ApiextensionsV1beta1Interface
+	store *Store
+}
+
+func (c *apiextensionsV1beta1) CustomResourceDefinitions() apiextensionsv1beta1client.CustomResourceDefinitionInterface {
+	return &customResourceDefinitions{store: c.store}
+}
+
+type customResourceDefinitions struct {
+	apiextensionsv1beta1client.CustomResourceDefinitionInterface
+	store *Store
+	ns    string
+}
+
+func (c *customResourceDefinitions) Create(obj *apiextensionsv1beta1.CustomResourceDefinition) (*apiextensionsv1beta1.CustomResourceDefinition, error) {
+	out, err := c.store.create("customresourcedefinitions", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*apiextensionsv1beta1.CustomResourceDefinition), nil
+}
+
+func (c *customResourceDefinitions) Update(obj *apiextensionsv1beta1.CustomResourceDefinition) (*apiextensionsv1beta1.CustomResourceDefinition, error) {
+	out, err := c.store.update("customresourcedefinitions", c.ns, obj)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*apiextensionsv1beta1.CustomResourceDefinition), nil
+}
+
+func (c *customResourceDefinitions) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.store.delete("customresourcedefinitions", c.ns, name)
+}
+
+func (c *customResourceDefinitions) Get(name string, options metav1.GetOptions) (*apiextensionsv1beta1.CustomResourceDefinition, error) {
+	out, err := c.store.get("customresourcedefinitions", c.ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*apiextensionsv1beta1.CustomResourceDefinition), nil
+}
+
+func (c *customResourceDefinitions) List(opts metav1.ListOptions) (*apiextensionsv1beta1.CustomResourceDefinitionList, error) {
+	objs, err := c.store.list("customresourcedefinitions", c.ns, opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &apiextensionsv1beta1.CustomResourceDefinitionList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*apiextensionsv1beta1.CustomResourceDefinition))
+	}
+	return list, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package harness runs deployers and components against an in-memory
+// object store so that apps can be tested without a cluster
+package harness
+
+import (
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/deployer"
+)
+
+// Harness provides clients that share an in-memory object store
+type Harness struct {
+	Store      *Store
+	Client     *Clientset
+	Extensions *ExtensionsClientset
+}
+
+// New creates a Harness object with an empty store
+func New() *Harness {
+	store := NewStore()
+	return &Harness{
+		Store:      store,
+		Client:     &Clientset{store: store},
+		Extensions: &ExtensionsClientset{store: store},
+	}
+}
+
+// NewDeployer creates a deployer that deploys to the harness store
+func (h *Harness) NewDeployer() *deployer.Deployer {
+	return deployer.NewDeployerWithClients(h.Client, h.Extensions)
+}
+
+// Resources returns the resources given to components and controllers
+func (h *Harness) Resources() api.DeployerResources {
+	return api.DeployerResources{
+		KubeClient:           h.Client,
+		KubeExtensionsClient: h.Extensions,
+	}
+}
@@ -0,0 +1,350 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+	"github.com/blackducksoftware/horizon/pkg/deployer"
+	utilserror "github.com/blackducksoftware/horizon/pkg/util/error"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newConfigMap(name string, value string) *components.ConfigMap {
+	cm := components.NewConfigMap(api.ConfigMapConfig{Name: name, Namespace: "ns"})
+	cm.AddData(map[string]string{"key": value})
+	return cm
+}
+
+func newTestDeployer(h *Harness, comps ...*components.ConfigMap) *deployer.Deployer {
+	d := h.NewDeployer()
+	d.AddComponent(api.NamespaceComponent, components.NewNamespace(api.NamespaceConfig{Name: "ns"}))
+	for _, c := range comps {
+		d.AddComponent(api.ConfigMapComponent, c)
+	}
+	return d
+}
+
+func configMapValue(t *testing.T, h *Harness, name string) string {
+	obj, ok := h.Store.Get("configmaps", "ns", name)
+	if !ok {
+		return ""
+	}
+	return obj.(*v1.ConfigMap).Data["key"]
+}
+
+func countActions(h *Harness, verb string) int {
+	count := 0
+	for _, a := range h.Store.Actions() {
+		if a.Verb == verb {
+			count++
+		}
+	}
+	return count
+}
+
+func TestRunAndUndeploy(t *testing.T) {
+	h := New()
+	if err := newTestDeployer(h, newConfigMap("cfg", "one")).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := h.Store.Get("namespaces", "", "ns"); !ok {
+		t.Errorf("expected the namespace to be created")
+	}
+	if configMapValue(t, h, "cfg") != "one" {
+		t.Errorf("expected the config map to be created")
+	}
+
+	d := newTestDeployer(h, newConfigMap("cfg", "two"))
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if configMapValue(t, h, "cfg") != "two" || countActions(h, "update") != 1 {
+		t.Errorf("expected the config map to be updated, got actions %v", h.Store.Actions())
+	}
+
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if countActions(h, "update") != 1 {
+		t.Errorf("expected an unchanged config map not to be updated, got actions %v", h.Store.Actions())
+	}
+
+	if err := d.Undeploy(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(h.Store.List("configmaps")) != 0 || len(h.Store.List("namespaces")) != 0 {
+		t.Errorf("expected all components to be removed, got actions %v", h.Store.Actions())
+	}
+}
+
+func TestReleaseHistoryAndRollback(t *testing.T) {
+	h := New()
+	config := api.ReleaseConfig{Name: "app", Namespace: "ns", AppVersion: "1.0"}
+	for _, value := range []string{"one", "two"} {
+		d := newTestDeployer(h, newConfigMap("cfg", value), newConfigMap("cfg-"+value, value))
+		d.SetReleaseConfig(config)
+		if err := d.Run(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	d := h.NewDeployer()
+	d.SetReleaseConfig(config)
+	releases, err := d.History()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(releases) != 2 || releases[0].Description != "Install" || releases[1].Description != "Upgrade" {
+		t.Fatalf("unexpected releases %+v", releases)
+	}
+
+	if err := d.Rollback(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if configMapValue(t, h, "cfg") != "one" || configMapValue(t, h, "cfg-one") != "one" {
+		t.Errorf("expected the config maps of revision 1 to be restored")
+	}
+	if _, ok := h.Store.Get("configmaps", "ns", "cfg-two"); ok {
+		t.Errorf("expected the config map added in revision 2 to be removed")
+	}
+
+	releases, _ = d.History()
+	if len(releases) != 3 || releases[2].Description != "Rollback to 1" {
+		t.Errorf("expected the rollback to be recorded, got %+v", releases)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	h := New()
+	inventory := api.InventoryConfig{AppName: "app", Instance: "one", Prune: true}
+	d := newTestDeployer(h, newConfigMap("keep", "value"), newConfigMap("remove", "value"))
+	d.SetInventoryConfig(inventory)
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d = newTestDeployer(h, newConfigMap("keep", "value"))
+	d.SetInventoryConfig(inventory)
+	results, err := d.Prune(true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "remove" {
+		t.Errorf("expected the removed config map to be pruned, got %+v", results)
+	}
+	if _, ok := h.Store.Get("configmaps", "ns", "remove"); !ok {
+		t.Errorf("expected a dry run not to remove anything")
+	}
+
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := h.Store.Get("configmaps", "ns", "remove"); ok {
+		t.Errorf("expected the removed config map to be pruned")
+	}
+	if _, ok := h.Store.Get("configmaps", "ns", "keep"); !ok {
+		t.Errorf("expected the kept config map to remain")
+	}
+}
+
+// markStatefulSetReady sets the status of the stateful set once it is created
+// and publishes the address of its pod as the endpoints of the service, like
+// the controllers would once its pods are ready
+func markStatefulSetReady(h *Harness, name string, stop chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+		obj, ok := h.Store.Get("statefulsets", "ns", name)
+		if !ok {
+			continue
+		}
+		ss := obj.(*appsv1.StatefulSet)
+		ss.Status.ReadyReplicas = 1
+		h.Store.Add("statefulsets", ss)
+		h.Store.Add("endpoints", &v1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Subsets:    []v1.EndpointSubset{{Addresses: []v1.EndpointAddress{{IP: "10.0.0.1"}}}},
+		})
+		return
+	}
+}
+
+func TestWaitForStatefulSetBehindService(t *testing.T) {
+	h := New()
+	h.Store.Add("namespaces", &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns"},
+		Status:     v1.NamespaceStatus{Phase: v1.NamespaceActive},
+	})
+	d := h.NewDeployer()
+	svc := components.NewService(api.ServiceConfig{Name: "db", Namespace: "ns", ClusterIP: "None"})
+	svc.AddSelectors(map[string]string{"app": "db"})
+	svc.AddPort(api.ServicePortConfig{Name: "db", Port: 5432})
+	d.AddComponent(api.ServiceComponent, svc)
+
+	c, err := components.NewContainer(api.ContainerConfig{Name: "db", Image: "postgres"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := components.NewPod(api.PodConfig{Name: "db", Namespace: "ns"})
+	pod.AddLabels(map[string]string{"app": "db"})
+	pod.AddContainer(c)
+	ss := components.NewStatefulSet(api.StatefulSetConfig{Name: "db", Namespace: "ns", Service: "db"})
+	ss.AddMatchLabelsSelectors(map[string]string{"app": "db"})
+	ss.AddPod(pod)
+	d.AddComponent(api.StatefulSetComponent, ss)
+	d.SetWaitConfig(api.WaitConfig{Timeout: 5 * time.Second, Interval: 10 * time.Millisecond})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go markStatefulSetReady(h, "db", stop)
+
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := h.Store.Get("statefulsets", "ns", "db"); !ok {
+		t.Errorf("expected the stateful set to be created")
+	}
+	created := []string{}
+	for _, a := range h.Store.Actions() {
+		if a.Verb == "create" {
+			created = append(created, a.Resource)
+		}
+	}
+	if strings.Join(created, ",") != "statefulsets,services" {
+		t.Errorf("expected the service to be created after the stateful set, got %v", created)
+	}
+}
+
+func TestWaitWithContextCancelled(t *testing.T) {
+	h := New()
+	d := h.NewDeployer()
+	svc := components.NewService(api.ServiceConfig{Name: "web", Namespace: "ns"})
+	svc.AddSelectors(map[string]string{"app": "web"})
+	svc.AddPort(api.ServicePortConfig{Name: "http", Port: 80})
+	d.AddComponent(api.ServiceComponent, svc)
+	h.Store.Add("services", &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+	})
+	d.SetWaitConfig(api.WaitConfig{Timeout: time.Minute, Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := d.WaitWithContext(ctx)
+	if err == nil || !strings.Contains(err.Error(), "stopped waiting for web") {
+		t.Errorf("expected the wait to be stopped, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the wait to stop with the context, took %v", elapsed)
+	}
+}
+
+type failingComponent struct {
+	*v1.ConfigMap
+}
+
+func (f *failingComponent) Deploy(context.Context, api.DeployerResources) error {
+	return fmt.Errorf("failed to deploy %s", f.Name)
+}
+
+func (f *failingComponent) Undeploy(context.Context, api.DeployerResources) error {
+	return nil
+}
+
+func TestTransactionalRollback(t *testing.T) {
+	h := New()
+	if err := newTestDeployer(h, newConfigMap("existing", "one")).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	existing := newConfigMap("existing", "two")
+	created := newConfigMap("new", "two")
+	failing := &failingComponent{&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "failing"}}}
+	d := newTestDeployer(h, existing, created)
+	d.AddComponent(api.ConfigMapComponent, failing)
+	d.AddDependency(failing, existing)
+	d.AddDependency(failing, created)
+	d.SetTransactional(true)
+
+	err := d.Run()
+	rollbackErrs, ok := err.(utilserror.RollbackErrors)
+	if !ok {
+		t.Fatalf("expected rollback errors, got %v", err)
+	}
+	if len(rollbackErrs.Errors()[api.ConfigMapComponent]) != 1 || len(rollbackErrs.RollbackErrors()) != 0 {
+		t.Errorf("unexpected errors %v", err)
+	}
+
+	if configMapValue(t, h, "existing") != "one" {
+		t.Errorf("expected the updated config map to be restored")
+	}
+	if _, ok := h.Store.Get("configmaps", "ns", "new"); ok {
+		t.Errorf("expected the created config map to be removed")
+	}
+}
+
+func TestObserver(t *testing.T) {
+	h := New()
+	recorder := &Recorder{}
+	d := newTestDeployer(h, newConfigMap("cfg", "value"))
+	d.AddObserver(recorder)
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Undeploy(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"start 2",
+		"Deploy Namespace ns",
+		"Deploy Namespace ns done",
+		"Deploy ConfigMap cfg",
+		"Deploy ConfigMap cfg done",
+		"Deploy complete",
+		"finish",
+		"start 2",
+		"Undeploy ConfigMap cfg",
+		"Undeploy ConfigMap cfg done",
+		"Undeploy Namespace ns",
+		"Undeploy Namespace ns done",
+		"Undeploy complete",
+		"finish",
+	}
+	if strings.Join(recorder.Events, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected events %v, got %v", expected, recorder.Events)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package harness
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+)
+
+// Recorder is a deploy observer that records the notifications it receives,
+// which lets tests assert on the order of the operations of a deployer
+type Recorder struct {
+	Events []string
+}
+
+// OnStart records the start of a run
+func (r *Recorder) OnStart(total int) {
+	r.Events = append(r.Events, fmt.Sprintf("start %d", total))
+}
+
+// OnComponentStart records the start of an operation on a component
+func (r *Recorder) OnComponentStart(phase api.DeployPhaseType, kind api.ComponentType, name string) {
+	r.Events = append(r.Events, fmt.Sprintf("%s %s %s", phase, kind, name))
+}
+
+// OnComponentDone records the result of an operation on a component
+func (r *Recorder) OnComponentDone(phase api.DeployPhaseType, kind api.ComponentType, name string, duration time.Duration, err error) {
+	r.Events = append(r.Events, fmt.Sprintf("%s %s %s done%s", phase, kind, name, errorSuffix(err)))
+}
+
+// OnPhaseComplete records the end of a phase
+func (r *Recorder) OnPhaseComplete(phase api.DeployPhaseType, err error) {
+	r.Events = append(r.Events, fmt.Sprintf("%s complete%s", phase, errorSuffix(err)))
+}
+
+// OnFinish records the end of a run
+func (r *Recorder) OnFinish(err error) {
+	r.Events = append(r.Events, fmt.Sprintf("finish%s", errorSuffix(err)))
+}
+
+func errorSuffix(err error) string {
+	if err != nil {
+		return ": failed"
+	}
+	return ""
+}
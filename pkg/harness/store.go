@@ -0,0 +1,223 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package harness
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Action defines a change made to the store through the clients
+type Action struct {
+	Verb      string
+	Resource  string
+	Namespace string
+	Name      string
+}
+
+// Store is an in-memory object store that behaves like the api server for
+// the operations used by the deployer.  Objects are keyed by their resource,
+// for example "configmaps", namespace and name
+type Store struct {
+	lock    sync.Mutex
+	objects map[string]runtime.Object
+	actions []Action
+	version int
+}
+
+// NewStore creates an empty Store object
+func NewStore() *Store {
+	return &Store{objects: make(map[string]runtime.Object)}
+}
+
+func storeKey(resource string, namespace string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", resource, namespace, name)
+}
+
+// Add stores an object without recording an action, which can be used to
+// set up the state of the cluster before a test
+func (s *Store) Add(resource string, obj runtime.Object) error {
+	obj = obj.DeepCopyObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.version++
+	accessor.SetResourceVersion(strconv.Itoa(s.version))
+	s.objects[storeKey(resource, accessor.GetNamespace(), accessor.GetName())] = obj
+	return nil
+}
+
+// Get returns a copy of the stored object
+func (s *Store) Get(resource string, namespace string, name string) (runtime.Object, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	obj, ok := s.objects[storeKey(resource, namespace, name)]
+	if !ok {
+		return nil, false
+	}
+	return obj.DeepCopyObject(), true
+}
+
+// List returns copies of the stored objects of a resource in all namespaces
+func (s *Store) List(resource string) []runtime.Object {
+	objs, _ := s.list(resource, metav1.NamespaceAll, metav1.ListOptions{})
+	return objs
+}
+
+// Actions returns the changes made through the clients in the order they happened
+func (s *Store) Actions() []Action {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Action{}, s.actions...)
+}
+
+func (s *Store) get(resource string, namespace string, name string) (runtime.Object, error) {
+	obj, ok := s.Get(resource, namespace, name)
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: resource}, name)
+	}
+	return obj, nil
+}
+
+func (s *Store) create(resource string, namespace string, obj runtime.Object) (runtime.Object, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	obj = obj.DeepCopyObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(namespace) > 0 {
+		accessor.SetNamespace(namespace)
+	}
+
+	key := storeKey(resource, accessor.GetNamespace(), accessor.GetName())
+	if _, ok := s.objects[key]; ok {
+		return nil, errors.NewAlreadyExists(schema.GroupResource{Resource: resource}, accessor.GetName())
+	}
+
+	s.version++
+	accessor.SetResourceVersion(strconv.Itoa(s.version))
+	accessor.SetUID(types.UID(fmt.Sprintf("uid-%d", s.version)))
+	accessor.SetCreationTimestamp(metav1.Now())
+	s.objects[key] = obj
+	s.actions = append(s.actions, Action{Verb: "create", Resource: resource, Namespace: accessor.GetNamespace(), Name: accessor.GetName()})
+	return obj.DeepCopyObject(), nil
+}
+
+func (s *Store) update(resource string, namespace string, obj runtime.Object) (runtime.Object, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	obj = obj.DeepCopyObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(namespace) > 0 {
+		accessor.SetNamespace(namespace)
+	}
+
+	key := storeKey(resource, accessor.GetNamespace(), accessor.GetName())
+	existing, ok := s.objects[key]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: resource}, accessor.GetName())
+	}
+	existingAccessor, err := meta.Accessor(existing)
+	if err != nil {
+		return nil, err
+	}
+	if rv := accessor.GetResourceVersion(); len(rv) > 0 && rv != existingAccessor.GetResourceVersion() {
+		return nil, errors.NewConflict(schema.GroupResource{Resource: resource}, accessor.GetName(), fmt.Errorf("the object has been modified"))
+	}
+
+	s.version++
+	accessor.SetResourceVersion(strconv.Itoa(s.version))
+	accessor.SetUID(existingAccessor.GetUID())
+	accessor.SetCreationTimestamp(existingAccessor.GetCreationTimestamp())
+	s.objects[key] = obj
+	s.actions = append(s.actions, Action{Verb: "update", Resource: resource, Namespace: accessor.GetNamespace(), Name: accessor.GetName()})
+	return obj.DeepCopyObject(), nil
+}
+
+func (s *Store) delete(resource string, namespace string, name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := storeKey(resource, namespace, name)
+	if _, ok := s.objects[key]; !ok {
+		return errors.NewNotFound(schema.GroupResource{Resource: resource}, name)
+	}
+	delete(s.objects, key)
+	s.actions = append(s.actions, Action{Verb: "delete", Resource: resource, Namespace: namespace, Name: name})
+	return nil
+}
+
+func (s *Store) list(resource string, namespace string, opts metav1.ListOptions) ([]runtime.Object, error) {
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	keys := []string{}
+	for key, obj := range s.objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		if key != storeKey(resource, accessor.GetNamespace(), accessor.GetName()) {
+			continue
+		}
+		if len(namespace) > 0 && accessor.GetNamespace() != namespace {
+			continue
+		}
+		if !selector.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	objs := []runtime.Object{}
+	for _, key := range keys {
+		objs = append(objs, s.objects[key].DeepCopyObject())
+	}
+	return objs, nil
+}
@@ -0,0 +1,340 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package loader
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Error defines an invalid definition at a line of a config file
+type Error struct {
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// node is a YAML node whose decoding is deferred until the type to decode it
+// into is known.  yaml only calls unmarshal while the document is decoded, so
+// nodes must not outlive the decoding of the document holding them
+type node struct {
+	line      int
+	unmarshal func(interface{}) error
+}
+
+// UnmarshalYAML records the node and its line
+func (n *node) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	n.unmarshal = unmarshal
+	n.line = nodeLine(unmarshal)
+	return nil
+}
+
+// nodeLine returns the line of a node.  yaml doesn't expose the position of the
+// nodes but reports it when a node can't be decoded, and no node can be decoded
+// in a channel.  Null nodes decode in anything, their line is 0
+func nodeLine(unmarshal func(interface{}) error) int {
+	var probe chan struct{}
+	err, ok := unmarshal(&probe).(*yaml.TypeError)
+	if !ok || len(err.Errors) == 0 {
+		return 0
+	}
+	line := 0
+	fmt.Sscanf(err.Errors[0], "line %d:", &line)
+	return line
+}
+
+// yamlError converts the errors returned by yaml into an Error
+func yamlError(err error, line int) error {
+	message := err.Error()
+	if e, ok := err.(*yaml.TypeError); ok && len(e.Errors) > 0 {
+		message = e.Errors[0]
+	}
+	message = strings.TrimPrefix(message, "yaml: ")
+	fmt.Sscanf(message, "line %d:", &line)
+	if i := strings.Index(message, ": "); i >= 0 && strings.HasPrefix(message, "line ") {
+		message = message[i+2:]
+	}
+	return &Error{Line: line, Message: message}
+}
+
+// enums maps every api enum type to its constants by name
+var enums = map[reflect.Type]map[string]reflect.Value{}
+
+func init() {
+	for name, value := range api.Constants {
+		v := reflect.ValueOf(value)
+		if enums[v.Type()] == nil {
+			enums[v.Type()] = map[string]reflect.Value{}
+		}
+		enums[v.Type()][name] = v
+	}
+}
+
+var (
+	apiPackage    = reflect.TypeOf(api.DeploymentConfig{}).PkgPath()
+	loaderPackage = reflect.TypeOf(decoder{}).PkgPath()
+	nodeType      = reflect.TypeOf(&node{})
+)
+
+// decoder decodes nodes into the api configs and the specs of the loader.  The
+// keys of a mapping match the name of a struct field regardless of the case,
+// the fields of the embedded structs being promoted as in Go, and enum values
+// are given by their Kubernetes spelling or the name of their api constant
+type decoder struct {
+	// lines holds the line of every struct decoded, keyed by its address
+	lines map[interface{}]int
+}
+
+func newDecoder() *decoder {
+	return &decoder{lines: map[interface{}]int{}}
+}
+
+// errorf returns an Error at the line of a decoded struct
+func (d *decoder) errorf(at interface{}, format string, args ...interface{}) error {
+	return &Error{Line: d.lines[at], Message: fmt.Sprintf(format, args...)}
+}
+
+func (d *decoder) decode(n *node, v reflect.Value) error {
+	if v.Type() == nodeType {
+		v.Set(reflect.ValueOf(n))
+		return nil
+	}
+
+	var value interface{}
+	if err := n.unmarshal(&value); err != nil {
+		return yamlError(err, n.line)
+	}
+	if value == nil {
+		return nil
+	}
+
+	t := v.Type()
+	if names, ok := enums[t]; ok {
+		var name string
+		if err := n.unmarshal(&name); err != nil {
+			return yamlError(err, n.line)
+		}
+		if constant, ok := names[name]; ok {
+			v.Set(constant)
+			return nil
+		}
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(name)); err != nil {
+				return &Error{Line: n.line, Message: err.Error()}
+			}
+			return nil
+		}
+		return &Error{Line: n.line, Message: fmt.Sprintf("unknown %s %q", t.Name(), name)}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(t.Elem())
+		if err := d.decode(n, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Struct:
+		if t.PkgPath() != apiPackage && t.PkgPath() != loaderPackage {
+			return d.decodeForeign(n, value, v)
+		}
+		return d.decodeStruct(n, v)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			var s string
+			if err := n.unmarshal(&s); err != nil {
+				return yamlError(err, n.line)
+			}
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return &Error{Line: n.line, Message: fmt.Sprintf("invalid base64 data: %v", err)}
+			}
+			v.SetBytes(data)
+			return nil
+		}
+		var items []*node
+		if err := n.unmarshal(&items); err != nil {
+			if t.Elem() == nodeType {
+				return &Error{Line: n.line, Message: "expected a list"}
+			}
+			return &Error{Line: n.line, Message: fmt.Sprintf("expected a list of %s", typeName(t.Elem()))}
+		}
+		// the elements are decoded in place so that the lines recorded for
+		// them stay valid
+		slice := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := d.decode(item, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Map:
+		var items map[string]*node
+		if err := n.unmarshal(&items); err != nil {
+			return &Error{Line: n.line, Message: fmt.Sprintf("expected a mapping of %s", typeName(t.Elem()))}
+		}
+		m := reflect.MakeMapWithSize(t, len(items))
+		for _, key := range keysInOrder(items) {
+			elem := reflect.New(t.Elem()).Elem()
+			if err := d.decode(items[key], elem); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elem)
+		}
+		v.Set(m)
+	default:
+		if err := n.unmarshal(v.Addr().Interface()); err != nil {
+			return yamlError(err, n.line)
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeStruct(n *node, v reflect.Value) error {
+	var fields map[string]*node
+	if err := n.unmarshal(&fields); err != nil {
+		return &Error{Line: n.line, Message: fmt.Sprintf("expected a mapping for %s", typeName(v.Type()))}
+	}
+	return d.decodeFields(n, fields, v)
+}
+
+// decodeFields decodes the fields of a mapping into a struct
+func (d *decoder) decodeFields(n *node, fields map[string]*node, v reflect.Value) error {
+	d.lines[v.Addr().Interface()] = n.line
+
+	index := fieldIndex(v.Type())
+	for _, key := range keysInOrder(fields) {
+		i, ok := index[strings.ToLower(key)]
+		if !ok {
+			line := fields[key].line
+			if line == 0 {
+				line = n.line
+			}
+			return &Error{Line: line, Message: fmt.Sprintf("unknown field %q in %s", key, typeName(v.Type()))}
+		}
+		if err := d.decode(fields[key], v.FieldByIndex(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeForeign decodes the structs defined outside of Horizon, like the CRD
+// schemas, through their JSON encoding
+func (d *decoder) decodeForeign(n *node, value interface{}, v reflect.Value) error {
+	data, err := json.Marshal(jsonValue(value))
+	if err == nil {
+		err = json.Unmarshal(data, v.Addr().Interface())
+	}
+	if err != nil {
+		return &Error{Line: n.line, Message: fmt.Sprintf("invalid %s: %v", typeName(v.Type()), err)}
+	}
+	return nil
+}
+
+// jsonValue converts the mappings decoded by yaml to mappings JSON can encode
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, elem := range v {
+			m[fmt.Sprint(key)] = jsonValue(elem)
+		}
+		return m
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = jsonValue(elem)
+		}
+	}
+	return value
+}
+
+// fieldIndex maps the lower case name of the fields of a struct to their index,
+// the fields of the embedded structs being promoted
+func fieldIndex(t reflect.Type) map[string][]int {
+	index := map[string][]int{}
+	embedded := []reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, f)
+			continue
+		}
+		index[strings.ToLower(f.Name)] = f.Index
+	}
+	for _, f := range embedded {
+		for name, i := range fieldIndex(f.Type) {
+			if _, ok := index[name]; !ok {
+				index[name] = append(append([]int{}, f.Index...), i...)
+			}
+		}
+	}
+	return index
+}
+
+// typeName names a type in the error messages, the specs of the loader being
+// named after what they define
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == loaderPackage {
+		return strings.TrimSuffix(t.Name(), "Spec")
+	}
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// keysInOrder returns the keys of a mapping in the order they appear in the file
+func keysInOrder(m map[string]*node) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if m[keys[i]].line != m[keys[j]].line {
+			return m[keys[i]].line < m[keys[j]].line
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
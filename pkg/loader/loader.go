@@ -0,0 +1,152 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package loader builds components from declarative Horizon config files.
+//
+// A config file is a YAML or JSON document listing components.  Every
+// component gives its kind, the fields of the api config of that kind, and the
+// fields the component adds after its creation, like the labels, the ports of
+// a service or the containers and volumes of a pod:
+//
+//	components:
+//	- kind: Deployment
+//	  name: web
+//	  namespace: shop
+//	  replicas: 2
+//	  selector:
+//	    labels: {app: web}
+//	  pod:
+//	    labels: {app: web}
+//	    containers:
+//	    - name: web
+//	      image: nginx:1.15
+//	      ports:
+//	      - containerPort: 80
+//	        protocol: TCP
+//
+// The keys match the fields of the api configs regardless of their case, and
+// the enum values are given by their Kubernetes spelling, like TCP, or by the
+// name of their api constant, like ProtocolTCP
+package loader
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"gopkg.in/yaml.v2"
+)
+
+// file defines the content of a config file
+type file struct {
+	Components []*node
+}
+
+// Load reads a config file and builds its components.  The errors point to the
+// line of the invalid definition
+func Load(r io.Reader) ([]components.DecodedComponent, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// the nodes are only valid while yaml decodes the document, so the
+	// components are built from within the decoding
+	var loaded []components.DecodedComponent
+	var loadErr error
+	root := loadFunc(func(n *node) {
+		loaded, loadErr = load(n)
+	})
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlError(err, 0)
+	}
+	return loaded, loadErr
+}
+
+// loadFunc is called with the root node of the document
+type loadFunc func(n *node)
+
+func (f loadFunc) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	n := &node{}
+	if err := n.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	f(n)
+	return nil
+}
+
+func load(root *node) ([]components.DecodedComponent, error) {
+	d := newDecoder()
+	f := file{}
+	if err := d.decode(root, reflect.ValueOf(&f).Elem()); err != nil {
+		return nil, err
+	}
+
+	loaded := make([]components.DecodedComponent, 0, len(f.Components))
+	for _, n := range f.Components {
+		spec, err := d.componentSpec(n)
+		if err != nil {
+			return nil, err
+		}
+		kind, component, err := spec.build(d)
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, components.DecodedComponent{Kind: kind, Component: component})
+	}
+	return loaded, nil
+}
+
+// componentSpec decodes a component into the spec of its kind
+func (d *decoder) componentSpec(n *node) (componentSpec, error) {
+	var fields map[string]*node
+	if err := n.unmarshal(&fields); err != nil {
+		return nil, &Error{Line: n.line, Message: "expected a mapping for the component"}
+	}
+	var kindNode *node
+	for key, value := range fields {
+		if strings.EqualFold(key, "kind") {
+			kindNode = value
+			delete(fields, key)
+		}
+	}
+	if kindNode == nil {
+		return nil, &Error{Line: n.line, Message: "the component has no kind"}
+	}
+
+	var kind string
+	if err := kindNode.unmarshal(&kind); err != nil {
+		return nil, yamlError(err, kindNode.line)
+	}
+	newSpec, ok := specs[kind]
+	if !ok {
+		return nil, &Error{Line: kindNode.line, Message: fmt.Sprintf("unknown kind %q", kind)}
+	}
+	spec := newSpec()
+	if err := d.decodeFields(n, fields, reflect.ValueOf(spec).Elem()); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package loader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/core/v1"
+)
+
+const config = `components:
+- kind: Namespace
+  name: shop
+- kind: ConfigMap
+  name: settings
+  namespace: shop
+  data:
+    mode: production
+- kind: Deployment
+  name: web
+  namespace: shop
+  replicas: 2
+  strategy: DeploymentStrategyTypeRecreate
+  labels:
+    app: web
+  selector:
+    labels:
+      app: web
+  pod:
+    labels:
+      app: web
+    serviceAccount: web
+    volumes:
+    - configMap:
+        volumeName: settings
+        mapOrSecretName: settings
+    containers:
+    - name: web
+      image: nginx:1.15
+      minCPU: 100m
+      env:
+      - nameOrPrefix: MODE
+        type: EnvFromConfigMap
+        keyOrVal: mode
+        fromName: settings
+      ports:
+      - containerPort: 80
+        protocol: ProtocolTCP
+      volumeMounts:
+      - name: settings
+        mountPath: /etc/web
+      livenessProbe:
+        type: ActionTypeHTTP
+        port: "80"
+        path: /healthz
+        delay: 5
+- kind: Service
+  name: web
+  namespace: shop
+  type: ServiceTypeNodePort
+  selector:
+    app: web
+  ports:
+  - port: 80
+    targetPort: "80"
+    protocol: TCP
+`
+
+func TestLoad(t *testing.T) {
+	loaded, err := Load(strings.NewReader(config))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kinds := []api.ComponentType{api.NamespaceComponent, api.ConfigMapComponent, api.DeploymentComponent, api.ServiceComponent}
+	if len(loaded) != len(kinds) {
+		t.Fatalf("expected %d components, got %d", len(kinds), len(loaded))
+	}
+	for i, kind := range kinds {
+		if loaded[i].Kind != kind {
+			t.Errorf("expected component %d to be a %s, got %s", i, kind, loaded[i].Kind)
+		}
+	}
+
+	deployment := loaded[2].Component.(*components.Deployment)
+	if *deployment.Spec.Replicas != 2 || deployment.Namespace != "shop" || deployment.Labels["app"] != "web" {
+		t.Errorf("unexpected deployment %+v", deployment.Deployment)
+	}
+	if deployment.Spec.Selector.MatchLabels["app"] != "web" {
+		t.Errorf("unexpected selector %+v", deployment.Spec.Selector)
+	}
+	pod := deployment.Spec.Template
+	if pod.Labels["app"] != "web" || pod.Spec.ServiceAccountName != "web" || len(pod.Spec.Volumes) != 1 {
+		t.Errorf("unexpected pod template %+v", pod)
+	}
+	if len(pod.Spec.Containers) != 1 {
+		t.Fatalf("expected a container, got %+v", pod.Spec.Containers)
+	}
+	container := pod.Spec.Containers[0]
+	if container.Image != "nginx:1.15" || container.Resources.Requests.Cpu().String() != "100m" {
+		t.Errorf("unexpected container %+v", container)
+	}
+	if len(container.Env) != 1 || container.Env[0].ValueFrom.ConfigMapKeyRef.Name != "settings" {
+		t.Errorf("unexpected env %+v", container.Env)
+	}
+	if len(container.Ports) != 1 || container.Ports[0].ContainerPort != 80 || container.Ports[0].Protocol != v1.ProtocolTCP {
+		t.Errorf("unexpected ports %+v", container.Ports)
+	}
+	if len(container.VolumeMounts) != 1 || container.LivenessProbe == nil || container.LivenessProbe.HTTPGet.Path != "/healthz" {
+		t.Errorf("unexpected container %+v", container)
+	}
+
+	service := loaded[3].Component.(*components.Service)
+	if service.Spec.Type != v1.ServiceTypeNodePort || service.Spec.Selector["app"] != "web" || len(service.Spec.Ports) != 1 {
+		t.Errorf("unexpected service %+v", service.Spec)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	loaded, err := Load(strings.NewReader(`{
+  "components": [
+    {"kind": "Secret", "name": "tls", "type": "kubernetes.io/tls", "data": {"tls.key": "a2V5"}}
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded) != 1 {
+		t.Fatalf("expected a component, got %d", len(loaded))
+	}
+	secret := loaded[0].Component.(*components.Secret)
+	if secret.Type != v1.SecretTypeTLS || string(secret.Data["tls.key"]) != "key" {
+		t.Errorf("unexpected secret %+v", secret.Secret)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	var tests = []struct {
+		description string
+		config      string
+		expected    string
+	}{
+		{
+			description: "syntax error",
+			config:      "components:\n- kind: Namespace\n  name: [shop\n",
+			expected:    "line 3: ",
+		},
+		{
+			description: "unknown kind",
+			config:      "components:\n- name: shop\n  kind: Namespaces\n",
+			expected:    `line 3: unknown kind "Namespaces"`,
+		},
+		{
+			description: "missing kind",
+			config:      "components:\n- name: shop\n",
+			expected:    "line 2: the component has no kind",
+		},
+		{
+			description: "unknown field",
+			config:      "components:\n- kind: Deployment\n  name: web\n  replica: 2\n",
+			expected:    `line 4: unknown field "replica" in deployment`,
+		},
+		{
+			description: "invalid type",
+			config:      "components:\n- kind: Deployment\n  name: web\n  replicas: two\n",
+			expected:    "line 4: cannot unmarshal !!str `two` into int32",
+		},
+		{
+			description: "unknown enum value",
+			config:      "components:\n- kind: Service\n  name: web\n  ports:\n  - port: 80\n    protocol: TPC\n",
+			expected:    `line 6: unknown ProtocolType "TPC"`,
+		},
+		{
+			description: "nested unknown field",
+			config:      "components:\n- kind: Pod\n  name: web\n  containers:\n  - name: web\n    image: nginx\n    port: 80\n",
+			expected:    `line 7: unknown field "port" in container`,
+		},
+		{
+			description: "invalid container",
+			config:      "components:\n- kind: Pod\n  name: web\n  containers:\n  - name: web\n    minCPU: lots\n",
+			expected:    "line 5: ",
+		},
+		{
+			description: "invalid volume",
+			config:      "components:\n- kind: Pod\n  name: web\n  volumes:\n  - emptyDir: {volumeName: a}\n    hostPath: {volumeName: a, path: /tmp}\n",
+			expected:    "line 5: a volume needs exactly one source, found 2",
+		},
+		{
+			description: "missing pod template",
+			config:      "components:\n- kind: Namespace\n  name: shop\n- kind: Deployment\n  name: web\n",
+			expected:    "line 4: no pod template",
+		},
+		{
+			description: "not a list",
+			config:      "components:\n  kind: Namespace\n",
+			expected:    "line 2: expected a list",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Load(strings.NewReader(test.config))
+		if err == nil {
+			t.Errorf("%s: expected an error", test.description)
+			continue
+		}
+		if _, ok := err.(*Error); !ok {
+			t.Errorf("%s: expected an Error, got %T", test.description, err)
+		}
+		if !strings.HasPrefix(err.Error(), test.expected) {
+			t.Errorf("%s: expected an error starting with %q, got %q", test.description, test.expected, err.Error())
+		}
+	}
+}
@@ -0,0 +1,647 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package loader
+
+import (
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+)
+
+// componentSpec defines a component in a config file.  The specs embed the
+// config of their component so that its fields are given inline, next to the
+// fields the components add after their creation
+type componentSpec interface {
+	build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error)
+}
+
+// specs creates the spec of every kind of component
+var specs = map[string]func() componentSpec{
+	"ClusterRole":              func() componentSpec { return &clusterRoleSpec{} },
+	"ClusterRoleBinding":       func() componentSpec { return &clusterRoleBindingSpec{} },
+	"ConfigMap":                func() componentSpec { return &configMapSpec{} },
+	"CustomResourceDefinition": func() componentSpec { return &crdSpec{} },
+	"DaemonSet":                func() componentSpec { return &daemonSetSpec{} },
+	"Deployment":               func() componentSpec { return &deploymentSpec{} },
+	"HorizontalPodAutoscaler":  func() componentSpec { return &hpaSpec{} },
+	"Ingress":                  func() componentSpec { return &ingressSpec{} },
+	"Job":                      func() componentSpec { return &jobSpec{} },
+	"Namespace":                func() componentSpec { return &namespaceSpec{} },
+	"PersistentVolumeClaim":    func() componentSpec { return &pvcSpec{} },
+	"Pod":                      func() componentSpec { return &podSpec{} },
+	"ReplicationController":    func() componentSpec { return &replicationControllerSpec{} },
+	"Role":                     func() componentSpec { return &roleSpec{} },
+	"RoleBinding":              func() componentSpec { return &roleBindingSpec{} },
+	"Secret":                   func() componentSpec { return &secretSpec{} },
+	"Service":                  func() componentSpec { return &serviceSpec{} },
+	"ServiceAccount":           func() componentSpec { return &serviceAccountSpec{} },
+	"StatefulSet":              func() componentSpec { return &statefulSetSpec{} },
+}
+
+type metadataComponent interface {
+	AddLabels(map[string]string)
+	AddAnnotations(map[string]string)
+}
+
+type metadataSpec struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+func (s *metadataSpec) apply(c metadataComponent) {
+	if len(s.Labels) > 0 {
+		c.AddLabels(s.Labels)
+	}
+	if len(s.Annotations) > 0 {
+		c.AddAnnotations(s.Annotations)
+	}
+}
+
+type selectorComponent interface {
+	AddMatchLabelsSelectors(map[string]string)
+	AddMatchExpressionsSelector(api.ExpressionRequirementConfig)
+}
+
+func applySelector(c selectorComponent, selector *api.SelectorConfig) {
+	if selector == nil {
+		return
+	}
+	if len(selector.Labels) > 0 {
+		c.AddMatchLabelsSelectors(selector.Labels)
+	}
+	for _, expression := range selector.Expressions {
+		c.AddMatchExpressionsSelector(expression)
+	}
+}
+
+type namespaceSpec struct {
+	api.NamespaceConfig
+	metadataSpec
+}
+
+func (s *namespaceSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	ns := components.NewNamespace(s.NamespaceConfig)
+	s.apply(ns)
+	return api.NamespaceComponent, ns, nil
+}
+
+type configMapSpec struct {
+	api.ConfigMapConfig
+	metadataSpec
+	Data       map[string]string
+	BinaryData map[string][]byte
+}
+
+func (s *configMapSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	cm := components.NewConfigMap(s.ConfigMapConfig)
+	s.apply(cm)
+	if len(s.Data) > 0 {
+		cm.AddData(s.Data)
+	}
+	if len(s.BinaryData) > 0 {
+		cm.AddBinaryData(s.BinaryData)
+	}
+	return api.ConfigMapComponent, cm, nil
+}
+
+type secretSpec struct {
+	api.SecretConfig
+	metadataSpec
+	Data       map[string][]byte
+	StringData map[string]string
+}
+
+func (s *secretSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	secret := components.NewSecret(s.SecretConfig)
+	s.apply(secret)
+	if len(s.Data) > 0 {
+		secret.AddData(s.Data)
+	}
+	if len(s.StringData) > 0 {
+		secret.AddStringData(s.StringData)
+	}
+	return api.SecretComponent, secret, nil
+}
+
+type serviceAccountSpec struct {
+	api.ServiceAccountConfig
+	metadataSpec
+	PullSecrets []string
+	Secrets     []api.ServiceAccountSecretConfig
+}
+
+func (s *serviceAccountSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	sa := components.NewServiceAccount(s.ServiceAccountConfig)
+	s.apply(sa)
+	if len(s.PullSecrets) > 0 {
+		sa.AddPullSecrets(s.PullSecrets)
+	}
+	for _, secret := range s.Secrets {
+		sa.AddSecret(secret)
+	}
+	return api.ServiceAccountComponent, sa, nil
+}
+
+type clusterRoleSpec struct {
+	api.ClusterRoleConfig
+	metadataSpec
+	Rules            []api.PolicyRuleConfig
+	AggregationRules []api.SelectorConfig
+}
+
+func (s *clusterRoleSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	cr := components.NewClusterRole(s.ClusterRoleConfig)
+	s.apply(cr)
+	for _, rule := range s.Rules {
+		cr.AddPolicyRule(rule)
+	}
+	for _, rule := range s.AggregationRules {
+		cr.AddAggregationRule(rule)
+	}
+	return api.ClusterRoleComponent, cr, nil
+}
+
+type roleSpec struct {
+	api.RoleConfig
+	metadataSpec
+	Rules []api.PolicyRuleConfig
+}
+
+func (s *roleSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	r := components.NewRole(s.RoleConfig)
+	s.apply(r)
+	for _, rule := range s.Rules {
+		r.AddPolicyRule(rule)
+	}
+	return api.RoleComponent, r, nil
+}
+
+type clusterRoleBindingSpec struct {
+	api.ClusterRoleBindingConfig
+	metadataSpec
+	Subjects []api.SubjectConfig
+	RoleRef  *api.RoleRefConfig
+}
+
+func (s *clusterRoleBindingSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	crb := components.NewClusterRoleBinding(s.ClusterRoleBindingConfig)
+	s.apply(crb)
+	for _, subject := range s.Subjects {
+		crb.AddSubject(subject)
+	}
+	if s.RoleRef == nil {
+		return "", nil, d.errorf(s, "cluster role binding %s has no roleRef", s.Name)
+	}
+	crb.AddRoleRef(*s.RoleRef)
+	return api.ClusterRoleBindingComponent, crb, nil
+}
+
+type roleBindingSpec struct {
+	api.RoleBindingConfig
+	metadataSpec
+	Subjects []api.SubjectConfig
+	RoleRef  *api.RoleRefConfig
+}
+
+func (s *roleBindingSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	rb := components.NewRoleBinding(s.RoleBindingConfig)
+	s.apply(rb)
+	for _, subject := range s.Subjects {
+		rb.AddSubject(subject)
+	}
+	if s.RoleRef == nil {
+		return "", nil, d.errorf(s, "role binding %s has no roleRef", s.Name)
+	}
+	rb.AddRoleRef(*s.RoleRef)
+	return api.RoleBindingComponent, rb, nil
+}
+
+type serviceSpec struct {
+	api.ServiceConfig
+	metadataSpec
+	Selector     map[string]string
+	Ports        []api.ServicePortConfig
+	ExternalIPs  []string
+	LoadBalancer *api.LoadBalancerConfig
+}
+
+func (s *serviceSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	svc := components.NewService(s.ServiceConfig)
+	s.apply(svc)
+	if len(s.Selector) > 0 {
+		svc.AddSelectors(s.Selector)
+	}
+	for i := range s.Ports {
+		if err := svc.AddPort(s.Ports[i]); err != nil {
+			return "", nil, d.errorf(&s.Ports[i], "%v", err)
+		}
+	}
+	if len(s.ExternalIPs) > 0 {
+		svc.AddExternalIPs(s.ExternalIPs)
+	}
+	if s.LoadBalancer != nil {
+		if err := svc.AddLoadBalancer(*s.LoadBalancer); err != nil {
+			return "", nil, d.errorf(s.LoadBalancer, "%v", err)
+		}
+	}
+	return api.ServiceComponent, svc, nil
+}
+
+type pvcSpec struct {
+	api.PVCConfig
+	metadataSpec
+	Selector    *api.SelectorConfig
+	AccessModes []api.PVCAccessModeType
+}
+
+func (s *pvcSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	pvc, err := s.claim(d)
+	return api.PersistentVolumeClaimComponent, pvc, err
+}
+
+func (s *pvcSpec) claim(d *decoder) (*components.PersistentVolumeClaim, error) {
+	pvc, err := components.NewPersistentVolumeClaim(s.PVCConfig)
+	if err != nil {
+		return nil, d.errorf(s, "%v", err)
+	}
+	s.apply(pvc)
+	applySelector(pvc, s.Selector)
+	for _, mode := range s.AccessModes {
+		pvc.AddAccessMode(mode)
+	}
+	return pvc, nil
+}
+
+type deploymentSpec struct {
+	api.DeploymentConfig
+	metadataSpec
+	Selector *api.SelectorConfig
+	Pod      *podSpec
+}
+
+func (s *deploymentSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	deployment := components.NewDeployment(s.DeploymentConfig)
+	s.apply(deployment)
+	applySelector(deployment, s.Selector)
+	pod, err := d.podTemplate(s, s.Pod)
+	if err != nil {
+		return "", nil, err
+	}
+	deployment.AddPod(pod)
+	return api.DeploymentComponent, deployment, nil
+}
+
+type statefulSetSpec struct {
+	api.StatefulSetConfig
+	metadataSpec
+	Selector             *api.SelectorConfig
+	Pod                  *podSpec
+	VolumeClaimTemplates []pvcSpec
+}
+
+func (s *statefulSetSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	ss := components.NewStatefulSet(s.StatefulSetConfig)
+	s.apply(ss)
+	applySelector(ss, s.Selector)
+	pod, err := d.podTemplate(s, s.Pod)
+	if err != nil {
+		return "", nil, err
+	}
+	ss.AddPod(pod)
+	for i := range s.VolumeClaimTemplates {
+		claim, err := s.VolumeClaimTemplates[i].claim(d)
+		if err != nil {
+			return "", nil, err
+		}
+		ss.AddVolumeClaimTemplate(*claim)
+	}
+	return api.StatefulSetComponent, ss, nil
+}
+
+type daemonSetSpec struct {
+	api.DaemonSetConfig
+	metadataSpec
+	Selector *api.SelectorConfig
+	Pod      *podSpec
+}
+
+func (s *daemonSetSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	ds := components.NewDaemonSet(s.DaemonSetConfig)
+	s.apply(ds)
+	applySelector(ds, s.Selector)
+	pod, err := d.podTemplate(s, s.Pod)
+	if err != nil {
+		return "", nil, err
+	}
+	ds.AddPod(pod)
+	return api.DaemonSetComponent, ds, nil
+}
+
+type jobSpec struct {
+	api.JobConfig
+	metadataSpec
+	Selector *api.SelectorConfig
+	Pod      *podSpec
+}
+
+func (s *jobSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	job := components.NewJob(s.JobConfig)
+	s.apply(job)
+	applySelector(job, s.Selector)
+	pod, err := d.podTemplate(s, s.Pod)
+	if err != nil {
+		return "", nil, err
+	}
+	job.AddPod(pod)
+	return api.JobComponent, job, nil
+}
+
+type replicationControllerSpec struct {
+	api.ReplicationControllerConfig
+	metadataSpec
+	Selector map[string]string
+	Pod      *podSpec
+}
+
+func (s *replicationControllerSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	rc := components.NewReplicationController(s.ReplicationControllerConfig)
+	s.apply(rc)
+	if len(s.Selector) > 0 {
+		rc.AddSelectors(s.Selector)
+	}
+	pod, err := d.podTemplate(s, s.Pod)
+	if err != nil {
+		return "", nil, err
+	}
+	rc.AddPod(pod)
+	return api.ReplicationControllerComponent, rc, nil
+}
+
+type hpaSpec struct {
+	api.HPAConfig
+	metadataSpec
+}
+
+func (s *hpaSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	hpa := components.NewHorizontalPodAutoscaler(s.HPAConfig)
+	s.apply(hpa)
+	return api.HorizontalPodAutoscalerComponent, hpa, nil
+}
+
+type ingressSpec struct {
+	api.IngressConfig
+	metadataSpec
+	TLS   []api.IngressTLSConfig
+	Rules []api.IngressHostRuleConfig
+}
+
+func (s *ingressSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	ingress, err := components.NewIngress(s.IngressConfig)
+	if err != nil {
+		return "", nil, d.errorf(s, "%v", err)
+	}
+	s.apply(ingress)
+	for _, tls := range s.TLS {
+		ingress.AddTLS(tls)
+	}
+	for _, rule := range s.Rules {
+		ingress.AddHostRule(rule)
+	}
+	return api.IngressComponent, ingress, nil
+}
+
+type crdSpec struct {
+	api.CRDConfig
+	metadataSpec
+}
+
+func (s *crdSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	crd := components.NewCustomResourceDefintion(s.CRDConfig)
+	s.apply(crd)
+	return api.CRDComponent, crd, nil
+}
+
+type nodeAffinitySpec struct {
+	api.NodeAffinityConfig
+	Type api.AffinityType
+}
+
+type podAffinitySpec struct {
+	api.PodAffinityConfig
+	Type api.AffinityType
+}
+
+type podSpec struct {
+	api.PodConfig
+	metadataSpec
+	Containers        []containerSpec
+	InitContainers    []containerSpec
+	Volumes           []volumeSpec
+	HostModes         []api.HostModeType
+	SupplementalGIDs  []int64
+	Sysctls           map[string]string
+	ImagePullSecrets  []string
+	HostAliases       []api.HostAliasConfig
+	Tolerations       []api.TolerationConfig
+	NodeSelector      map[string]string
+	DNS               *api.PodDNSConfig
+	NodeAffinities    []nodeAffinitySpec
+	PodAffinities     []podAffinitySpec
+	PodAntiAffinities []podAffinitySpec
+}
+
+func (s *podSpec) build(d *decoder) (api.ComponentType, api.DeployableComponentInterface, error) {
+	pod, err := s.pod(d)
+	return api.PodComponent, pod, err
+}
+
+// podTemplate builds the pod template of a controller
+func (d *decoder) podTemplate(controller interface{}, s *podSpec) (*components.Pod, error) {
+	if s == nil {
+		return nil, d.errorf(controller, "no pod template")
+	}
+	return s.pod(d)
+}
+
+func (s *podSpec) pod(d *decoder) (*components.Pod, error) {
+	pod := components.NewPod(s.PodConfig)
+	s.apply(pod)
+	for i := range s.Volumes {
+		volume, err := s.Volumes[i].volume(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := pod.AddVolume(volume); err != nil {
+			return nil, d.errorf(&s.Volumes[i], "%v", err)
+		}
+	}
+	for i := range s.InitContainers {
+		container, err := s.InitContainers[i].container(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := pod.AddInitContainer(container); err != nil {
+			return nil, d.errorf(&s.InitContainers[i], "%v", err)
+		}
+	}
+	for i := range s.Containers {
+		container, err := s.Containers[i].container(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := pod.AddContainer(container); err != nil {
+			return nil, d.errorf(&s.Containers[i], "%v", err)
+		}
+	}
+	for _, mode := range s.HostModes {
+		pod.AddHostMode(mode)
+	}
+	if len(s.SupplementalGIDs) > 0 {
+		pod.AddSupplementalGIDs(s.SupplementalGIDs)
+	}
+	if len(s.Sysctls) > 0 {
+		pod.AddSysctls(s.Sysctls)
+	}
+	if len(s.ImagePullSecrets) > 0 {
+		pod.AddImagePullSecrets(s.ImagePullSecrets)
+	}
+	if len(s.HostAliases) > 0 {
+		pod.AddHostAliases(s.HostAliases)
+	}
+	if len(s.Tolerations) > 0 {
+		pod.AddTolerations(s.Tolerations)
+	}
+	if len(s.NodeSelector) > 0 {
+		pod.AddNodeSelectors(s.NodeSelector)
+	}
+	if s.DNS != nil {
+		pod.AddDNSConfig(*s.DNS)
+	}
+	for i, affinity := range s.NodeAffinities {
+		if err := pod.AddNodeAffinity(affinity.Type, affinity.NodeAffinityConfig); err != nil {
+			return nil, d.errorf(&s.NodeAffinities[i], "%v", err)
+		}
+	}
+	for _, affinity := range s.PodAffinities {
+		pod.AddPodAffinity(affinity.Type, affinity.PodAffinityConfig)
+	}
+	for _, affinity := range s.PodAntiAffinities {
+		pod.AddPodAntiAffinity(affinity.Type, affinity.PodAffinityConfig)
+	}
+	return pod, nil
+}
+
+type containerSpec struct {
+	api.ContainerConfig
+	Env              []api.EnvConfig
+	Ports            []api.PortConfig
+	VolumeMounts     []api.VolumeMountConfig
+	VolumeDevices    []api.VolumeDeviceConfig
+	LivenessProbe    *api.ProbeConfig
+	ReadinessProbe   *api.ProbeConfig
+	PostStart        *api.ActionConfig
+	PreStop          *api.ActionConfig
+	AddCapabilities  []string
+	DropCapabilities []string
+}
+
+func (s *containerSpec) container(d *decoder) (*components.Container, error) {
+	container, err := components.NewContainer(s.ContainerConfig)
+	if err != nil {
+		return nil, d.errorf(s, "%v", err)
+	}
+	for _, env := range s.Env {
+		container.AddEnv(env)
+	}
+	for _, port := range s.Ports {
+		container.AddPort(port)
+	}
+	for i := range s.VolumeMounts {
+		if err := container.AddVolumeMount(s.VolumeMounts[i]); err != nil {
+			return nil, d.errorf(&s.VolumeMounts[i], "%v", err)
+		}
+	}
+	for i := range s.VolumeDevices {
+		if err := container.AddVolumeDevice(s.VolumeDevices[i]); err != nil {
+			return nil, d.errorf(&s.VolumeDevices[i], "%v", err)
+		}
+	}
+	if s.LivenessProbe != nil {
+		container.AddLivenessProbe(*s.LivenessProbe)
+	}
+	if s.ReadinessProbe != nil {
+		container.AddReadinessProbe(*s.ReadinessProbe)
+	}
+	if s.PostStart != nil {
+		container.AddPostStartAction(*s.PostStart)
+	}
+	if s.PreStop != nil {
+		container.AddPreStopAction(*s.PreStop)
+	}
+	if len(s.AddCapabilities) > 0 {
+		container.AddAddCapabilities(s.AddCapabilities)
+	}
+	if len(s.DropCapabilities) > 0 {
+		container.AddDeleteCapabilities(s.DropCapabilities)
+	}
+	return container, nil
+}
+
+// volumeSpec defines a volume by the config of its source, of which exactly
+// one must be given
+type volumeSpec struct {
+	EmptyDir              *api.EmptyDirVolumeConfig
+	HostPath              *api.HostPathVolumeConfig
+	ConfigMap             *api.ConfigMapOrSecretVolumeConfig
+	Secret                *api.ConfigMapOrSecretVolumeConfig
+	GCEPersistentDisk     *api.GCEPersistentDiskVolumeConfig
+	PersistentVolumeClaim *api.PVCVolumeConfig
+}
+
+func (s *volumeSpec) volume(d *decoder) (*components.Volume, error) {
+	volumes := []*components.Volume{}
+	if s.EmptyDir != nil {
+		volume, err := components.NewEmptyDirVolume(*s.EmptyDir)
+		if err != nil {
+			return nil, d.errorf(s, "%v", err)
+		}
+		volumes = append(volumes, volume)
+	}
+	if s.HostPath != nil {
+		volumes = append(volumes, components.NewHostPathVolume(*s.HostPath))
+	}
+	if s.ConfigMap != nil {
+		volumes = append(volumes, components.NewConfigMapVolume(*s.ConfigMap))
+	}
+	if s.Secret != nil {
+		volumes = append(volumes, components.NewSecretVolume(*s.Secret))
+	}
+	if s.GCEPersistentDisk != nil {
+		volumes = append(volumes, components.NewGCEPersistentDiskVolume(*s.GCEPersistentDisk))
+	}
+	if s.PersistentVolumeClaim != nil {
+		volumes = append(volumes, components.NewPVCVolume(*s.PersistentVolumeClaim))
+	}
+	if len(volumes) != 1 {
+		return nil, d.errorf(s, "a volume needs exactly one source, found %d", len(volumes))
+	}
+	return volumes[0], nil
+}
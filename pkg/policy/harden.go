@@ -0,0 +1,211 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HardeningExemptionAnnotation exempts a component from hardening.  Its
+	// value is the justification of the exemption, which can't be empty
+	HardeningExemptionAnnotation = "horizon.blackducksoftware.com/hardening-exemption"
+	// WritablePathsAnnotation lists the paths, separated by commas, the
+	// containers of a component write to.  Hardening mounts an empty dir at
+	// each of them, since the root filesystem is made read-only
+	WritablePathsAnnotation = "horizon.blackducksoftware.com/writable-paths"
+	// NetBindServiceAnnotation lists the containers, separated by commas, of a
+	// component that listen on ports below 1024.  Hardening gives them the
+	// NET_BIND_SERVICE capability, since all other capabilities are dropped
+	NetBindServiceAnnotation = "horizon.blackducksoftware.com/net-bind-service"
+
+	seccompPodAnnotation     = "seccomp.security.alpha.kubernetes.io/pod"
+	appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+	runtimeDefaultProfile    = "runtime/default"
+	writableVolumePrefix     = "writable-"
+)
+
+// Harden rewrites the pods of a component to a restricted security profile:
+// containers drop all capabilities, can't be privileged or escalate their
+// privileges, can't run as root and have a read-only root filesystem, with
+// empty dirs mounted at the paths listed in the WritablePathsAnnotation.  Only
+// the containers listed in the NetBindServiceAnnotation get NET_BIND_SERVICE.  The
+// pods get the runtime default seccomp and AppArmor profiles, and don't mount
+// the token of their service account unless keepToken is true or the pod sets
+// it explicitly.  Components that don't create pods are left unchanged.
+//
+// A component with a HardeningExemptionAnnotation isn't changed, and its
+// justification is returned.  An error is returned if the justification is empty
+func Harden(c api.DeployableComponentInterface, keepToken bool) (string, error) {
+	spec := components.GetPodSpec(c)
+	template := podMeta(c)
+	if spec == nil || template == nil {
+		return "", nil
+	}
+	accessor, err := meta.Accessor(c)
+	if err != nil {
+		return "", err
+	}
+	annotations := accessor.GetAnnotations()
+	if justification, ok := annotations[HardeningExemptionAnnotation]; ok {
+		if len(strings.TrimSpace(justification)) == 0 {
+			return "", fmt.Errorf("the %s annotation requires a justification", HardeningExemptionAnnotation)
+		}
+		return justification, nil
+	}
+
+	paths := []string{}
+	for _, p := range strings.Split(annotations[WritablePathsAnnotation], ",") {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			if !path.IsAbs(p) {
+				return "", fmt.Errorf("writable path %s must be absolute", p)
+			}
+			paths = append(paths, path.Clean(p))
+		}
+	}
+
+	netBind := map[string]bool{}
+	for _, name := range strings.Split(annotations[NetBindServiceAnnotation], ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			if !hasContainer(spec, name) {
+				return "", fmt.Errorf("container %s of the %s annotation not found", name, NetBindServiceAnnotation)
+			}
+			netBind[name] = true
+		}
+	}
+
+	yes, no := true, false
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &v1.PodSecurityContext{}
+	}
+	spec.SecurityContext.RunAsNonRoot = &yes
+	if spec.AutomountServiceAccountToken == nil && !keepToken {
+		spec.AutomountServiceAccountToken = &no
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	if _, ok := template.Annotations[seccompPodAnnotation]; !ok {
+		template.Annotations[seccompPodAnnotation] = runtimeDefaultProfile
+	}
+
+	containers := []*v1.Container{}
+	for i := range spec.InitContainers {
+		containers = append(containers, &spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		containers = append(containers, &spec.Containers[i])
+	}
+	for _, c := range containers {
+		container := &components.Container{Container: c}
+		container.AddDeleteCapabilities([]string{"ALL"})
+		c.SecurityContext.Capabilities.Add = nil
+		if netBind[c.Name] {
+			c.SecurityContext.Capabilities.Add = []v1.Capability{"NET_BIND_SERVICE"}
+		}
+		c.SecurityContext.Privileged = &no
+		c.SecurityContext.AllowPrivilegeEscalation = &no
+		c.SecurityContext.ReadOnlyRootFilesystem = &yes
+
+		key := appArmorAnnotationPrefix + c.Name
+		if _, ok := template.Annotations[key]; !ok {
+			template.Annotations[key] = runtimeDefaultProfile
+		}
+	}
+
+	// paths already mounted by every container don't need a volume
+	for i, p := range paths {
+		name := fmt.Sprintf("%s%d", writableVolumePrefix, i)
+		for _, c := range containers {
+			if !hasMountPath(c, p) {
+				c.VolumeMounts = append(c.VolumeMounts, v1.VolumeMount{Name: name, MountPath: p})
+				if !hasVolume(spec, name) {
+					spec.Volumes = append(spec.Volumes, v1.Volume{Name: name, VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}})
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// podMeta returns the metadata of the pods created by a component
+func podMeta(c api.DeployableComponentInterface) *metav1.ObjectMeta {
+	switch obj := c.(type) {
+	case *components.Pod:
+		return &obj.ObjectMeta
+	case *components.Deployment:
+		return &obj.Spec.Template.ObjectMeta
+	case *components.StatefulSet:
+		return &obj.Spec.Template.ObjectMeta
+	case *components.DaemonSet:
+		return &obj.Spec.Template.ObjectMeta
+	case *components.Job:
+		return &obj.Spec.Template.ObjectMeta
+	case *components.ReplicationController:
+		if obj.Spec.Template != nil {
+			return &obj.Spec.Template.ObjectMeta
+		}
+	}
+	return nil
+}
+
+func hasContainer(spec *v1.PodSpec, name string) bool {
+	for _, c := range spec.InitContainers {
+		if c.Name == name {
+			return true
+		}
+	}
+	for _, c := range spec.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(spec *v1.PodSpec, name string) bool {
+	for _, v := range spec.Volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMountPath(c *v1.Container, p string) bool {
+	for _, m := range c.VolumeMounts {
+		if path.Clean(m.MountPath) == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/core/v1"
+)
+
+func newHardeningDeployment(t *testing.T) *components.Deployment {
+	yes := true
+	c := newContainer(t, api.ContainerConfig{Name: "web", Image: "shop/web:1.0", MaxCPU: "1", MaxMem: "1Gi", Privileged: &yes})
+	c.AddAddCapabilities([]string{"NET_ADMIN", "NET_BIND_SERVICE"})
+	c.AddVolumeMount(api.VolumeMountConfig{Name: "cache", MountPath: "/var/cache"})
+	pod := components.NewPod(api.PodConfig{Name: "web"})
+	pod.AddContainer(c)
+	cache, _ := components.NewEmptyDirVolume(api.EmptyDirVolumeConfig{VolumeName: "cache"})
+	pod.AddVolume(cache)
+	d := components.NewDeployment(api.DeploymentConfig{Name: "web", Namespace: "shop"})
+	d.AddPod(pod)
+	d.AddAnnotations(map[string]string{WritablePathsAnnotation: "/tmp, /var/cache/"})
+	return d
+}
+
+func TestHarden(t *testing.T) {
+	d := newHardeningDeployment(t)
+	for i := 0; i < 2; i++ {
+		if justification, err := Harden(d, false); err != nil || len(justification) > 0 {
+			t.Fatalf("unexpected result %q, %v", justification, err)
+		}
+	}
+
+	spec := d.Spec.Template.Spec
+	sc := spec.Containers[0].SecurityContext
+	if *sc.Privileged || *sc.AllowPrivilegeEscalation || !*sc.ReadOnlyRootFilesystem {
+		t.Errorf("unexpected security context %+v", sc)
+	}
+	if !reflect.DeepEqual(sc.Capabilities.Drop, []v1.Capability{"ALL"}) || len(sc.Capabilities.Add) > 0 {
+		t.Errorf("unexpected capabilities %+v", sc.Capabilities)
+	}
+	if !*spec.SecurityContext.RunAsNonRoot || *spec.AutomountServiceAccountToken {
+		t.Errorf("unexpected pod security settings %+v, %v", spec.SecurityContext, *spec.AutomountServiceAccountToken)
+	}
+	if len(spec.Volumes) != 2 || spec.Volumes[1].Name != "writable-0" || spec.Volumes[1].EmptyDir == nil {
+		t.Errorf("unexpected volumes %+v", spec.Volumes)
+	}
+	mounts := spec.Containers[0].VolumeMounts
+	if len(mounts) != 2 || mounts[1].Name != "writable-0" || mounts[1].MountPath != "/tmp" {
+		t.Errorf("unexpected mounts %+v", mounts)
+	}
+	annotations := d.Spec.Template.Annotations
+	if annotations["seccomp.security.alpha.kubernetes.io/pod"] != "runtime/default" || annotations["container.apparmor.security.beta.kubernetes.io/web"] != "runtime/default" {
+		t.Errorf("unexpected annotations %v", annotations)
+	}
+
+	if findings := findingStrings(NewDefaultPolicy().Check(d)); len(findings) > 0 {
+		t.Errorf("expected no findings, got:\n%s", strings.Join(findings, "\n"))
+	}
+}
+
+func TestHardenNetBindService(t *testing.T) {
+	d := newHardeningDeployment(t)
+	d.AddAnnotations(map[string]string{NetBindServiceAnnotation: "web"})
+	if _, err := Harden(d, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	capabilities := d.Spec.Template.Spec.Containers[0].SecurityContext.Capabilities
+	if !reflect.DeepEqual(capabilities.Drop, []v1.Capability{"ALL"}) || !reflect.DeepEqual(capabilities.Add, []v1.Capability{"NET_BIND_SERVICE"}) {
+		t.Errorf("unexpected capabilities %+v", capabilities)
+	}
+
+	expected := []string{"web: spec.template.spec.containers[0].securityContext.capabilities.add[0]: capability NET_BIND_SERVICE is added (added-capabilities, medium)"}
+	actual := findingStrings(NewDefaultPolicy().Check(d))
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected findings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	d = newHardeningDeployment(t)
+	d.AddAnnotations(map[string]string{NetBindServiceAnnotation: "web, proxy"})
+	if _, err := Harden(d, false); err == nil || !strings.Contains(err.Error(), "container proxy") {
+		t.Errorf("expected an error for an unknown container, got %v", err)
+	}
+	if !*d.Spec.Template.Spec.Containers[0].SecurityContext.Privileged {
+		t.Errorf("expected the deployment to be unchanged after the error")
+	}
+}
+
+func TestHardenKeepToken(t *testing.T) {
+	d := newHardeningDeployment(t)
+	if _, err := Harden(d, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Spec.Template.Spec.AutomountServiceAccountToken != nil {
+		t.Errorf("expected the token to be mounted")
+	}
+}
+
+func TestHardenExemption(t *testing.T) {
+	d := newHardeningDeployment(t)
+	d.AddAnnotations(map[string]string{HardeningExemptionAnnotation: "needs to manage the network of the node"})
+	justification, err := Harden(d, false)
+	if err != nil || justification != "needs to manage the network of the node" {
+		t.Errorf("unexpected result %q, %v", justification, err)
+	}
+	if !*d.Spec.Template.Spec.Containers[0].SecurityContext.Privileged {
+		t.Errorf("expected the exempt deployment to be unchanged")
+	}
+
+	d.AddAnnotations(map[string]string{HardeningExemptionAnnotation: " "})
+	if _, err := Harden(d, false); err == nil {
+		t.Errorf("expected an error for an exemption without justification")
+	}
+
+	d = newHardeningDeployment(t)
+	d.AddAnnotations(map[string]string{WritablePathsAnnotation: "tmp"})
+	if _, err := Harden(d, false); err == nil {
+		t.Errorf("expected an error for a relative writable path")
+	}
+}
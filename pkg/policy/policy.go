@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package policy checks the pods and containers of Horizon components against
+// security rules.  A Policy is a set of rules, each with an ID and a severity,
+// and checking a component returns a Finding for every violation of a rule.
+//
+// Rules can be suppressed for a component by listing their IDs, separated by
+// commas, in its IgnoreRulesAnnotation:
+//
+//	pod.AddAnnotations(map[string]string{policy.IgnoreRulesAnnotation: "host-path-volume"})
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// IgnoreRulesAnnotation is the annotation listing the IDs of the rules that
+// aren't checked for a component
+const IgnoreRulesAnnotation = "horizon.blackducksoftware.com/ignore-rules"
+
+// Severity defines how much a violation of a rule weakens the security of a
+// component
+type Severity int
+
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+)
+
+var severityNames = []string{"low", "medium", "high"}
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	if s < 1 || int(s) > len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s-1]
+}
+
+// Violation defines a field violating a rule
+type Violation struct {
+	Field   *field.Path
+	Message string
+}
+
+// Rule defines a check of the pods and containers of the components
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+
+	// CheckPod returns the violations of the rule by a pod, if set
+	CheckPod func(spec *v1.PodSpec, path *field.Path) []Violation
+	// CheckContainer returns the violations of the rule by a container, if set.
+	// The pod is nil for containers that aren't part of a component
+	CheckContainer func(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation
+}
+
+// Finding defines a violation of a rule by a component
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Name     string
+	Field    string
+	Message  string
+}
+
+func (f *Finding) Error() string {
+	return fmt.Sprintf("%s: %s: %s (%s, %s)", f.Name, f.Field, f.Message, f.RuleID, f.Severity)
+}
+
+// Policy defines the rules the components are checked against
+type Policy struct {
+	rules []*Rule
+}
+
+// NewPolicy creates a Policy object with the given rules
+func NewPolicy(rules ...*Rule) *Policy {
+	return &Policy{rules: rules}
+}
+
+// NewDefaultPolicy creates a Policy object with the rules of this package
+func NewDefaultPolicy() *Policy {
+	return NewPolicy(DefaultRules()...)
+}
+
+// AddRule will add a rule to the policy, replacing the rule with the same ID
+func (p *Policy) AddRule(rule *Rule) {
+	for i, r := range p.rules {
+		if r.ID == rule.ID {
+			p.rules[i] = rule
+			return
+		}
+	}
+	p.rules = append(p.rules, rule)
+}
+
+// RemoveRule will remove the rule with the given ID from the policy
+func (p *Policy) RemoveRule(id string) {
+	for i, r := range p.rules {
+		if r.ID == id {
+			p.rules = append(p.rules[:i], p.rules[i+1:]...)
+			return
+		}
+	}
+}
+
+// Rules returns the rules of the policy
+func (p *Policy) Rules() []*Rule {
+	return p.rules
+}
+
+// Check returns the violations of the rules of the policy by a component.  Pods,
+// containers and the pod templates of controllers are checked, other components
+// have no findings
+func (p *Policy) Check(obj interface{}) []Finding {
+	name := ""
+	ignored := map[string]bool{}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		name = accessor.GetName()
+		for _, id := range strings.Split(accessor.GetAnnotations()[IgnoreRulesAnnotation], ",") {
+			ignored[strings.TrimSpace(id)] = true
+		}
+	}
+
+	var pod *v1.PodSpec
+	var containers []*v1.Container
+	var paths []*field.Path
+	var path *field.Path
+	switch c := obj.(type) {
+	case *components.Container:
+		name = c.Name
+		containers = []*v1.Container{c.Container}
+		paths = []*field.Path{nil}
+	case *components.Pod:
+		pod = &c.Spec
+		path = field.NewPath("spec")
+	case api.DeployableComponentInterface:
+		pod = components.GetPodSpec(c)
+		path = field.NewPath("spec", "template", "spec")
+	}
+	if pod != nil {
+		for i := range pod.InitContainers {
+			containers = append(containers, &pod.InitContainers[i])
+			paths = append(paths, path.Child("initContainers").Index(i))
+		}
+		for i := range pod.Containers {
+			containers = append(containers, &pod.Containers[i])
+			paths = append(paths, path.Child("containers").Index(i))
+		}
+	}
+
+	findings := []Finding{}
+	for _, rule := range p.rules {
+		if ignored[rule.ID] {
+			continue
+		}
+		violations := []Violation{}
+		if rule.CheckPod != nil && pod != nil {
+			violations = append(violations, rule.CheckPod(pod, path)...)
+		}
+		if rule.CheckContainer != nil {
+			for i, c := range containers {
+				violations = append(violations, rule.CheckContainer(c, pod, paths[i])...)
+			}
+		}
+		for _, v := range violations {
+			findings = append(findings, Finding{RuleID: rule.ID, Severity: rule.Severity, Name: name, Field: v.Field.String(), Message: v.Message})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func findingStrings(findings []Finding) []string {
+	s := []string{}
+	for _, f := range findings {
+		s = append(s, f.Error())
+	}
+	return s
+}
+
+func newContainer(t *testing.T, config api.ContainerConfig) *components.Container {
+	c, err := components.NewContainer(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestDefaultRules(t *testing.T) {
+	yes, no := true, false
+	root, user := int64(0), int64(1000)
+	hardened := api.ContainerConfig{
+		Name:                     "web",
+		Image:                    "shop/web:1.2.3",
+		MaxCPU:                   "500m",
+		MaxMem:                   "256Mi",
+		AllowPrivilegeEscalation: &no,
+		ReadOnlyFS:               &yes,
+		UID:                      &user,
+	}
+
+	var cases = []struct {
+		description string
+		container   func(c *api.ContainerConfig)
+		expected    []string
+	}{
+		{
+			description: "hardened",
+			container:   func(c *api.ContainerConfig) {},
+		},
+		{
+			description: "privileged",
+			container:   func(c *api.ContainerConfig) { c.Privileged = &yes },
+			expected:    []string{"web: securityContext.privileged: container is privileged (privileged, high)"},
+		},
+		{
+			description: "privilege escalation unset",
+			container:   func(c *api.ContainerConfig) { c.AllowPrivilegeEscalation = nil },
+			expected:    []string{"web: securityContext.allowPrivilegeEscalation: privilege escalation isn't disabled (privilege-escalation, medium)"},
+		},
+		{
+			description: "privilege escalation allowed",
+			container:   func(c *api.ContainerConfig) { c.AllowPrivilegeEscalation = &yes },
+			expected:    []string{"web: securityContext.allowPrivilegeEscalation: privilege escalation is allowed (privilege-escalation, medium)"},
+		},
+		{
+			description: "writable root filesystem",
+			container:   func(c *api.ContainerConfig) { c.ReadOnlyFS = nil },
+			expected:    []string{"web: securityContext.readOnlyRootFilesystem: root filesystem is writable (read-only-root-filesystem, low)"},
+		},
+		{
+			description: "root",
+			container:   func(c *api.ContainerConfig) { c.UID = &root },
+			expected:    []string{"web: securityContext.runAsUser: container runs as root (run-as-root, high)"},
+		},
+		{
+			description: "no limits",
+			container:   func(c *api.ContainerConfig) { c.MaxCPU, c.MaxMem = "", "" },
+			expected: []string{
+				"web: resources.limits: no cpu limit (resource-limits, low)",
+				"web: resources.limits: no memory limit (resource-limits, low)",
+			},
+		},
+		{
+			description: "latest",
+			container:   func(c *api.ContainerConfig) { c.Image = "shop/web:latest" },
+			expected:    []string{"web: image: image shop/web:latest uses the latest tag (image-tag, medium)"},
+		},
+		{
+			description: "untagged with registry port",
+			container:   func(c *api.ContainerConfig) { c.Image = "registry:5000/shop/web" },
+			expected:    []string{"web: image: image registry:5000/shop/web has no tag (image-tag, medium)"},
+		},
+		{
+			description: "digest",
+			container:   func(c *api.ContainerConfig) { c.Image = "shop/web@sha256:abcd" },
+		},
+	}
+
+	p := NewDefaultPolicy()
+	for _, tc := range cases {
+		config := hardened
+		tc.container(&config)
+		actual := findingStrings(p.Check(newContainer(t, config)))
+		if strings.Join(actual, "\n") != strings.Join(tc.expected, "\n") {
+			t.Errorf("%s: expected findings:\n%s\ngot:\n%s", tc.description, strings.Join(tc.expected, "\n"), strings.Join(actual, "\n"))
+		}
+	}
+}
+
+func TestCheckPodTemplate(t *testing.T) {
+	no := false
+	root := int64(0)
+	c := newContainer(t, api.ContainerConfig{Name: "agent", Image: "shop/agent:1.0", MaxCPU: "1", MaxMem: "1Gi", AllowPrivilegeEscalation: &no})
+	c.AddAddCapabilities([]string{"NET_ADMIN"})
+	pod := components.NewPod(api.PodConfig{Name: "agent", RunAsUser: &root})
+	pod.AddContainer(c)
+	pod.AddVolume(components.NewHostPathVolume(api.HostPathVolumeConfig{VolumeName: "logs", Path: "/var/log"}))
+	pod.AddHostMode(api.HostModeNet)
+	pod.AddHostMode(api.HostModePID)
+	ds := components.NewDaemonSet(api.DaemonSetConfig{Name: "agent"})
+	ds.AddPod(pod)
+
+	expected := []string{
+		"agent: spec.template.spec.containers[0].securityContext.readOnlyRootFilesystem: root filesystem is writable (read-only-root-filesystem, low)",
+		"agent: spec.template.spec.securityContext.runAsUser: pod runs as root (run-as-root, high)",
+		"agent: spec.template.spec.containers[0].securityContext.capabilities.add[0]: capability NET_ADMIN is added (added-capabilities, medium)",
+		"agent: spec.template.spec.volumes[0].hostPath: volume logs mounts /var/log of the node (host-path-volume, high)",
+		"agent: spec.template.spec.hostNetwork: pod uses the network of the node (host-namespaces, high)",
+		"agent: spec.template.spec.hostPID: pod uses the PID namespace of the node (host-namespaces, high)",
+	}
+	actual := findingStrings(NewDefaultPolicy().Check(ds))
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected findings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	ds.AddAnnotations(map[string]string{IgnoreRulesAnnotation: "read-only-root-filesystem, host-namespaces,run-as-root"})
+	actual = findingStrings(NewDefaultPolicy().Check(ds))
+	if strings.Join(actual, "\n") != strings.Join(expected[2:4], "\n") {
+		t.Errorf("expected findings:\n%s\ngot:\n%s", strings.Join(expected[2:4], "\n"), strings.Join(actual, "\n"))
+	}
+
+	if findings := NewDefaultPolicy().Check(components.NewConfigMap(api.ConfigMapConfig{Name: "settings"})); len(findings) != 0 {
+		t.Errorf("expected no findings for a config map, got %v", findings)
+	}
+}
+
+func TestCustomRule(t *testing.T) {
+	p := NewPolicy()
+	p.AddRule(&Rule{
+		ID:       "registry",
+		Severity: SeverityHigh,
+		CheckContainer: func(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+			if !strings.HasPrefix(c.Image, "registry.example.com/") {
+				return []Violation{{Field: path.Child("image"), Message: "untrusted registry"}}
+			}
+			return nil
+		},
+	})
+
+	expected := []string{"web: image: untrusted registry (registry, high)"}
+	actual := findingStrings(p.Check(newContainer(t, api.ContainerConfig{Name: "web", Image: "docker.io/web:1"})))
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected findings %v, got %v", expected, actual)
+	}
+
+	p.RemoveRule("registry")
+	if len(p.Rules()) != 0 {
+		t.Errorf("expected no rules, got %d", len(p.Rules()))
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultRules returns the rules of this package
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{
+			ID:             "privileged",
+			Severity:       SeverityHigh,
+			Description:    "Containers must not run in privileged mode",
+			CheckContainer: checkPrivileged,
+		},
+		{
+			ID:             "privilege-escalation",
+			Severity:       SeverityMedium,
+			Description:    "Containers must not allow privilege escalation",
+			CheckContainer: checkPrivilegeEscalation,
+		},
+		{
+			ID:             "read-only-root-filesystem",
+			Severity:       SeverityLow,
+			Description:    "Containers should have a read-only root filesystem",
+			CheckContainer: checkReadOnlyRootFilesystem,
+		},
+		{
+			ID:             "run-as-root",
+			Severity:       SeverityHigh,
+			Description:    "Pods and containers must not run as the root user",
+			CheckPod:       checkPodRunAsRoot,
+			CheckContainer: checkContainerRunAsRoot,
+		},
+		{
+			ID:             "added-capabilities",
+			Severity:       SeverityMedium,
+			Description:    "Containers should not add Linux capabilities",
+			CheckContainer: checkAddedCapabilities,
+		},
+		{
+			ID:          "host-path-volume",
+			Severity:    SeverityHigh,
+			Description: "Pods must not mount directories of the node",
+			CheckPod:    checkHostPathVolumes,
+		},
+		{
+			ID:          "host-namespaces",
+			Severity:    SeverityHigh,
+			Description: "Pods must not share the network, PID or IPC namespace of the node",
+			CheckPod:    checkHostNamespaces,
+		},
+		{
+			ID:             "resource-limits",
+			Severity:       SeverityLow,
+			Description:    "Containers should have CPU and memory limits",
+			CheckContainer: checkResourceLimits,
+		},
+		{
+			ID:             "image-tag",
+			Severity:       SeverityMedium,
+			Description:    "Container images should be pinned to a tag other than latest or to a digest",
+			CheckContainer: checkImageTag,
+		},
+	}
+}
+
+func checkPrivileged(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+	if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+		return []Violation{{Field: path.Child("securityContext", "privileged"), Message: "container is privileged"}}
+	}
+	return nil
+}
+
+func checkPrivilegeEscalation(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+	path = path.Child("securityContext", "allowPrivilegeEscalation")
+	if c.SecurityContext == nil || c.SecurityContext.AllowPrivilegeEscalation == nil {
+		return []Violation{{Field: path, Message: "privilege escalation isn't disabled"}}
+	}
+	if *c.SecurityContext.AllowPrivilegeEscalation {
+		return []Violation{{Field: path, Message: "privilege escalation is allowed"}}
+	}
+	return nil
+}
+
+func checkReadOnlyRootFilesystem(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+	if c.SecurityContext == nil || c.SecurityContext.ReadOnlyRootFilesystem == nil || !*c.SecurityContext.ReadOnlyRootFilesystem {
+		return []Violation{{Field: path.Child("securityContext", "readOnlyRootFilesystem"), Message: "root filesystem is writable"}}
+	}
+	return nil
+}
+
+func checkPodRunAsRoot(spec *v1.PodSpec, path *field.Path) []Violation {
+	if spec.SecurityContext != nil && spec.SecurityContext.RunAsUser != nil && *spec.SecurityContext.RunAsUser == 0 {
+		return []Violation{{Field: path.Child("securityContext", "runAsUser"), Message: "pod runs as root"}}
+	}
+	return nil
+}
+
+func checkContainerRunAsRoot(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+	if c.SecurityContext != nil && c.SecurityContext.RunAsUser != nil && *c.SecurityContext.RunAsUser == 0 {
+		return []Violation{{Field: path.Child("securityContext", "runAsUser"), Message: "container runs as root"}}
+	}
+	return nil
+}
+
+func checkAddedCapabilities(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+	if c.SecurityContext == nil || c.SecurityContext.Capabilities == nil {
+		return nil
+	}
+	violations := []Violation{}
+	for i, capability := range c.SecurityContext.Capabilities.Add {
+		violations = append(violations, Violation{Field: path.Child("securityContext", "capabilities", "add").Index(i), Message: fmt.Sprintf("capability %s is added", capability)})
+	}
+	return violations
+}
+
+func checkHostPathVolumes(spec *v1.PodSpec, path *field.Path) []Violation {
+	violations := []Violation{}
+	for i, v := range spec.Volumes {
+		if v.HostPath != nil {
+			violations = append(violations, Violation{Field: path.Child("volumes").Index(i).Child("hostPath"), Message: fmt.Sprintf("volume %s mounts %s of the node", v.Name, v.HostPath.Path)})
+		}
+	}
+	return violations
+}
+
+func checkHostNamespaces(spec *v1.PodSpec, path *field.Path) []Violation {
+	violations := []Violation{}
+	if spec.HostNetwork {
+		violations = append(violations, Violation{Field: path.Child("hostNetwork"), Message: "pod uses the network of the node"})
+	}
+	if spec.HostPID {
+		violations = append(violations, Violation{Field: path.Child("hostPID"), Message: "pod uses the PID namespace of the node"})
+	}
+	if spec.HostIPC {
+		violations = append(violations, Violation{Field: path.Child("hostIPC"), Message: "pod uses the IPC namespace of the node"})
+	}
+	return violations
+}
+
+func checkResourceLimits(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+	violations := []Violation{}
+	for _, resource := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if _, ok := c.Resources.Limits[resource]; !ok {
+			violations = append(violations, Violation{Field: path.Child("resources", "limits"), Message: fmt.Sprintf("no %s limit", resource)})
+		}
+	}
+	return violations
+}
+
+func checkImageTag(c *v1.Container, pod *v1.PodSpec, path *field.Path) []Violation {
+	if strings.Contains(c.Image, "@") {
+		return nil
+	}
+	// the tag follows the last colon, unless it is the port of the registry
+	tag := ""
+	if i := strings.LastIndex(c.Image, ":"); i > strings.LastIndex(c.Image, "/") {
+		tag = c.Image[i+1:]
+	}
+	switch tag {
+	case "":
+		return []Violation{{Field: path.Child("image"), Message: fmt.Sprintf("image %s has no tag", c.Image)}}
+	case "latest":
+		return []Violation{{Field: path.Child("image"), Message: fmt.Sprintf("image %s uses the latest tag", c.Image)}}
+	}
+	return nil
+}
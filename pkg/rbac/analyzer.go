@@ -0,0 +1,298 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package rbac computes the permissions granted to service accounts by the
+// roles, cluster roles and bindings of Horizon components.  An Analyzer
+// answers whether a service account can perform a request, which allows tests
+// to assert that an application has the least privileges it needs:
+//
+//	a := rbac.NewAnalyzer(role, binding)
+//	if a.Can("shop", "web", rbac.Request{Verb: "list", Resource: "secrets", Namespace: "shop"}) {
+//		t.Errorf("web can list secrets")
+//	}
+//
+// Only the roles given to the analyzer grant permissions, so bindings to roles
+// provided by the cluster, such as the view cluster role, grant nothing.
+package rbac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blackducksoftware/horizon/pkg/components"
+
+	"k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	serviceAccountKind        = "ServiceAccount"
+	serviceAccountsGroup      = "system:serviceaccounts"
+	serviceAccountUserPrefix  = "system:serviceaccount:"
+	serviceAccountGroupPrefix = "system:serviceaccounts:"
+)
+
+// Request defines an action of a service account.  Resource can name a
+// subresource, like pods/exec.  An empty Namespace requests the resources of
+// all namespaces, or a resource that isn't namespaced
+type Request struct {
+	Verb           string
+	APIGroup       string
+	Resource       string
+	Name           string
+	Namespace      string
+	NonResourceURL string
+}
+
+// Permission defines a rule granted to a service account by a binding
+type Permission struct {
+	// Namespace is the namespace the rule applies to, or empty if it
+	// applies to all namespaces
+	Namespace string
+	Rule      v1.PolicyRule
+	Role      string
+	Binding   string
+}
+
+type binding struct {
+	name      string
+	namespace string
+	subjects  []v1.Subject
+	rules     []v1.PolicyRule
+	role      string
+}
+
+// Analyzer defines the permissions granted by a set of roles and bindings
+type Analyzer struct {
+	bindings []*binding
+	accounts []string
+}
+
+// NewAnalyzer creates an Analyzer object for the ClusterRole, Role,
+// ClusterRoleBinding and RoleBinding components.  Other components are
+// ignored.  The rules of the cluster roles with aggregation rules include the
+// rules of the cluster roles they select
+func NewAnalyzer(objs ...interface{}) *Analyzer {
+	clusterRoles := map[string]*v1.ClusterRole{}
+	roles := map[string]*v1.Role{}
+	clusterRoleBindings := []*v1.ClusterRoleBinding{}
+	roleBindings := []*v1.RoleBinding{}
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *components.ClusterRole:
+			clusterRoles[o.Name] = o.ClusterRole
+		case *components.Role:
+			roles[o.Namespace+"/"+o.Name] = o.Role
+		case *components.ClusterRoleBinding:
+			clusterRoleBindings = append(clusterRoleBindings, o.ClusterRoleBinding)
+		case *components.RoleBinding:
+			roleBindings = append(roleBindings, o.RoleBinding)
+		}
+	}
+
+	a := &Analyzer{}
+	resolve := func(namespace string, ref v1.RoleRef) ([]v1.PolicyRule, string) {
+		if ref.Kind == "Role" {
+			if r, ok := roles[namespace+"/"+ref.Name]; ok {
+				return r.Rules, fmt.Sprintf("Role %s/%s", namespace, ref.Name)
+			}
+			return nil, fmt.Sprintf("Role %s/%s", namespace, ref.Name)
+		}
+		return aggregatedRules(clusterRoles, ref.Name, map[string]bool{}), "ClusterRole " + ref.Name
+	}
+	for _, b := range clusterRoleBindings {
+		rules, role := resolve("", b.RoleRef)
+		a.bindings = append(a.bindings, &binding{name: "ClusterRoleBinding " + b.Name, subjects: b.Subjects, rules: rules, role: role})
+	}
+	for _, b := range roleBindings {
+		rules, role := resolve(b.Namespace, b.RoleRef)
+		a.bindings = append(a.bindings, &binding{name: fmt.Sprintf("RoleBinding %s/%s", b.Namespace, b.Name), namespace: b.Namespace, subjects: b.Subjects, rules: rules, role: role})
+	}
+
+	accounts := map[string]bool{}
+	for _, b := range a.bindings {
+		for _, s := range b.subjects {
+			switch {
+			case s.Kind == serviceAccountKind:
+				accounts[subjectNamespace(s, b.namespace)+"/"+s.Name] = true
+			case s.Kind == v1.UserKind && strings.HasPrefix(s.Name, serviceAccountUserPrefix):
+				if parts := strings.SplitN(strings.TrimPrefix(s.Name, serviceAccountUserPrefix), ":", 2); len(parts) == 2 {
+					accounts[parts[0]+"/"+parts[1]] = true
+				}
+			}
+		}
+	}
+	for account := range accounts {
+		a.accounts = append(a.accounts, account)
+	}
+	sort.Strings(a.accounts)
+	return a
+}
+
+// aggregatedRules returns the rules of a cluster role, including the rules of
+// the cluster roles selected by its aggregation rule
+func aggregatedRules(clusterRoles map[string]*v1.ClusterRole, name string, visited map[string]bool) []v1.PolicyRule {
+	cr, ok := clusterRoles[name]
+	if !ok || visited[name] {
+		return nil
+	}
+	visited[name] = true
+	rules := append([]v1.PolicyRule{}, cr.Rules...)
+	if cr.AggregationRule == nil {
+		return rules
+	}
+
+	names := []string{}
+	for n := range clusterRoles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		for _, s := range cr.AggregationRule.ClusterRoleSelectors {
+			selector, err := metav1.LabelSelectorAsSelector(&s)
+			if err != nil || selector.Empty() || !selector.Matches(labels.Set(clusterRoles[n].Labels)) {
+				continue
+			}
+			rules = append(rules, aggregatedRules(clusterRoles, n, visited)...)
+			break
+		}
+	}
+	return rules
+}
+
+// subjectNamespace returns the namespace of a service account subject, which
+// defaults to the namespace of its role binding
+func subjectNamespace(s v1.Subject, bindingNamespace string) string {
+	if len(s.Namespace) > 0 {
+		return s.Namespace
+	}
+	return bindingNamespace
+}
+
+// binds returns true if the binding applies to the service account
+func (b *binding) binds(namespace string, name string) bool {
+	for _, s := range b.subjects {
+		switch s.Kind {
+		case serviceAccountKind:
+			if s.Name == name && subjectNamespace(s, b.namespace) == namespace {
+				return true
+			}
+		case v1.UserKind:
+			if s.Name == serviceAccountUserPrefix+namespace+":"+name {
+				return true
+			}
+		case v1.GroupKind:
+			if s.Name == serviceAccountsGroup || s.Name == serviceAccountGroupPrefix+namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ServiceAccounts returns the service accounts that are subjects of the
+// bindings, directly or by their user name, as namespace/name
+func (a *Analyzer) ServiceAccounts() []string {
+	return a.accounts
+}
+
+// Permissions returns the rules granted to a service account
+func (a *Analyzer) Permissions(namespace string, name string) []Permission {
+	permissions := []Permission{}
+	for _, b := range a.bindings {
+		if !b.binds(namespace, name) {
+			continue
+		}
+		for _, rule := range b.rules {
+			permissions = append(permissions, Permission{Namespace: b.namespace, Rule: rule, Role: b.role, Binding: b.name})
+		}
+	}
+	return permissions
+}
+
+// Can returns true if the service account is allowed to perform the request
+func (a *Analyzer) Can(namespace string, name string, request Request) bool {
+	for _, p := range a.Permissions(namespace, name) {
+		if p.allows(request) {
+			return true
+		}
+	}
+	return false
+}
+
+// allows returns true if the permission allows the request.  Rules granted in
+// a namespace only allow requests in that namespace, and never allow
+// non-resource requests
+func (p *Permission) allows(r Request) bool {
+	if !matches(p.Rule.Verbs, r.Verb) {
+		return false
+	}
+	if len(r.NonResourceURL) > 0 {
+		return len(p.Namespace) == 0 && matchesURL(p.Rule.NonResourceURLs, r.NonResourceURL)
+	}
+	if len(p.Namespace) > 0 && p.Namespace != r.Namespace {
+		return false
+	}
+	return matches(p.Rule.APIGroups, r.APIGroup) && matchesResource(p.Rule.Resources, r.Resource) &&
+		(len(p.Rule.ResourceNames) == 0 || contains(p.Rule.ResourceNames, r.Name))
+}
+
+// matches returns true if the values include the value or the * wildcard
+func matches(values []string, value string) bool {
+	return contains(values, "*") || contains(values, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesResource returns true if the resources include the resource.  A
+// resource of */subresource matches the subresource of every resource
+func matchesResource(resources []string, resource string) bool {
+	subresource := ""
+	if i := strings.Index(resource, "/"); i >= 0 {
+		subresource = resource[i+1:]
+	}
+	for _, r := range resources {
+		if r == v1.ResourceAll || r == resource || (len(subresource) > 0 && r == "*/"+subresource) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesURL returns true if the URLs include the URL.  URLs ending with *
+// match the URLs they prefix
+func matchesURL(urls []string, url string) bool {
+	for _, u := range urls {
+		if u == v1.NonResourceAll || u == url || (strings.HasSuffix(u, "*") && strings.HasPrefix(url, strings.TrimSuffix(u, "*"))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package rbac
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blackducksoftware/horizon/pkg/api"
+	"github.com/blackducksoftware/horizon/pkg/components"
+)
+
+func newClusterRole(name string, labels map[string]string, rules ...api.PolicyRuleConfig) *components.ClusterRole {
+	cr := components.NewClusterRole(api.ClusterRoleConfig{Name: name})
+	cr.AddLabels(labels)
+	for _, r := range rules {
+		cr.AddPolicyRule(r)
+	}
+	return cr
+}
+
+func newRoleBinding(namespace string, name string, kind string, role string, subjects ...api.SubjectConfig) *components.RoleBinding {
+	rb := components.NewRoleBinding(api.RoleBindingConfig{Name: name, Namespace: namespace})
+	rb.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: kind, Name: role})
+	for _, s := range subjects {
+		rb.AddSubject(s)
+	}
+	return rb
+}
+
+func newClusterRoleBinding(name string, role string, subjects ...api.SubjectConfig) *components.ClusterRoleBinding {
+	crb := components.NewClusterRoleBinding(api.ClusterRoleBindingConfig{Name: name})
+	crb.AddRoleRef(api.RoleRefConfig{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: role})
+	for _, s := range subjects {
+		crb.AddSubject(s)
+	}
+	return crb
+}
+
+func TestCan(t *testing.T) {
+	role := components.NewRole(api.RoleConfig{Name: "web", Namespace: "shop"})
+	role.AddPolicyRule(api.PolicyRuleConfig{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"settings"}})
+	role.AddPolicyRule(api.PolicyRuleConfig{Verbs: []string{"get", "list"}, APIGroups: []string{"apps"}, Resources: []string{"*"}})
+
+	aggregate := components.NewClusterRole(api.ClusterRoleConfig{Name: "monitoring"})
+	aggregate.AddAggregationRule(api.SelectorConfig{Labels: map[string]string{"monitoring": "true"}})
+	pods := newClusterRole("pods-reader", map[string]string{"monitoring": "true"}, api.PolicyRuleConfig{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"pods", "*/log"}})
+	metrics := newClusterRole("metrics", map[string]string{"monitoring": "true"}, api.PolicyRuleConfig{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics*"}})
+	other := newClusterRole("other", map[string]string{"monitoring": "false"}, api.PolicyRuleConfig{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}})
+
+	a := NewAnalyzer(
+		role, aggregate, pods, metrics, other,
+		newRoleBinding("shop", "web", "Role", "web", api.SubjectConfig{Kind: "ServiceAccount", Name: "web"}),
+		newClusterRoleBinding("monitoring", "monitoring", api.SubjectConfig{Kind: "ServiceAccount", Name: "prometheus", Namespace: "monitoring"}),
+		newRoleBinding("shop", "all-accounts", "ClusterRole", "pods-reader", api.SubjectConfig{Kind: "Group", Name: "system:serviceaccounts:shop"}),
+		components.NewConfigMap(api.ConfigMapConfig{Name: "ignored"}),
+	)
+
+	var cases = []struct {
+		namespace string
+		name      string
+		request   Request
+		expected  bool
+	}{
+		{"shop", "web", Request{Verb: "get", Resource: "configmaps", Name: "settings", Namespace: "shop"}, true},
+		{"shop", "web", Request{Verb: "get", Resource: "configmaps", Name: "other", Namespace: "shop"}, false},
+		{"shop", "web", Request{Verb: "list", Resource: "configmaps", Namespace: "shop"}, false},
+		{"shop", "web", Request{Verb: "list", APIGroup: "apps", Resource: "deployments", Namespace: "shop"}, true},
+		{"shop", "web", Request{Verb: "list", APIGroup: "apps", Resource: "deployments", Namespace: "default"}, false},
+		{"shop", "web", Request{Verb: "list", APIGroup: "apps", Resource: "deployments"}, false},
+		{"shop", "web", Request{Verb: "delete", APIGroup: "apps", Resource: "deployments", Namespace: "shop"}, false},
+		{"shop", "web", Request{Verb: "list", Resource: "pods", Namespace: "shop"}, true},
+		{"shop", "web", Request{Verb: "list", Resource: "pods", Namespace: "default"}, false},
+		{"default", "web", Request{Verb: "list", APIGroup: "apps", Resource: "deployments", Namespace: "shop"}, false},
+		{"monitoring", "prometheus", Request{Verb: "list", Resource: "pods"}, true},
+		{"monitoring", "prometheus", Request{Verb: "get", Resource: "pods/log", Namespace: "shop"}, true},
+		{"monitoring", "prometheus", Request{Verb: "get", Resource: "pods/exec", Namespace: "shop"}, false},
+		{"monitoring", "prometheus", Request{Verb: "get", NonResourceURL: "/metrics/cadvisor"}, true},
+		{"monitoring", "prometheus", Request{Verb: "get", NonResourceURL: "/healthz"}, false},
+		{"monitoring", "prometheus", Request{Verb: "delete", Resource: "pods"}, false},
+	}
+
+	for _, tc := range cases {
+		if actual := a.Can(tc.namespace, tc.name, tc.request); actual != tc.expected {
+			t.Errorf("%s/%s %+v: expected %t, got %t", tc.namespace, tc.name, tc.request, tc.expected, actual)
+		}
+	}
+
+	if accounts := strings.Join(a.ServiceAccounts(), ","); accounts != "monitoring/prometheus,shop/web" {
+		t.Errorf("unexpected service accounts %s", accounts)
+	}
+	if permissions := a.Permissions("monitoring", "prometheus"); len(permissions) != 2 || permissions[0].Role != "ClusterRole monitoring" || permissions[0].Binding != "ClusterRoleBinding monitoring" {
+		t.Errorf("unexpected permissions %+v", permissions)
+	}
+}
+
+func TestDangerousGrants(t *testing.T) {
+	admin := newClusterRole("admin", nil, api.PolicyRuleConfig{Verbs: []string{"*"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}})
+	secrets := newClusterRole("secrets", nil, api.PolicyRuleConfig{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"secrets"}})
+	rbac := newClusterRole("rbac", nil, api.PolicyRuleConfig{Verbs: []string{"bind", "escalate"}, APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterroles"}})
+	debug := newClusterRole("debug", nil,
+		api.PolicyRuleConfig{Verbs: []string{"create"}, APIGroups: []string{""}, Resources: []string{"pods/exec"}},
+		api.PolicyRuleConfig{Verbs: []string{"impersonate"}, APIGroups: []string{""}, Resources: []string{"serviceaccounts"}},
+	)
+	operator := api.SubjectConfig{Kind: "ServiceAccount", Name: "operator", Namespace: "shop"}
+
+	a := NewAnalyzer(admin, secrets, rbac, debug,
+		newClusterRoleBinding("operator-secrets", "secrets", operator),
+		newRoleBinding("shop", "operator-secrets", "ClusterRole", "secrets", operator),
+		newRoleBinding("shop", "operator-admin", "ClusterRole", "admin", operator),
+		newClusterRoleBinding("operator-rbac", "rbac", operator),
+		newRoleBinding("shop", "operator-debug", "ClusterRole", "debug", operator),
+	)
+
+	expected := []string{
+		"shop/operator can read secrets in all namespaces (ClusterRole secrets, ClusterRoleBinding operator-secrets)",
+		"shop/operator can escalate roles in all namespaces (ClusterRole rbac, ClusterRoleBinding operator-rbac)",
+		"shop/operator can bind roles in all namespaces (ClusterRole rbac, ClusterRoleBinding operator-rbac)",
+		"shop/operator has all verbs in namespace shop (ClusterRole admin, RoleBinding shop/operator-admin)",
+		"shop/operator can exec into pods in namespace shop (ClusterRole debug, RoleBinding shop/operator-debug)",
+		"shop/operator can impersonate in namespace shop (ClusterRole debug, RoleBinding shop/operator-debug)",
+	}
+	actual := []string{}
+	for _, g := range a.DangerousGrants() {
+		actual = append(actual, g.String())
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected grants:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestDangerousGrantsToGroupsAndUsers(t *testing.T) {
+	secrets := newClusterRole("secrets", nil, api.PolicyRuleConfig{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"secrets"}})
+	debug := newClusterRole("debug", nil, api.PolicyRuleConfig{Verbs: []string{"create"}, APIGroups: []string{""}, Resources: []string{"pods/exec"}})
+
+	a := NewAnalyzer(secrets, debug,
+		newClusterRoleBinding("all-secrets", "secrets", api.SubjectConfig{Kind: "Group", Name: "system:serviceaccounts"}),
+		newRoleBinding("shop", "shop-debug", "ClusterRole", "debug", api.SubjectConfig{Kind: "Group", Name: "system:serviceaccounts:shop"}),
+		newClusterRoleBinding("web-secrets", "secrets", api.SubjectConfig{Kind: "User", Name: "system:serviceaccount:shop:web"}),
+	)
+
+	expected := []string{
+		"Group system:serviceaccounts can read secrets in all namespaces (ClusterRole secrets, ClusterRoleBinding all-secrets)",
+		"User system:serviceaccount:shop:web can read secrets in all namespaces (ClusterRole secrets, ClusterRoleBinding web-secrets)",
+		"Group system:serviceaccounts:shop can exec into pods in namespace shop (ClusterRole debug, RoleBinding shop/shop-debug)",
+	}
+	actual := []string{}
+	for _, g := range a.DangerousGrants() {
+		actual = append(actual, g.String())
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected grants:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	if accounts := strings.Join(a.ServiceAccounts(), ","); accounts != "shop/web" {
+		t.Errorf("expected the service account bound by its user name, got %s", accounts)
+	}
+}
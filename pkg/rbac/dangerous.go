@@ -0,0 +1,130 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package rbac
+
+import (
+	"fmt"
+
+	"k8s.io/api/rbac/v1"
+)
+
+// DangerousGrant defines a permission allowing the subject of a binding to take
+// over the resources of other applications or of the cluster.  Subject is the
+// namespace/name of a service account, or the kind and name of a user or group
+type DangerousGrant struct {
+	Subject    string
+	Permission Permission
+	Reason     string
+}
+
+func (g *DangerousGrant) String() string {
+	scope := "all namespaces"
+	if len(g.Permission.Namespace) > 0 {
+		scope = "namespace " + g.Permission.Namespace
+	}
+	return fmt.Sprintf("%s %s in %s (%s, %s)", g.Subject, g.Reason, scope, g.Permission.Role, g.Permission.Binding)
+}
+
+// dangerousRequests are the requests no application should need.  They are
+// checked in the namespace the permission applies to, and requests that are
+// only dangerous across all namespaces aren't checked within a namespace
+var dangerousRequests = []struct {
+	reason      string
+	clusterWide bool
+	requests    []Request
+}{
+	{"can read secrets", true, []Request{
+		{Verb: "get", Resource: "secrets"},
+		{Verb: "list", Resource: "secrets"},
+		{Verb: "watch", Resource: "secrets"},
+	}},
+	{"can escalate roles", false, []Request{
+		{Verb: "escalate", APIGroup: "rbac.authorization.k8s.io", Resource: "roles"},
+		{Verb: "escalate", APIGroup: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	}},
+	{"can bind roles", false, []Request{
+		{Verb: "bind", APIGroup: "rbac.authorization.k8s.io", Resource: "roles"},
+		{Verb: "bind", APIGroup: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	}},
+	{"can impersonate", false, []Request{
+		{Verb: "impersonate", Resource: "users"},
+		{Verb: "impersonate", Resource: "groups"},
+		{Verb: "impersonate", Resource: "serviceaccounts"},
+	}},
+	{"can exec into pods", false, []Request{
+		{Verb: "create", Resource: "pods/exec"},
+		{Verb: "get", Resource: "pods/exec"},
+	}},
+}
+
+// DangerousGrants returns the dangerous permissions granted to every subject
+// of the bindings, including the users and groups service accounts belong to:
+// rules granting all verbs or all resources, reading secrets across all
+// namespaces, escalating or binding roles, impersonating and executing
+// commands in pods.  Reading secrets within a namespace is common and isn't
+// reported
+func (a *Analyzer) DangerousGrants() []DangerousGrant {
+	grants := []DangerousGrant{}
+	for _, b := range a.bindings {
+		for _, s := range b.subjects {
+			for _, rule := range b.rules {
+				p := Permission{Namespace: b.namespace, Rule: rule, Role: b.role, Binding: b.name}
+				for _, reason := range dangerousReasons(p) {
+					grants = append(grants, DangerousGrant{Subject: subjectName(s, b.namespace), Permission: p, Reason: reason})
+				}
+			}
+		}
+	}
+	return grants
+}
+
+// subjectName returns the namespace/name of a service account subject, and the
+// kind and name of other subjects
+func subjectName(s v1.Subject, bindingNamespace string) string {
+	if s.Kind == serviceAccountKind {
+		return subjectNamespace(s, bindingNamespace) + "/" + s.Name
+	}
+	return s.Kind + " " + s.Name
+}
+
+func dangerousReasons(p Permission) []string {
+	reasons := []string{}
+	if contains(p.Rule.Verbs, "*") {
+		reasons = append(reasons, "has all verbs")
+	}
+	if contains(p.Rule.Resources, "*") {
+		reasons = append(reasons, "has all resources")
+	}
+	for _, d := range dangerousRequests {
+		if d.clusterWide && len(p.Namespace) > 0 {
+			continue
+		}
+		for _, r := range d.requests {
+			r.Namespace = p.Namespace
+			if p.allows(r) {
+				reasons = append(reasons, d.reason)
+				break
+			}
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContextLines defines the number of unchanged lines shown around each change
+const ContextLines = 3
+
+type operation struct {
+	kind byte
+	text string
+}
+
+// Unified returns a unified diff of the lines in from and to.  An empty string
+// is returned if there are no differences
+func Unified(from string, to string, fromName string, toName string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	// record the line number in from and to before each operation
+	fromPos := make([]int, len(ops)+1)
+	toPos := make([]int, len(ops)+1)
+	changes := []int{}
+	for i, op := range ops {
+		fromPos[i+1], toPos[i+1] = fromPos[i], toPos[i]
+		if op.kind != '+' {
+			fromPos[i+1]++
+		}
+		if op.kind != '-' {
+			toPos[i+1]++
+		}
+		if op.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	for i := 0; i < len(changes); {
+		start := changes[i] - ContextLines
+		if start < 0 {
+			start = 0
+		}
+		last := changes[i]
+		for i++; i < len(changes) && changes[i]-last <= 2*ContextLines; i++ {
+			last = changes[i]
+		}
+		end := last + ContextLines + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		fromLen := fromPos[end] - fromPos[start]
+		toLen := toPos[end] - toPos[start]
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(fromPos[start], fromLen), hunkRange(toPos[start], toLen))
+		for _, op := range ops[start:end] {
+			fmt.Fprintf(&b, "%c%s\n", op.kind, op.text)
+		}
+	}
+
+	return b.String()
+}
+
+func hunkRange(pos int, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", pos)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", pos+1)
+	}
+	return fmt.Sprintf("%d,%d", pos+1, length)
+}
+
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes the operations needed to turn from into to using the
+// longest common subsequence of lines
+func diffLines(from []string, to []string) []operation {
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := []operation{}
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			ops = append(ops, operation{' ', from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, operation{'-', from[i]})
+			i++
+		default:
+			ops = append(ops, operation{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		ops = append(ops, operation{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		ops = append(ops, operation{'+', to[j]})
+	}
+
+	return ops
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (C) 2019 Synopsys, Inc.
+
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements. See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership. The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License. You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied. See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package diff
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		From     string
+		To       string
+		Expected string
+	}{
+		{
+			Name:     "no changes",
+			From:     "a\nb\n",
+			To:       "a\nb\n",
+			Expected: "",
+		},
+		{
+			Name:     "new file",
+			From:     "",
+			To:       "a\nb\n",
+			Expected: "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			Name:     "changed line",
+			From:     "a\nb\nc\n",
+			To:       "a\nx\nc\n",
+			Expected: "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			Name:     "separate hunks",
+			From:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			To:       "x\n2\n3\n4\n5\n6\n7\n8\n9\ny\n",
+			Expected: "--- from\n+++ to\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+y\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		if result := Unified(tc.From, tc.To, "from", "to"); result != tc.Expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tc.Name, tc.Expected, result)
+		}
+	}
+}
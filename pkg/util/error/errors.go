@@ -69,6 +69,43 @@ func (d deployErrors) Errors() map[api.ComponentType][]error {
 	return d
 }
 
+// RollbackErrors defines the errors of a deploy that was rolled back.  Errors
+// returns the errors that caused the rollback
+type RollbackErrors interface {
+	DeployErrors
+	RollbackErrors() map[api.ComponentType][]error
+}
+
+type rollbackErrors struct {
+	deployErrors
+	rollback deployErrors
+}
+
+// NewRollbackErrors creates a RollbackErrors from the errors that caused the
+// rollback and the errors that occurred while rolling back
+func NewRollbackErrors(errMap map[api.ComponentType][]error, rollbackErrMap map[api.ComponentType][]error) RollbackErrors {
+	errs := rollbackErrors{deployErrors: deployErrors{}, rollback: deployErrors{}}
+	for k, v := range errMap {
+		errs.deployErrors[k] = v
+	}
+	for k, v := range rollbackErrMap {
+		errs.rollback[k] = v
+	}
+	return errs
+}
+
+// Error implements the error interface Error function
+func (r rollbackErrors) Error() string {
+	if len(r.rollback) == 0 {
+		return fmt.Sprintf("deploy was rolled back: %s", r.deployErrors.Error())
+	}
+	return fmt.Sprintf("deploy failed: %s; rollback failed: %s", r.deployErrors.Error(), r.rollback.Error())
+}
+
+func (r rollbackErrors) RollbackErrors() map[api.ComponentType][]error {
+	return r.rollback
+}
+
 // ComponentErrorCount returns the count by component
 func ComponentErrorCount(errs error, component api.ComponentType) int {
 	if errs == nil {